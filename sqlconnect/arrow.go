@@ -0,0 +1,40 @@
+package sqlconnect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow/array"
+)
+
+// arrowQuerier is implemented by the package's DBs, for reading the results of queries as arrow records
+type arrowQuerier interface {
+	QueryArrow(ctx context.Context, query string, params ...any) (array.RecordReader, error)
+}
+
+// QueryArrow executes a query and returns a reader of its results as arrow record batches, for extracting large results without scanning them row by row.
+// The reader must be released once done, since it holds on to the query's connection:
+//   - snowflake reads the results natively using arrow batches, with integer columns widened to int64.
+//   - bigquery reads the results natively using the storage read api.
+//   - databricks reads the results natively using arrow batches.
+//
+// Other warehouses, e.g. postgres, mysql and trino, whose driver cannot return results as arrow records, convert the rows of the query using their column type mappings,
+// i.e. int columns become int64, float columns float64, boolean columns bool, datetime columns timestamps and any other column a string.
+// The same applies to statements other than read-only queries, as well as to snowflake's SHOW commands whose results are not available as arrow records,
+// which are executed again for converting their rows. Queries without results return a reader without records, whose schema follows the column type mappings.
+//
+// [ErrNotSupported] is returned if db is not a [DB] of this package, e.g. a [sql.DB], since its column type mappings are unknown.
+//
+//	reader, err := sqlconnect.QueryArrow(ctx, db, "SELECT * FROM events")
+//	defer reader.Release()
+//	for reader.Next() {
+//		record := reader.Record()
+//	}
+//	err = reader.Err()
+func QueryArrow(ctx context.Context, db QueryDB, query string, params ...any) (array.RecordReader, error) {
+	querier, ok := db.(arrowQuerier)
+	if !ok {
+		return nil, fmt.Errorf("querying arrow records: %w", ErrNotSupported)
+	}
+	return querier.QueryArrow(ctx, query, params...)
+}
@@ -0,0 +1,19 @@
+package sqlconnect_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQueryArrow(t *testing.T) {
+	db := sql.OpenDB(&countingConnector{rows: 1})
+	defer func() { _ = db.Close() }()
+
+	_, err := sqlconnect.QueryArrow(context.Background(), db, "SELECT")
+	require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should not support dbs without column type mappings")
+}
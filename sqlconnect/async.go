@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/rudderlabs/rudder-go-kit/async"
 )
@@ -55,12 +57,14 @@ func QueryAsync[T any](ctx context.Context, db QueryDB, mapper RowMapper[T], que
 			return
 		}
 		defer func() { _ = rows.Close() }()
+		var returned int64
+		defer func() { recordReturnedRows(ctx, db, query, returned) }()
 		cols, err := rows.ColumnTypes()
 		if err != nil {
 			s.Send(ValueOrError[T]{Err: fmt.Errorf("getting column types: %w", err)})
 			return
 		}
-		for rows.Next() {
+		for ; rows.Next(); returned++ {
 			select {
 			case <-ctx.Done():
 				s.Send(ValueOrError[T]{Err: ctx.Err()})
@@ -75,12 +79,184 @@ func QueryAsync[T any](ctx context.Context, db QueryDB, mapper RowMapper[T], que
 			s.Send(ValueOrError[T]{Value: v})
 		}
 		if err := rows.Err(); err != nil {
+			err = classifyError(db, err) // errors surfacing while iterating haven't been classified yet
 			s.Send(ValueOrError[T]{Err: fmt.Errorf("iterating rows: %w", err)})
 		}
 	}()
 	return ch, leave
 }
 
+// QueryBatchesAsync executes a query and returns a channel that will receive the results in batches of up to batchSize values or an error,
+// along with a function that the caller can use to leave the channel early. The channel will be closed when the query is done or when the context is canceled.
+//
+// A partial batch is only sent once the rows are exhausted, unless [WithMaxLatency] is provided, while [WithBufferSize] allows reading batches ahead of the receiver:
+//
+//	ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, db.JSONRowMapper(), 1000, "SELECT * FROM events WHERE day = ?", []any{day},
+//		sqlconnect.WithMaxLatency(time.Second), sqlconnect.WithBufferSize(4))
+func QueryBatchesAsync[T any](ctx context.Context, db QueryDB, mapper RowMapper[T], batchSize int, query string, params []any, opts ...Option) (ch <-chan ValueOrError[[]T], leave func()) {
+	options, err := NewQueryBatchesOptions(opts...)
+	if err == nil && batchSize <= 0 {
+		err = fmt.Errorf("invalid batch size: %d", batchSize)
+	}
+	s := &batchSender[T]{
+		size:       batchSize,
+		maxLatency: options.MaxLatency,
+		ch:         make(chan ValueOrError[[]T], options.BufferSize),
+	}
+	ctx, ch, leave = s.begin(ctx)
+	go func() {
+		defer s.close()
+		if err != nil {
+			s.sendError(err)
+			return
+		}
+		rows, err := db.QueryContext(ctx, query, params...)
+		if err != nil {
+			s.sendError(fmt.Errorf("executing query: %w", err))
+			return
+		}
+		defer func() { _ = rows.Close() }()
+		var returned int64
+		defer func() { recordReturnedRows(ctx, db, query, returned) }()
+		cols, err := rows.ColumnTypes()
+		if err != nil {
+			s.sendError(fmt.Errorf("getting column types: %w", err))
+			return
+		}
+		for ; rows.Next(); returned++ {
+			if err := ctx.Err(); err != nil {
+				s.sendError(err)
+				return
+			}
+			v, err := mapper(cols, rows)
+			if err != nil {
+				s.sendError(fmt.Errorf("mapping row: %w", err))
+				return
+			}
+			s.add(v)
+		}
+		if err := rows.Err(); err != nil {
+			err = classifyError(db, err) // errors surfacing while iterating haven't been classified yet
+			s.sendError(fmt.Errorf("iterating rows: %w", err))
+		}
+	}()
+	return ch, leave
+}
+
+// classifyError classifies an error returned by the rows of a query, if the db classifies errors
+func classifyError(db QueryDB, err error) error {
+	if classifier, ok := db.(interface{ ClassifyError(error) error }); ok {
+		return classifier.ClassifyError(err)
+	}
+	return err
+}
+
+// recordReturnedRows records the number of rows returned by the query, if the db reports metrics
+func recordReturnedRows(ctx context.Context, db QueryDB, query string, rows int64) {
+	if recorder, ok := db.(interface {
+		RecordReturnedRows(ctx context.Context, query string, rows int64)
+	}); ok {
+		recorder.RecordReturnedRows(ctx, query, rows)
+	}
+}
+
+// batchSender collects values into batches, sending them to a buffered channel with the same guarantees as [async.SingleSender].
+// Batches are sent either by the goroutine adding values once they are full, or by a timer once their first value has been waiting for the maximum latency.
+type batchSender[T any] struct {
+	size       int
+	maxLatency time.Duration
+	ch         chan ValueOrError[[]T]
+
+	ctxCancel     context.CancelFunc
+	sendCtx       context.Context
+	sendCtxCancel context.CancelFunc
+
+	sendMu sync.Mutex // serializes sending, so that batches are sent in the order they are collected
+	closed bool
+
+	batchMu sync.Mutex // guards the batch being collected and its timer
+	batch   []T
+	timer   *time.Timer
+}
+
+func (s *batchSender[T]) begin(parentCtx context.Context) (ctx context.Context, ch <-chan ValueOrError[[]T], leave func()) {
+	ctx, s.ctxCancel = context.WithCancel(parentCtx)
+	s.sendCtx, s.sendCtxCancel = context.WithCancel(context.Background())
+	return ctx, s.ch, s.sendCtxCancel
+}
+
+// add adds a value to the current batch, sending the batch if it is full
+func (s *batchSender[T]) add(v T) {
+	s.batchMu.Lock()
+	if len(s.batch) == 0 && s.maxLatency > 0 {
+		s.timer = time.AfterFunc(s.maxLatency, s.flush)
+	}
+	s.batch = append(s.batch, v)
+	full := len(s.batch) >= s.size
+	s.batchMu.Unlock()
+	if full {
+		s.flush()
+	}
+}
+
+// flush sends the current batch, if not empty
+func (s *batchSender[T]) flush() {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if batch := s.takeBatch(); len(batch) > 0 {
+		s.send(ValueOrError[[]T]{Value: batch})
+	}
+}
+
+// sendError sends the current batch, if not empty, followed by the error
+func (s *batchSender[T]) sendError(err error) {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if batch := s.takeBatch(); len(batch) > 0 {
+		s.send(ValueOrError[[]T]{Value: batch})
+	}
+	s.send(ValueOrError[[]T]{Err: err})
+}
+
+// takeBatch returns the current batch, replacing it with an empty one
+func (s *batchSender[T]) takeBatch() []T {
+	s.batchMu.Lock()
+	defer s.batchMu.Unlock()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	batch := s.batch
+	s.batch = make([]T, 0, s.size)
+	return batch
+}
+
+// send sends a value to the channel, unless it is closed or the receiver has left. It must be called while holding sendMu.
+func (s *batchSender[T]) send(v ValueOrError[[]T]) {
+	if s.closed {
+		return
+	}
+	select {
+	case <-s.sendCtx.Done():
+		s.ctxCancel()
+	case s.ch <- v:
+	}
+}
+
+// close sends the current batch, if not empty, closes the channel and cancels all related contexts
+func (s *batchSender[T]) close() {
+	s.flush()
+	s.ctxCancel()
+	s.sendCtxCancel()
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	close(s.ch)
+}
+
 // ValueOrError represents a value or an error
 type ValueOrError[T any] struct {
 	Value T
@@ -0,0 +1,164 @@
+package sqlconnect_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQueryBatchesAsync(t *testing.T) {
+	ctx := context.Background()
+	mapper := func(_ []*sql.ColumnType, row sqlconnect.RowScan) (v int64, err error) {
+		err = row.Scan(&v)
+		return v, err
+	}
+
+	t.Run("full and partial batches", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 5})
+		defer func() { _ = db.Close() }()
+
+		ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, mapper, 2, "SELECT", nil)
+		defer leave()
+		var batches [][]int64
+		for batch := range ch {
+			require.NoError(t, batch.Err)
+			batches = append(batches, batch.Value)
+		}
+		require.Equal(t, [][]int64{{1, 2}, {3, 4}, {5}}, batches)
+	})
+
+	t.Run("with max latency", func(t *testing.T) {
+		next := make(chan struct{})
+		db := sql.OpenDB(&countingConnector{rows: 3, next: next})
+		defer func() { _ = db.Close() }()
+
+		ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, mapper, 10, "SELECT", nil, sqlconnect.WithMaxLatency(10*time.Millisecond))
+		defer leave()
+		next <- struct{}{}
+		batch := <-ch
+		require.NoError(t, batch.Err)
+		require.Equal(t, []int64{1}, batch.Value, "it should send a partial batch once the max latency has elapsed")
+
+		next <- struct{}{}
+		next <- struct{}{}
+		close(next)
+		var values []int64
+		for batch := range ch {
+			require.NoError(t, batch.Err)
+			values = append(values, batch.Value...)
+		}
+		require.Equal(t, []int64{2, 3}, values, "it should send the remaining rows")
+	})
+
+	t.Run("with buffer size", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 3})
+		defer func() { _ = db.Close() }()
+
+		ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, mapper, 1, "SELECT", nil, sqlconnect.WithBufferSize(3))
+		defer leave()
+		require.Eventually(t, func() bool { return len(ch) == 3 }, time.Second, time.Millisecond, "it should read batches ahead of the receiver")
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 3, err: io.ErrUnexpectedEOF})
+		defer func() { _ = db.Close() }()
+
+		ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, mapper, 2, "SELECT", nil)
+		defer leave()
+		var batches []sqlconnect.ValueOrError[[]int64]
+		for batch := range ch {
+			batches = append(batches, batch)
+		}
+		require.Len(t, batches, 3)
+		require.Equal(t, []int64{1, 2}, batches[0].Value)
+		require.Equal(t, []int64{3}, batches[1].Value, "it should send the rows read before the error")
+		require.ErrorIs(t, batches[2].Err, io.ErrUnexpectedEOF)
+	})
+
+	t.Run("with leave", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 100})
+		defer func() { _ = db.Close() }()
+
+		ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, mapper, 1, "SELECT", nil)
+		<-ch
+		leave()
+		require.Eventually(t, func() bool { return db.Stats().InUse == 0 }, time.Second, time.Millisecond, "it should stop reading rows after leaving")
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		for _, tc := range []struct {
+			name      string
+			batchSize int
+			opts      []sqlconnect.Option
+		}{
+			{name: "invalid batch size", batchSize: 0},
+			{name: "invalid buffer size", batchSize: 1, opts: []sqlconnect.Option{sqlconnect.WithBufferSize(-1)}},
+			{name: "unsupported option", batchSize: 1, opts: []sqlconnect.Option{sqlconnect.WithBatchSize(1)}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				ch, leave := sqlconnect.QueryBatchesAsync(ctx, sql.OpenDB(&countingConnector{}), mapper, tc.batchSize, "SELECT", nil, tc.opts...)
+				defer leave()
+				batch := <-ch
+				require.Error(t, batch.Err)
+				_, ok := <-ch
+				require.False(t, ok, "it should close the channel")
+			})
+		}
+	})
+}
+
+// countingConnector creates connections whose queries return the given number of rows with increasing values, followed by err if not nil.
+// If next is not nil, each row is returned once a value is received from it.
+type countingConnector struct {
+	rows int64
+	err  error
+	next chan struct{}
+
+	lastCtx context.Context // the context of the last query
+}
+
+func (c *countingConnector) Connect(context.Context) (driver.Conn, error) {
+	return countingConn{c}, nil
+}
+func (c *countingConnector) Driver() driver.Driver { return nil }
+
+type countingConn struct{ connector *countingConnector }
+
+func (countingConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (countingConn) Close() error                        { return nil }
+func (countingConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (c countingConn) QueryContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.connector.lastCtx = ctx
+	return &countingRows{connector: c.connector}, nil
+}
+
+type countingRows struct {
+	connector *countingConnector
+	last      int64
+}
+
+func (r *countingRows) Columns() []string { return []string{"value"} }
+func (r *countingRows) Close() error      { return nil }
+func (r *countingRows) Next(dest []driver.Value) error {
+	if r.last == r.connector.rows {
+		if r.connector.err != nil {
+			return r.connector.err
+		}
+		return io.EOF
+	}
+	if r.connector.next != nil {
+		if _, ok := <-r.connector.next; !ok {
+			return io.EOF
+		}
+	}
+	r.last++
+	dest[0] = r.last
+	return nil
+}
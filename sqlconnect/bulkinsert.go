@@ -0,0 +1,21 @@
+package sqlconnect
+
+import "fmt"
+
+// InsertBatchError is returned by [BulkInserter.InsertRows] when a batch of rows could not be inserted.
+// All batches preceding the failed one have already been inserted, whereas none of the following batches have been attempted.
+type InsertBatchError struct {
+	Relation RelationRef // the relation rows were being inserted into
+	Batch    int         // zero-based index of the batch that failed
+	Offset   int         // number of rows that were successfully inserted before the failed batch
+	Size     int         // number of rows in the failed batch
+	Err      error       // the underlying error
+}
+
+func (e *InsertBatchError) Error() string {
+	return fmt.Sprintf("inserting batch %d (rows %d to %d) into %s: %v", e.Batch, e.Offset, e.Offset+e.Size-1, e.Relation, e.Err)
+}
+
+func (e *InsertBatchError) Unwrap() error {
+	return e.Err
+}
@@ -1,8 +1,55 @@
 package sqlconnect
 
+import "fmt"
+
 // ColumnRef provides a reference to a table column
 type ColumnRef struct {
 	Name    string `json:"name"`
 	Type    string `json:"type"`
 	RawType string `json:"rawType"`
+	// TypeTree is the parsed type of nested and semi-structured columns, e.g. arrays, structs, maps and variants.
+	// It is nil for scalar columns and for warehouses which don't report nested types.
+	TypeTree *TypeNode `json:"typeTree,omitempty"`
+}
+
+// ColumnDetails provides a column's reference along with additional metadata about it.
+// Details that are not reported by a warehouse are left empty.
+type ColumnDetails struct {
+	ColumnRef
+	Ordinal       int     `json:"ordinal"`                 // 1-based position of the column in the table
+	Nullable      bool    `json:"nullable"`                // whether the column accepts null values
+	Precision     *int64  `json:"precision,omitempty"`     // precision of numeric columns
+	Scale         *int64  `json:"scale,omitempty"`         // scale of numeric columns
+	MaxLength     *int64  `json:"maxLength,omitempty"`     // maximum length of character and binary columns
+	Default       *string `json:"default,omitempty"`       // default value expression of the column
+	Comment       string  `json:"comment,omitempty"`       // comment or description of the column
+	PrimaryKey    bool    `json:"primaryKey,omitempty"`    // whether the column is part of the table's primary key
+	PartitionKey  bool    `json:"partitionKey,omitempty"`  // whether the column is used for partitioning the table
+	ClusteringKey bool    `json:"clusteringKey,omitempty"` // whether the column is part of the table's clustering (or sort) key
+}
+
+// ColumnDef provides the definition of a table column to be created
+type ColumnDef struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`                 // rudder type, i.e. one of int, float, string, datetime, boolean, json
+	NotNull    bool   `json:"notNull,omitempty"`    // whether the column rejects null values
+	PrimaryKey bool   `json:"primaryKey,omitempty"` // whether the column is part of the table's primary key, implies [ColumnDef.NotNull]
+}
+
+// ColumnTypeChangeError is returned by [TableAdmin.AlterColumnType] when the warehouse doesn't support changing the type of a column in place.
+// It always wraps [ErrNotSupported].
+type ColumnTypeChangeError struct {
+	Relation RelationRef // the relation the column belongs to
+	Column   string      // the name of the column
+	FromType string      // the current rudder type of the column
+	ToType   string      // the requested rudder type of the column
+	Err      error       // the underlying error
+}
+
+func (e *ColumnTypeChangeError) Error() string {
+	return fmt.Sprintf("changing type of column %s in %s from %s to %s: %v", e.Column, e.Relation, e.FromType, e.ToType, e.Err)
+}
+
+func (e *ColumnTypeChangeError) Unwrap() error {
+	return e.Err
 }
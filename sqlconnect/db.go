@@ -6,6 +6,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"iter"
 	"time"
 
 	"github.com/rudderlabs/goqu/v10"
@@ -20,9 +21,29 @@ type DB interface {
 	sqlDB
 	// SqlDB returns the underlying *sql.DB
 	SqlDB() *sql.DB
+	// ClassifyError wraps an error returned by the underlying driver into the warehouse-agnostic error classes it belongs to, e.g. [ErrRelationNotFound].
+	// Errors returned by the DB's methods are already classified, thus this is only needed for errors returned by other types, e.g. [sql.Rows.Err], [sql.Tx.ExecContext]
+	// or [sql.Row.Scan], which reports the errors of QueryRowContext and QueryRow as they are neither intercepted nor classified.
+	ClassifyError(err error) error
+	// SetRetryPolicy sets the policy for retrying idempotent operations that fail with [ErrRetryable] errors. Retries are disabled by default.
+	//
+	//	db.SetRetryPolicy(sqlconnect.DefaultRetryPolicy)
+	SetRetryPolicy(policy RetryPolicy)
+	// SSHTunnel returns the ssh tunnel the db connects to its warehouse through, or nil if its credentials don't configure one
+	//
+	//	if tunnel := db.SSHTunnel(); tunnel != nil {
+	//		fingerprint := tunnel.HostKeyFingerprint() // e.g. for pinning the host key trusted on first use
+	//		tunnel.OnStatusChange(func(status sqlconnect.SSHTunnelStatus) {
+	//			log.Printf("ssh tunnel %s: %v", status.State, status.Err) // e.g. for reporting the tunnel's health
+	//		})
+	//	}
+	SSHTunnel() SSHTunnel
 	CatalogAdmin
 	SchemaAdmin
 	TableAdmin
+	BulkInserter
+	QueryEstimator
+	AsyncQuerier
 	JsonRowMapper
 	Dialect
 }
@@ -83,6 +104,18 @@ type SchemaAdmin interface {
 type TableAdmin interface {
 	// CreateTestTable creates a test table
 	CreateTestTable(ctx context.Context, relation RelationRef) error
+	// CreateTable creates a table with the provided columns, translating their rudder types to the native types of the warehouse.
+	// Columns flagged as primary keys form the table's primary key, which some warehouses don't enforce.
+	//
+	// Supported options:
+	//   - [WithIfNotExists]: don't fail if the table already exists.
+	//   - [WithPartitionBy]: partition the table by the given columns (bigquery by a single datetime column, databricks).
+	//   - [WithClusterBy]: cluster the table by the given columns (bigquery, databricks, snowflake, redshift as a sort key).
+	//
+	// If a type or an option is not supported by the warehouse, an error wrapping [ErrNotSupported] is returned, e.g. for boolean columns in mysql, which reports them as int.
+	//
+	//	err := db.CreateTable(ctx, table, []ColumnDef{{Name: "id", Type: "int", PrimaryKey: true}, {Name: "name", Type: "string"}}, WithIfNotExists())
+	CreateTable(ctx context.Context, relation RelationRef, columns []ColumnDef, opts ...Option) error
 	// ListTables returns a list of tables in the given schema.
 	//
 	// Supported options:
@@ -95,8 +128,23 @@ type TableAdmin interface {
 	TableExists(ctx context.Context, relation RelationRef) (bool, error)
 	// ListColumns returns a list of columns for the given table
 	ListColumns(ctx context.Context, relation RelationRef) ([]ColumnRef, error)
-	// ListColumnsForSqlQuery returns a list of columns for the given sql query
-	ListColumnsForSqlQuery(ctx context.Context, sql string) ([]ColumnRef, error)
+	// ListColumnDetails returns a list of columns for the given table along with their metadata, ordered by their position in the table.
+	// Support for each detail varies per warehouse:
+	//   - primary keys are not reported by redshift and trino.
+	//   - partitioning keys are only reported by bigquery and databricks.
+	//   - clustering keys are only reported by bigquery and redshift (sort keys).
+	//   - comments are not reported by trino.
+	ListColumnDetails(ctx context.Context, relation RelationRef) ([]ColumnDetails, error)
+	// ListColumnsForSqlQuery returns a list of columns for the given sql query, using the warehouse's metadata without executing the query:
+	//   - bigquery uses a dry-run job.
+	//   - snowflake describes the query without executing it.
+	//   - databricks uses DESCRIBE QUERY and trino uses PREPARE and DESCRIBE OUTPUT.
+	//   - postgres creates a temporary view for the query in a transaction which is rolled back.
+	//   - mysql and redshift wrap the query in a SELECT with LIMIT 0, which is only planned, along with postgres if the view can't be created.
+	//     Statements that can't be wrapped, e.g. SHOW statements, as well as mysql queries with duplicate column names, are executed instead.
+	//
+	// If the query cannot be described, the query is executed for listing its columns only if [WithExecuteFallback] is provided.
+	ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...Option) ([]ColumnRef, error)
 	// CountTableRows returns the number of rows in the given table
 	CountTableRows(ctx context.Context, table RelationRef) (count int, err error)
 	// DropTable drops a table
@@ -111,6 +159,78 @@ type TableAdmin interface {
 	CreateTableFromQuery(ctx context.Context, table RelationRef, query string) error
 	// GetRowCountForQuery returns the number of rows returned by the query
 	GetRowCountForQuery(ctx context.Context, query string, params ...any) (int, error)
+	// AddColumns adds the provided columns to an existing table, translating their rudder types to the native types of the warehouse.
+	// Primary key columns cannot be added.
+	AddColumns(ctx context.Context, relation RelationRef, columns []ColumnDef) error
+	// DropColumns drops the provided columns from a table
+	DropColumns(ctx context.Context, relation RelationRef, columns []string) error
+	// RenameColumn renames a column of a table
+	RenameColumn(ctx context.Context, relation RelationRef, oldName, newName string) error
+	// AlterColumnType changes the type of a column to the native type of the warehouse for the provided rudder type.
+	// It is a no-op if the column is already of the requested rudder type.
+	// If the warehouse cannot change the column's type in place, e.g. bigquery only allows widening an int column to float, a [ColumnTypeChangeError] is returned.
+	AlterColumnType(ctx context.Context, relation RelationRef, column, newType string) error
+	// MergeTable upserts the rows of the source table into the target table, matching rows on the provided key columns.
+	// Matched rows get their update columns overwritten by the source values, whereas rows that are not matched are inserted using both key and update columns.
+	// If no update columns are provided, matched rows are left untouched.
+	//
+	// The operation is performed using the native mechanism of each warehouse, e.g. MERGE INTO, INSERT ... ON CONFLICT (postgres) or INSERT ... ON DUPLICATE KEY UPDATE (mysql).
+	// Postgres and mysql require a unique constraint on the key columns of the target table, whereas redshift updates matched rows and inserts the rest in the same transaction.
+	//
+	//	err := db.MergeTable(ctx, target, staging, []string{"id"}, []string{"name", "updated_at"})
+	MergeTable(ctx context.Context, target, source RelationRef, keyColumns, updateColumns []string, opts ...Option) error
+}
+
+type BulkInserter interface {
+	// InsertRows inserts rows into the given table using the fastest method available for the warehouse.
+	// Each row must contain one value per column, in the same order as the provided columns.
+	// Rows are inserted in sequential batches and a failure to insert a batch results in an [InsertBatchError] being returned.
+	// Row slices are retained until their batch gets inserted, thus they shouldn't be reused by the iterator.
+	//
+	// Supported options:
+	//   - [WithBatchSize]: the maximum number of rows in each batch (defaults to [DefaultInsertBatchSize]).
+	//
+	//	err := db.InsertRows(ctx, table, []string{"id", "name"}, slices.Values(rows), WithBatchSize(500))
+	InsertRows(ctx context.Context, relation RelationRef, columns []string, rows iter.Seq[[]any], opts ...Option) error
+}
+
+type QueryEstimator interface {
+	// EstimateQuery returns an estimate of the rows, bytes and partitions the given sql query will process, using the warehouse's query planner without executing the query:
+	//   - bigquery uses a dry-run job, reporting the bytes processed.
+	//   - snowflake uses EXPLAIN USING JSON, reporting the bytes and micro-partitions assigned to the query.
+	//   - postgres, redshift, mysql, trino and databricks use EXPLAIN, reporting the planner's row (and, where available, byte) estimates.
+	//
+	// If the warehouse cannot estimate queries [ErrNotSupported] will be returned, as well as for sql containing multiple statements in warehouses using EXPLAIN.
+	//
+	//	estimate, err := db.EstimateQuery(ctx, "SELECT * FROM events")
+	//	if err == nil && estimate.Bytes != nil && *estimate.Bytes > budget {
+	//		return errors.New("query exceeds budget")
+	//	}
+	EstimateQuery(ctx context.Context, sql string) (QueryEstimate, error)
+}
+
+type AsyncQuerier interface {
+	// SubmitQuery submits a query to the warehouse without waiting for it to complete, returning a handle for tracking it and fetching its results later on.
+	// The query keeps running in the warehouse after the context is done or the db is closed:
+	//   - snowflake submits the query in async mode.
+	//   - bigquery creates a query job.
+	//   - redshift submits a statement using the data api.
+	//
+	// If the warehouse cannot run queries asynchronously [ErrNotSupported] will be returned, e.g. for postgres, mysql, trino, databricks and redshift when not using the data api.
+	// Even though databricks and trino report the ids of their queries, their drivers cannot fetch the results of a query by id, thus its results would be lost.
+	//
+	//	handle, err := db.SubmitQuery(ctx, "INSERT INTO events_daily SELECT * FROM events WHERE day = ?", day)
+	//	b, err := json.Marshal(handle) // store the handle for resuming later on
+	SubmitQuery(ctx context.Context, sql string, params ...any) (QueryHandle, error)
+	// QueryStatus returns the status of a submitted query. The error the query has failed with, if any, is reported in the status and not returned.
+	QueryStatus(ctx context.Context, handle QueryHandle) (QueryStatus, error)
+	// FetchResults waits for a submitted query to complete and returns its results, or the error it has failed with
+	FetchResults(ctx context.Context, handle QueryHandle) (*sql.Rows, error)
+	// CancelQuery cancels a query in the warehouse, e.g. a query submitted by another process whose handle has been stored.
+	// Queries performed by the db are already canceled in the warehouse as soon as their context is done.
+	//
+	// If the warehouse cannot run queries asynchronously [ErrNotSupported] will be returned.
+	CancelQuery(ctx context.Context, handle QueryHandle) error
 }
 
 type JsonRowMapper interface {
@@ -1,23 +1,44 @@
 package sqlconnect
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 )
 
 // NewDB creates a new database client for the provided name.
 func NewDB(name string, credentialsJSON json.RawMessage) (DB, error) {
+	return NewDBContext(context.Background(), name, credentialsJSON)
+}
+
+// NewDBContext creates a new database client for the provided name.
+// The client's setup, e.g. host validation, ssh tunnel dialling and dialect probing, is aborted if the context is done before it completes.
+// The context is only used during setup, thus cancelling it afterwards doesn't affect the client.
+func NewDBContext(ctx context.Context, name string, credentialsJSON json.RawMessage) (DB, error) {
 	factory, ok := dbfactories[name]
 	if !ok {
 		return nil, fmt.Errorf("unknown client factory: %s", name)
 	}
-	return factory(credentialsJSON)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return factory(ctx, credentialsJSON)
 }
 
 type DBFactory func(credentialsJSON json.RawMessage) (DB, error)
 
-var dbfactories = map[string]DBFactory{}
+// DBContextFactory is a [DBFactory] whose setup respects the provided context
+type DBContextFactory func(ctx context.Context, credentialsJSON json.RawMessage) (DB, error)
+
+var dbfactories = map[string]DBContextFactory{}
 
 func RegisterDBFactory(name string, factory DBFactory) {
+	RegisterDBContextFactory(name, func(_ context.Context, credentialsJSON json.RawMessage) (DB, error) {
+		return factory(credentialsJSON)
+	})
+}
+
+// RegisterDBContextFactory registers a factory whose setup respects the context provided to [NewDBContext]
+func RegisterDBContextFactory(name string, factory DBContextFactory) {
 	dbfactories[name] = factory
 }
@@ -1,6 +1,8 @@
 package sqlconnect_test
 
 import (
+	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,3 +14,27 @@ func TestNewDB(t *testing.T) {
 	_, err := sqlconnect.NewDB("invalid", []byte{})
 	require.Error(t, err, "should return error for invalid db name")
 }
+
+func TestNewDBContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("with context factory", func(t *testing.T) {
+		sqlconnect.RegisterDBContextFactory("test-context-factory", func(ctx context.Context, _ json.RawMessage) (sqlconnect.DB, error) {
+			return nil, ctx.Err()
+		})
+		_, err := sqlconnect.NewDBContext(context.Background(), "test-context-factory", []byte{})
+		require.NoError(t, err)
+	})
+
+	t.Run("with cancelled context", func(t *testing.T) {
+		var called bool
+		sqlconnect.RegisterDBFactory("test-factory", func(json.RawMessage) (sqlconnect.DB, error) {
+			called = true
+			return nil, nil
+		})
+		_, err := sqlconnect.NewDBContext(ctx, "test-factory", []byte{})
+		require.ErrorIs(t, err, context.Canceled, "it should not create the db with a cancelled context")
+		require.False(t, called)
+	})
+}
@@ -0,0 +1,22 @@
+package sqlconnect
+
+import "errors"
+
+// Warehouse-agnostic classes of errors. Errors returned by a [DB] wrap the underlying driver errors into the classes they belong to, so that they can be checked using [errors.Is] regardless of the warehouse, e.g.
+//
+//	if errors.Is(err, sqlconnect.ErrRelationNotFound) {
+//		// create the table
+//	}
+//
+// An error may belong to more than one class, e.g. an exceeded rate limit is both [ErrQuotaExceeded] and [ErrRetryable], whereas the original driver error is still available through [errors.As].
+// Errors reported by the types a [DB] returns, e.g. the [sql.Row] returned by [DB.QueryRowContext], are not classified, see [DB.ClassifyError].
+var (
+	ErrAuthentication   = errors.New("sqlconnect: authentication failed")           // the credentials are invalid or expired
+	ErrPermissionDenied = errors.New("sqlconnect: permission denied")               // the credentials lack the privileges required for the operation
+	ErrRelationNotFound = errors.New("sqlconnect: relation not found")              // a table or view doesn't exist
+	ErrSchemaNotFound   = errors.New("sqlconnect: schema not found")                // a schema, or the catalog it belongs to, doesn't exist
+	ErrQueryTimeout     = errors.New("sqlconnect: query timed out")                 // the query exceeded a client or warehouse time limit
+	ErrSyntax           = errors.New("sqlconnect: syntax error")                    // the query is not syntactically valid
+	ErrQuotaExceeded    = errors.New("sqlconnect: quota exceeded")                  // a quota, rate limit or resource limit of the warehouse was exceeded
+	ErrRetryable        = errors.New("sqlconnect: transient error, retry possible") // the operation failed due to a transient condition and can be retried
+)
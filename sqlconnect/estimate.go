@@ -0,0 +1,11 @@
+package sqlconnect
+
+// QueryEstimate is an estimate of the work a query will perform, as reported by the warehouse's query planner without executing the query.
+// Estimates that are not reported by a warehouse are left nil.
+type QueryEstimate struct {
+	Rows       *int64   `json:"rows,omitempty"`       // estimated number of rows returned by the query
+	Bytes      *int64   `json:"bytes,omitempty"`      // estimated number of bytes scanned (or billed) by the query
+	Partitions *int64   `json:"partitions,omitempty"` // number of partitions (or micro-partitions) the query will scan
+	Cost       *float64 `json:"cost,omitempty"`       // planner cost of the query, in warehouse-specific units which are only comparable across queries of the same warehouse
+	Plan       string   `json:"plan"`                 // the raw plan, as returned by the warehouse
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// csvWriter writes rows as CSV records, with null values written as empty fields, timestamps in RFC 3339 format and json values, e.g. snowflake's VARIANT columns, as json
+type csvWriter struct {
+	delimiter rune
+	quoting   Quoting
+	header    bool
+
+	columns []string
+	fields  []string
+}
+
+func (cw *csvWriter) begin(w io.Writer, columns []string) error {
+	cw.columns = columns
+	cw.fields = make([]string, len(columns))
+	if !cw.header {
+		return nil
+	}
+	return cw.writeRecord(w, columns)
+}
+
+func (cw *csvWriter) write(w io.Writer, row map[string]any) error {
+	for i, column := range cw.columns {
+		field, err := csvField(row[column])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", column, err)
+		}
+		cw.fields[i] = field
+	}
+	return cw.writeRecord(w, cw.fields)
+}
+
+func (cw *csvWriter) writeRecord(w io.Writer, fields []string) error {
+	var sb strings.Builder
+	for i, field := range fields {
+		if i > 0 {
+			sb.WriteRune(cw.delimiter)
+		}
+		if cw.quoting != QuoteAll && !cw.needsQuotes(field) {
+			sb.WriteString(field)
+			continue
+		}
+		sb.WriteByte('"')
+		sb.WriteString(strings.ReplaceAll(field, `"`, `""`))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('\n')
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// needsQuotes reports whether a field needs to be quoted, following the same rules as [encoding/csv]
+func (cw *csvWriter) needsQuotes(field string) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, cw.delimiter) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+// csvField formats a value of a json row mapper as a CSV field
+func csvField(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case json.RawMessage:
+		return string(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("marshalling %T: %w", value, err)
+	}
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s, nil // e.g. decimals marshalled as json strings
+	}
+	return string(b), nil
+}
@@ -0,0 +1,232 @@
+// Package export streams the results of queries to writers as CSV, NDJSON or Parquet files.
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// Format is the file format of an export
+type Format string
+
+const (
+	// FormatCSV exports rows as comma-separated values, using the values of [sqlconnect.JsonRowMapper]. Results with duplicate column names are not supported.
+	FormatCSV Format = "csv"
+	// FormatNDJSON exports rows as newline-delimited json objects, using the values of [sqlconnect.JsonRowMapper]. Results with duplicate column names are not supported.
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet exports rows as a snappy-compressed parquet file, using the arrow records of [sqlconnect.QueryArrow].
+	// Its columns are typed after the column types of the warehouse, e.g. int columns become INT64 columns and datetime columns TIMESTAMP columns.
+	FormatParquet Format = "parquet"
+)
+
+// DB is the subset of [sqlconnect.DB] used for exporting query results
+type DB interface {
+	sqlconnect.JsonQueryDB
+}
+
+// Stats are the statistics of a completed export
+type Stats struct {
+	Rows   int64        // the number of rows exported
+	Bytes  int64        // the number of bytes written
+	Chunks []ChunkStats // the statistics of each chunk, in the order they were written
+}
+
+// ChunkStats are the statistics of a single chunk of an export
+type ChunkStats struct {
+	Rows  int64 // the number of rows written to the chunk
+	Bytes int64 // the number of bytes written to the chunk
+}
+
+// ExportQuery executes a query and streams its results to w in the given format, returning the number of rows and bytes written once done.
+//
+// The output can be split into chunks using [WithChunks], in which case every chunk is a complete file of its own, e.g. each CSV chunk has a header and each Parquet chunk a footer.
+//
+//	f, err := os.Create("events.csv")
+//	stats, err := export.ExportQuery(ctx, db, "SELECT * FROM events", nil, f, export.FormatCSV, export.WithCSVDelimiter(';'))
+func ExportQuery(ctx context.Context, db DB, query string, args []any, w io.Writer, format Format, opts ...Option) (Stats, error) {
+	o, err := newOptions(format, opts...)
+	if err != nil {
+		return Stats{}, err
+	}
+	c := newChunks(w, o)
+	switch format {
+	case FormatCSV:
+		err = exportRows(ctx, db, query, args, c, &csvWriter{delimiter: o.csvDelimiter, quoting: o.csvQuoting, header: o.csvHeader})
+	case FormatNDJSON:
+		err = exportRows(ctx, db, query, args, c, &ndjsonWriter{})
+	case FormatParquet:
+		err = exportParquet(ctx, db, query, args, c)
+	}
+	if err != nil {
+		c.abort()
+		return c.stats, fmt.Errorf("exporting query as %s: %w", format, err)
+	}
+	if err := c.finish(); err != nil {
+		return c.stats, fmt.Errorf("exporting query as %s: %w", format, err)
+	}
+	return c.stats, nil
+}
+
+// rowWriter writes the rows of a query to a chunk, one at a time
+type rowWriter interface {
+	// begin starts a new chunk
+	begin(w io.Writer, columns []string) error
+	// write writes a row to the current chunk
+	write(w io.Writer, row map[string]any) error
+}
+
+// exportRows exports the results of a query row by row, using the values of the db's json row mapper
+func exportRows(ctx context.Context, db DB, query string, args []any, c *chunks, rw rowWriter) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("getting column types: %w", err)
+	}
+	columns := make([]string, len(cols))
+	for i, col := range cols {
+		columns[i] = col.Name()
+		if slices.Contains(columns[:i], columns[i]) { // the json row mapper maps values by column name, thus the values of duplicate columns would be mixed up
+			return fmt.Errorf("duplicate column %q: %w", columns[i], sqlconnect.ErrNotSupported)
+		}
+	}
+	mapper := db.JSONRowMapper()
+	if err := rw.begin(c.w, columns); err != nil {
+		return err
+	}
+	for rows.Next() {
+		if c.full() {
+			if err := c.next(); err != nil {
+				return err
+			}
+			if err := rw.begin(c.w, columns); err != nil {
+				return err
+			}
+		}
+		row, err := mapper(cols, rows)
+		if err != nil {
+			return fmt.Errorf("mapping row: %w", err)
+		}
+		if err := rw.write(c.w, row); err != nil {
+			return fmt.Errorf("writing row: %w", err)
+		}
+		c.addRows(1)
+	}
+	return rows.Err()
+}
+
+// chunks keeps track of the chunk being written and the statistics of the export
+type chunks struct {
+	w        *bufio.Writer // the buffered writer of the current chunk
+	cw       *countingWriter
+	closer   io.Closer // closes the current chunk, if it was opened using nextChunk
+	maxRows  int64
+	maxBytes int64
+	nextFn   func(chunk int) (io.WriteCloser, error)
+	stats    Stats
+}
+
+func newChunks(w io.Writer, o *options) *chunks {
+	cw := &countingWriter{w: w}
+	return &chunks{
+		w:        bufio.NewWriter(cw),
+		cw:       cw,
+		maxRows:  o.chunkRows,
+		maxBytes: o.chunkBytes,
+		nextFn:   o.nextChunk,
+		stats:    Stats{Chunks: []ChunkStats{{}}},
+	}
+}
+
+// current returns the statistics of the current chunk
+func (c *chunks) current() *ChunkStats {
+	return &c.stats.Chunks[len(c.stats.Chunks)-1]
+}
+
+// bytes returns the number of bytes written to the current chunk, including the buffered ones
+func (c *chunks) bytes() int64 {
+	return c.cw.n + int64(c.w.Buffered())
+}
+
+// remainingRows returns the number of rows the current chunk can still hold, or -1 if rows are not limited
+func (c *chunks) remainingRows() int64 {
+	if c.maxRows <= 0 {
+		return -1
+	}
+	return max(c.maxRows-c.current().Rows, 0)
+}
+
+// full returns whether the current chunk has reached its row or byte limit, which chunks without rows never do, e.g. if their header alone exceeds the byte limit
+func (c *chunks) full() bool {
+	if c.current().Rows == 0 {
+		return false
+	}
+	return c.remainingRows() == 0 || (c.maxBytes > 0 && c.bytes() >= c.maxBytes)
+}
+
+func (c *chunks) addRows(n int64) {
+	c.current().Rows += n
+	c.stats.Rows += n
+}
+
+// next finishes the current chunk and starts a new one
+func (c *chunks) next() error {
+	if err := c.finish(); err != nil {
+		return err
+	}
+	chunk := len(c.stats.Chunks)
+	w, err := c.nextFn(chunk)
+	if err != nil {
+		return fmt.Errorf("opening chunk %d: %w", chunk, err)
+	}
+	c.cw = &countingWriter{w: w}
+	c.w.Reset(c.cw)
+	c.closer = w
+	c.stats.Chunks = append(c.stats.Chunks, ChunkStats{})
+	return nil
+}
+
+// finish flushes the current chunk and closes it, if it was opened using nextChunk
+func (c *chunks) finish() error {
+	if err := c.w.Flush(); err != nil {
+		return err
+	}
+	c.current().Bytes = c.cw.n
+	c.stats.Bytes += c.cw.n
+	if c.closer != nil {
+		closer := c.closer
+		c.closer = nil
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("closing chunk %d: %w", len(c.stats.Chunks)-1, err)
+		}
+	}
+	return nil
+}
+
+// abort closes the current chunk without flushing it, after a failed export
+func (c *chunks) abort() {
+	if c.closer != nil {
+		_ = c.closer.Close()
+		c.closer = nil
+	}
+}
+
+// countingWriter counts the bytes written to the underlying writer
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
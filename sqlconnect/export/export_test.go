@@ -0,0 +1,245 @@
+package export_test
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/export"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+func TestExportQuery(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	db := newFakeDB(t, [][]driver.Value{
+		{int64(1), "plain", createdAt},
+		{int64(2), `with "quotes", and commas`, createdAt},
+		{int64(3), nil, nil},
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		stats, err := export.ExportQuery(ctx, db, "SELECT", nil, &buf, export.FormatCSV)
+		require.NoError(t, err)
+		require.Equal(t, "id,name,created_at\n"+
+			"1,plain,2024-01-02T03:04:05.000006Z\n"+
+			`2,"with ""quotes"", and commas",2024-01-02T03:04:05.000006Z`+"\n"+
+			"3,,\n", buf.String())
+		require.Equal(t, export.Stats{Rows: 3, Bytes: int64(buf.Len()), Chunks: []export.ChunkStats{{Rows: 3, Bytes: int64(buf.Len())}}}, stats)
+	})
+
+	t.Run("csv with options", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := export.ExportQuery(ctx, db, "SELECT", nil, &buf, export.FormatCSV,
+			export.WithCSVDelimiter(';'),
+			export.WithCSVQuoting(export.QuoteAll),
+			export.WithCSVHeader(false),
+		)
+		require.NoError(t, err)
+		require.Equal(t, `"1";"plain";"2024-01-02T03:04:05.000006Z"`+"\n"+
+			`"2";"with ""quotes"", and commas";"2024-01-02T03:04:05.000006Z"`+"\n"+
+			`"3";"";""`+"\n", buf.String())
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		stats, err := export.ExportQuery(ctx, db, "SELECT", nil, &buf, export.FormatNDJSON)
+		require.NoError(t, err)
+		require.Equal(t, `{"id":1,"name":"plain","created_at":"2024-01-02T03:04:05.000006Z"}`+"\n"+
+			`{"id":2,"name":"with \"quotes\", and commas","created_at":"2024-01-02T03:04:05.000006Z"}`+"\n"+
+			`{"id":3,"name":null,"created_at":null}`+"\n", buf.String(), "it should keep the order of the columns")
+		require.EqualValues(t, 3, stats.Rows)
+		require.EqualValues(t, buf.Len(), stats.Bytes)
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		var buf bytes.Buffer
+		stats, err := export.ExportQuery(ctx, db, "SELECT", nil, &buf, export.FormatParquet)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, stats.Rows)
+		require.EqualValues(t, buf.Len(), stats.Bytes)
+
+		table := readParquet(t, buf.Bytes())
+		defer table.Release()
+		require.Equal(t, arrow.PrimitiveTypes.Int64, table.Schema().Field(0).Type, "it should use the column types")
+		require.Equal(t, arrow.BinaryTypes.String, table.Schema().Field(1).Type)
+		require.Equal(t, arrow.FixedWidthTypes.Timestamp_us, table.Schema().Field(2).Type)
+		require.EqualValues(t, 3, table.NumRows())
+		require.Equal(t, []int64{1, 2, 3}, table.Column(0).Data().Chunk(0).(*array.Int64).Int64Values())
+		require.Equal(t, `["plain" "with \"quotes\", and commas" (null)]`, table.Column(1).Data().Chunk(0).String())
+		require.Equal(t, arrow.Timestamp(createdAt.UnixMicro()), table.Column(2).Data().Chunk(0).(*array.Timestamp).Value(0))
+	})
+
+	t.Run("with chunks", func(t *testing.T) {
+		for _, tc := range []struct {
+			name     string
+			format   export.Format
+			maxRows  int64
+			maxBytes int64
+			rows     []int64
+		}{
+			{name: "csv by rows", format: export.FormatCSV, maxRows: 2, rows: []int64{2, 1}},
+			{name: "ndjson by bytes", format: export.FormatNDJSON, maxBytes: 1, rows: []int64{1, 1, 1}},
+			{name: "parquet by rows", format: export.FormatParquet, maxRows: 2, rows: []int64{2, 1}},
+			{name: "parquet by bytes", format: export.FormatParquet, maxBytes: 1, rows: []int64{3}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				chunks := []*bufferCloser{{}}
+				stats, err := export.ExportQuery(ctx, db, "SELECT", nil, chunks[0], tc.format, export.WithChunks(tc.maxRows, tc.maxBytes, func(chunk int) (io.WriteCloser, error) {
+					require.Equal(t, len(chunks), chunk)
+					chunks = append(chunks, &bufferCloser{})
+					return chunks[chunk], nil
+				}))
+				require.NoError(t, err)
+				require.EqualValues(t, 3, stats.Rows)
+				require.Len(t, stats.Chunks, len(tc.rows))
+				var bytes int64
+				for i, chunk := range chunks {
+					require.Equal(t, tc.rows[i], stats.Chunks[i].Rows)
+					require.EqualValues(t, chunk.Len(), stats.Chunks[i].Bytes)
+					require.Equal(t, i > 0, chunk.closed, "it should close the writers of all chunks but the first")
+					bytes += stats.Chunks[i].Bytes
+				}
+				require.Equal(t, bytes, stats.Bytes)
+
+				if tc.format == export.FormatCSV {
+					require.Equal(t, "id,name,created_at\n3,,\n", chunks[1].String(), "it should repeat the header")
+				}
+				if tc.format == export.FormatParquet {
+					for i, chunk := range chunks {
+						table := readParquet(t, chunk.Bytes())
+						require.Equal(t, tc.rows[i], table.NumRows(), "it should write a complete parquet file per chunk")
+						table.Release()
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("without rows", func(t *testing.T) {
+		db := newFakeDB(t, nil)
+		var buf bytes.Buffer
+		stats, err := export.ExportQuery(ctx, db, "SELECT", nil, &buf, export.FormatParquet)
+		require.NoError(t, err)
+		require.Zero(t, stats.Rows)
+		table := readParquet(t, buf.Bytes())
+		defer table.Release()
+		require.Zero(t, table.NumRows())
+		require.Equal(t, 3, table.Schema().NumFields(), "it should write the schema")
+	})
+
+	t.Run("with duplicate columns", func(t *testing.T) {
+		db := base.NewDB(sql.OpenDB(fakeConnector{columns: []string{"id", "id", "created_at"}}), func() error { return nil })
+		t.Cleanup(func() { _ = db.Close() })
+		for _, format := range []export.Format{export.FormatCSV, export.FormatNDJSON} {
+			_, err := export.ExportQuery(ctx, db, "SELECT", nil, io.Discard, format)
+			require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should reject %s exports of results with duplicate columns, since their values are mapped by name", format)
+			require.ErrorContains(t, err, `duplicate column "id"`)
+		}
+	})
+
+	t.Run("with invalid options", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			format export.Format
+			opts   []export.Option
+			err    string
+		}{
+			{name: "unsupported format", format: "xml", err: `unsupported export format: "xml"`},
+			{name: "invalid delimiter", format: export.FormatCSV, opts: []export.Option{export.WithCSVDelimiter('"')}, err: "invalid csv delimiter"},
+			{name: "csv option", format: export.FormatNDJSON, opts: []export.Option{export.WithCSVHeader(false)}, err: "csv options are not supported for ndjson exports"},
+			{name: "negative chunk limit", format: export.FormatCSV, opts: []export.Option{export.WithChunks(-1, 0, nil)}, err: "chunk limits cannot be negative"},
+			{name: "chunks without writers", format: export.FormatCSV, opts: []export.Option{export.WithChunks(1, 0, nil)}, err: "chunks require a function opening their writers"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := export.ExportQuery(ctx, db, "SELECT", nil, io.Discard, tc.format, tc.opts...)
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+}
+
+func readParquet(t *testing.T, b []byte) arrow.Table {
+	t.Helper()
+	table, err := pqarrow.ReadTable(context.Background(), bytes.NewReader(b), parquet.NewReaderProperties(memory.DefaultAllocator), pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	require.NoError(t, err)
+	return table
+}
+
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
+// newFakeDB returns a db whose queries return the given rows, with an id INT, a name TEXT and a created_at TIMESTAMP column
+func newFakeDB(t *testing.T, rows [][]driver.Value) *base.DB {
+	db := base.NewDB(sql.OpenDB(fakeConnector{rows: rows}), func() error { return nil }, base.WithColumnTypeMappings(map[string]string{
+		"INT":       "int",
+		"TEXT":      "string",
+		"TIMESTAMP": "datetime",
+	}))
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+type fakeConnector struct {
+	rows    [][]driver.Value
+	columns []string // the columns of the rows, if other than id, name and created_at
+}
+
+func (c fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn(c), nil }
+func (fakeConnector) Driver() driver.Driver                          { return nil }
+
+type fakeConn struct {
+	rows    [][]driver.Value
+	columns []string
+}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (c fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return &fakeRows{rows: c.rows, columns: c.columns}, nil
+}
+
+type fakeRows struct {
+	rows    [][]driver.Value
+	columns []string
+}
+
+func (r *fakeRows) Columns() []string {
+	if r.columns != nil {
+		return r.columns
+	}
+	return []string{"id", "name", "created_at"}
+}
+func (r *fakeRows) ColumnTypeDatabaseTypeName(index int) string {
+	return []string{"INT", "TEXT", "TIMESTAMP"}[index]
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ndjsonWriter writes rows as json objects, one per line, keeping the order of the columns
+type ndjsonWriter struct {
+	keys [][]byte // the json-encoded column names
+	buf  bytes.Buffer
+
+	columns []string
+}
+
+func (nw *ndjsonWriter) begin(_ io.Writer, columns []string) error {
+	if nw.columns != nil {
+		return nil // the same columns are used by every chunk
+	}
+	nw.columns = columns
+	nw.keys = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(column)
+		if err != nil {
+			return fmt.Errorf("marshalling column %s: %w", column, err)
+		}
+		nw.keys[i] = key
+	}
+	return nil
+}
+
+func (nw *ndjsonWriter) write(w io.Writer, row map[string]any) error {
+	nw.buf.Reset()
+	nw.buf.WriteByte('{')
+	for i, column := range nw.columns {
+		if i > 0 {
+			nw.buf.WriteByte(',')
+		}
+		nw.buf.Write(nw.keys[i])
+		nw.buf.WriteByte(':')
+		value, err := json.Marshal(row[column])
+		if err != nil {
+			return fmt.Errorf("marshalling column %s: %w", column, err)
+		}
+		nw.buf.Write(value)
+	}
+	nw.buf.WriteString("}\n")
+	_, err := w.Write(nw.buf.Bytes())
+	return err
+}
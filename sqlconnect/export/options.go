@@ -0,0 +1,103 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf8"
+)
+
+// Quoting controls which CSV fields are quoted
+type Quoting int
+
+const (
+	// QuoteMinimal only quotes fields containing the delimiter, quotes, line breaks or leading spaces
+	QuoteMinimal Quoting = iota
+	// QuoteAll quotes all fields, including the header
+	QuoteAll
+)
+
+// Option is a function that configures an export
+type Option func(*options)
+
+type options struct {
+	csvDelimiter    rune
+	csvDelimiterSet bool
+	csvQuoting      Quoting
+	csvQuotingSet   bool
+	csvHeader       bool
+	csvHeaderSet    bool
+
+	chunkRows  int64
+	chunkBytes int64
+	nextChunk  func(chunk int) (io.WriteCloser, error)
+}
+
+// WithCSVDelimiter sets the delimiter of CSV fields, which defaults to a comma
+func WithCSVDelimiter(delimiter rune) Option {
+	return func(o *options) {
+		o.csvDelimiter = delimiter
+		o.csvDelimiterSet = true
+	}
+}
+
+// WithCSVQuoting sets which CSV fields are quoted, which defaults to [QuoteMinimal]
+func WithCSVQuoting(quoting Quoting) Option {
+	return func(o *options) {
+		o.csvQuoting = quoting
+		o.csvQuotingSet = true
+	}
+}
+
+// WithCSVHeader sets whether the CSV output starts with a header of the column names, which defaults to true
+func WithCSVHeader(header bool) Option {
+	return func(o *options) {
+		o.csvHeader = header
+		o.csvHeaderSet = true
+	}
+}
+
+// WithChunks splits the output into chunks of up to maxRows rows and about maxBytes bytes, with zero meaning no limit.
+// The first chunk is written to the export's writer, while next is called for opening the writer of every following chunk, which gets closed once the chunk is complete.
+// A chunk may exceed maxBytes by up to a row, or a record batch for Parquet, since its size is checked after writing each of them.
+//
+//	export.WithChunks(1_000_000, 0, func(chunk int) (io.WriteCloser, error) {
+//		return os.Create(fmt.Sprintf("events-%d.csv", chunk))
+//	})
+func WithChunks(maxRows, maxBytes int64, next func(chunk int) (io.WriteCloser, error)) Option {
+	return func(o *options) {
+		o.chunkRows = maxRows
+		o.chunkBytes = maxBytes
+		o.nextChunk = next
+	}
+}
+
+// newOptions applies the options, validating them against the format of the export
+func newOptions(format Format, opts ...Option) (*options, error) {
+	o := &options{csvDelimiter: ',', csvHeader: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+	switch format {
+	case FormatCSV:
+		if o.csvDelimiter == '"' || o.csvDelimiter == '\r' || o.csvDelimiter == '\n' || !utf8.ValidRune(o.csvDelimiter) || o.csvDelimiter == utf8.RuneError {
+			return nil, fmt.Errorf("invalid csv delimiter: %q", o.csvDelimiter)
+		}
+		if o.csvQuoting != QuoteMinimal && o.csvQuoting != QuoteAll {
+			return nil, fmt.Errorf("invalid csv quoting: %d", o.csvQuoting)
+		}
+	case FormatNDJSON, FormatParquet:
+		if o.csvDelimiterSet || o.csvQuotingSet || o.csvHeaderSet {
+			return nil, fmt.Errorf("csv options are not supported for %s exports", format)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported export format: %q", format)
+	}
+	if o.chunkRows < 0 || o.chunkBytes < 0 {
+		return nil, errors.New("chunk limits cannot be negative")
+	}
+	if (o.chunkRows > 0 || o.chunkBytes > 0) && o.nextChunk == nil {
+		return nil, errors.New("chunks require a function opening their writers")
+	}
+	return o, nil
+}
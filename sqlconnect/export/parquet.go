@@ -0,0 +1,104 @@
+package export
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// exportParquet exports the results of a query as parquet files, writing a row group for every arrow record read
+func exportParquet(ctx context.Context, db DB, query string, args []any, c *chunks) error {
+	reader, err := sqlconnect.QueryArrow(ctx, db, query, args...)
+	if err != nil {
+		return err
+	}
+	defer reader.Release()
+	pw := &parquetWriter{schema: reader.Schema(), c: c}
+	if err := pw.begin(); err != nil {
+		return err
+	}
+	defer pw.abort()
+	for reader.Next() {
+		if err := pw.write(reader.Record()); err != nil {
+			return err
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return err
+	}
+	return pw.end()
+}
+
+// parquetWriter writes arrow records to the chunks of an export, as a parquet file per chunk
+type parquetWriter struct {
+	schema *arrow.Schema
+	c      *chunks
+	fw     *pqarrow.FileWriter // the writer of the current chunk
+}
+
+// begin starts a snappy-compressed parquet file for the current chunk, storing the arrow schema for keeping details such as timestamp zones
+func (pw *parquetWriter) begin() error {
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	fw, err := pqarrow.NewFileWriter(pw.schema, pw.c.w, props, pqarrow.NewArrowWriterProperties(pqarrow.WithStoreSchema()))
+	if err != nil {
+		return fmt.Errorf("creating parquet writer: %w", err)
+	}
+	pw.fw = fw
+	return nil
+}
+
+// write writes a record, splitting it across chunks if it exceeds the rows left in the current one
+func (pw *parquetWriter) write(record arrow.Record) error {
+	if record.NumRows() == 0 {
+		return nil
+	}
+	for offset := int64(0); offset < record.NumRows(); {
+		if pw.c.full() {
+			if err := pw.end(); err != nil {
+				return err
+			}
+			if err := pw.c.next(); err != nil {
+				return err
+			}
+			if err := pw.begin(); err != nil {
+				return err
+			}
+		}
+		end := record.NumRows()
+		if remaining := pw.c.remainingRows(); remaining >= 0 {
+			end = min(end, offset+remaining)
+		}
+		slice := record.NewSlice(offset, end)
+		err := pw.fw.Write(slice)
+		slice.Release()
+		if err != nil {
+			return fmt.Errorf("writing parquet row group: %w", err)
+		}
+		pw.c.addRows(end - offset)
+		offset = end
+	}
+	return nil
+}
+
+// end writes the footer of the current parquet file
+func (pw *parquetWriter) end() error {
+	fw := pw.fw
+	pw.fw = nil
+	if err := fw.Close(); err != nil {
+		return fmt.Errorf("closing parquet file: %w", err)
+	}
+	return nil
+}
+
+// abort closes the current parquet file, if any, after a failed export
+func (pw *parquetWriter) abort() {
+	if pw.fw != nil {
+		_ = pw.fw.Close()
+	}
+}
@@ -0,0 +1,140 @@
+package sqlconnect
+
+import (
+	"context"
+	"database/sql"
+)
+
+// OperationKind is the kind of an [Operation] seen by an [Interceptor]
+type OperationKind string
+
+const (
+	OperationQuery   OperationKind = "query"    // a call to [sql.DB.QueryContext] or [sql.DB.Query]
+	OperationExec    OperationKind = "exec"     // a call to [sql.DB.ExecContext] or [sql.DB.Exec]
+	OperationPrepare OperationKind = "prepare"  // a call to [sql.DB.PrepareContext] or [sql.DB.Prepare]
+	OperationBeginTx OperationKind = "begin_tx" // a call to [sql.DB.BeginTx] or [sql.DB.Begin]
+)
+
+// Operation is a query, statement or transaction about to be performed by a [DB], either because the caller invoked the corresponding [sql.DB] method directly, or internally by one of the [DB]'s methods, e.g. [TableAdmin.ListTables]
+type Operation struct {
+	Kind      OperationKind
+	SQL       string         // the sql text of the query or statement, empty for [OperationBeginTx]
+	Args      []any          // the arguments of the query or statement
+	TxOptions *sql.TxOptions // the options of the transaction for [OperationBeginTx]
+	Method    string         // the [DB] method performing the operation, e.g. "ListTables", empty if the operation was invoked directly by the caller
+	Relations []RelationRef  // the relations the [DB] method refers to, if any
+}
+
+// OperationResult is the result of an [Operation], depending on its kind
+type OperationResult struct {
+	Rows   *sql.Rows  // for [OperationQuery]
+	Result sql.Result // for [OperationExec]
+	Stmt   *sql.Stmt  // for [OperationPrepare]
+	Tx     *sql.Tx    // for [OperationBeginTx]
+}
+
+// Invoker performs an [Operation], returning its result
+type Invoker func(ctx context.Context, op Operation) (OperationResult, error)
+
+// Interceptor is called around every [Operation] performed by a [DB] and is responsible for invoking it, e.g. for logging it, rewriting its sql, or rejecting it altogether:
+//
+//	func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+//		start := time.Now()
+//		res, err := invoke(ctx, op)
+//		log.Printf("%s %s: %v (%s)", op.Kind, op.SQL, err, time.Since(start))
+//		return res, err
+//	}
+//
+// Errors returned by invoke have already been classified and retried according to the [DB]'s [RetryPolicy].
+// Calls to [sql.DB.QueryRowContext] cannot be intercepted, as well as any queries or statements performed using a [sql.Conn] or a [sql.Tx].
+type Interceptor func(ctx context.Context, op Operation, invoke Invoker) (OperationResult, error)
+
+// ChainInterceptors returns an [Invoker] running the interceptors in order around the given invoker, i.e. the first interceptor is the outermost one
+func ChainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(ctx context.Context, op Operation) (OperationResult, error) {
+			return interceptor(ctx, op, next)
+		}
+	}
+	return invoker
+}
+
+// Wrap returns a [DB] running the interceptors around every [Operation] performed by the db, including the ones performed internally by its methods, e.g. [TableAdmin.CountTableRows].
+// The returned [DB] shares the connection pool and configuration of the db, thus closing either of them closes both.
+// Interceptors already configured for the db run before the given interceptors.
+//
+//	db = sqlconnect.Wrap(db, loggingInterceptor, guardrailInterceptor)
+func Wrap(db DB, interceptors ...Interceptor) DB {
+	if len(interceptors) == 0 {
+		return db
+	}
+	if idb, ok := db.(interface {
+		WithInterceptors(interceptors ...Interceptor) DB
+	}); ok {
+		return idb.WithInterceptors(interceptors...)
+	}
+	return &interceptedDB{DB: db, interceptors: interceptors}
+}
+
+// interceptedDB runs interceptors around calls to a [DB] that doesn't support them natively, i.e. only around operations invoked directly by the caller
+type interceptedDB struct {
+	DB
+	interceptors []Interceptor
+}
+
+func (db *interceptedDB) invoke(ctx context.Context, op Operation) (OperationResult, error) {
+	return ChainInterceptors(db.interceptors, func(ctx context.Context, op Operation) (OperationResult, error) {
+		var (
+			res OperationResult
+			err error
+		)
+		switch op.Kind {
+		case OperationQuery:
+			res.Rows, err = db.DB.QueryContext(ctx, op.SQL, op.Args...) // nolint:rowserrcheck
+		case OperationExec:
+			res.Result, err = db.DB.ExecContext(ctx, op.SQL, op.Args...)
+		case OperationPrepare:
+			res.Stmt, err = db.DB.PrepareContext(ctx, op.SQL)
+		case OperationBeginTx:
+			res.Tx, err = db.DB.BeginTx(ctx, op.TxOptions)
+		}
+		return res, err
+	})(ctx, op)
+}
+
+func (db *interceptedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	res, err := db.invoke(ctx, Operation{Kind: OperationQuery, SQL: query, Args: args})
+	return res.Rows, err
+}
+
+func (db *interceptedDB) Query(query string, args ...any) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+func (db *interceptedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := db.invoke(ctx, Operation{Kind: OperationExec, SQL: query, Args: args})
+	return res.Result, err
+}
+
+func (db *interceptedDB) Exec(query string, args ...any) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+func (db *interceptedDB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	res, err := db.invoke(ctx, Operation{Kind: OperationPrepare, SQL: query})
+	return res.Stmt, err
+}
+
+func (db *interceptedDB) Prepare(query string) (*sql.Stmt, error) {
+	return db.PrepareContext(context.Background(), query)
+}
+
+func (db *interceptedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	res, err := db.invoke(ctx, Operation{Kind: OperationBeginTx, TxOptions: opts})
+	return res.Tx, err
+}
+
+func (db *interceptedDB) Begin() (*sql.Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
@@ -0,0 +1,63 @@
+package sqlconnect_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestChainInterceptors(t *testing.T) {
+	var calls []string
+	interceptor := func(name string) sqlconnect.Interceptor {
+		return func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+			calls = append(calls, name+":before")
+			res, err := invoke(ctx, op)
+			calls = append(calls, name+":after")
+			return res, err
+		}
+	}
+	invoker := sqlconnect.ChainInterceptors([]sqlconnect.Interceptor{interceptor("first"), interceptor("second")}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		calls = append(calls, "invoke")
+		return sqlconnect.OperationResult{}, nil
+	})
+	_, err := invoker(context.Background(), sqlconnect.Operation{Kind: sqlconnect.OperationQuery, SQL: "SELECT 1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"first:before", "second:before", "invoke", "second:after", "first:after"}, calls, "the first interceptor should be the outermost one")
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("without interceptors", func(t *testing.T) {
+		db := &fakeDB{}
+		require.Same(t, db, sqlconnect.Wrap(db))
+	})
+
+	t.Run("db without native support", func(t *testing.T) {
+		db := &fakeDB{}
+		var ops []sqlconnect.Operation
+		wrapped := sqlconnect.Wrap(db, func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+			ops = append(ops, op)
+			op.SQL = "/* wrapped */ " + op.SQL
+			return invoke(ctx, op)
+		})
+
+		_, err := wrapped.Exec("DELETE FROM t WHERE id = ?", 1)
+		require.NoError(t, err)
+		require.Equal(t, []sqlconnect.Operation{{Kind: sqlconnect.OperationExec, SQL: "DELETE FROM t WHERE id = ?", Args: []any{1}}}, ops)
+		require.Equal(t, []string{"/* wrapped */ DELETE FROM t WHERE id = ?"}, db.execs, "it should invoke the db with the rewritten sql")
+	})
+}
+
+// fakeDB is a [sqlconnect.DB] recording the statements executed, without support for interceptors
+type fakeDB struct {
+	sqlconnect.DB
+	execs []string
+}
+
+func (db *fakeDB) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	db.execs = append(db.execs, query)
+	return nil, nil
+}
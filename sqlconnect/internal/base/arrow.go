@@ -0,0 +1,397 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// arrowBatchSize is the number of rows in each of the records converted from the rows of a query
+const arrowBatchSize = 10_000
+
+// ArrowQuery runs a query returning its results natively as arrow records, using a connection of the driver as provided by [sql.Conn.Raw], see [WithArrowQuery].
+// The connection is reserved until the returned reader is released, thus the reader can keep using it while reading the results.
+// rowsSchema returns the schema of the records converted from the rows of the driver using the column type mappings, e.g. for results without any records.
+// It is only used for read-only queries, since returning an error wrapping [sqlconnect.ErrNotSupported] makes [DB.QueryArrow] execute the query again, converting its rows instead.
+type ArrowQuery func(ctx context.Context, driverConn any, query string, args []driver.NamedValue, rowsSchema func(driver.Rows) *arrow.Schema) (array.RecordReader, error)
+
+// QueryArrow executes a query returning a reader of its results as arrow records, using the driver's native support for read-only queries if configured with [WithArrowQuery],
+// or converting the rows of the query using the column type mappings otherwise
+func (db *DB) QueryArrow(ctx context.Context, query string, args ...any) (array.RecordReader, error) {
+	ctx = WithOperation(ctx, "QueryArrow")
+	if db.arrowQuery != nil && IsReadOnlyQuery(query) {
+		var reader array.RecordReader
+		_, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationQuery, SQL: query, Args: args}, func(ctx context.Context, op sqlconnect.Operation) (_ sqlconnect.OperationResult, err error) {
+			reader, err = RetryWithData(ctx, db, true, func() (array.RecordReader, error) {
+				return db.queryArrow(ctx, op.SQL, op.Args)
+			})
+			return sqlconnect.OperationResult{}, err
+		})
+		if !errors.Is(err, sqlconnect.ErrNotSupported) {
+			return reader, err
+		}
+	}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("getting column types: %w", err)
+	}
+	schema := db.arrowSchema(sqlArrowColumns(cols))
+	// closing the rows on release as well, in case the reader is released before reading any records
+	return NewReleasingRecordReader(array.ReaderFromIter(schema, db.arrowRecords(ctx, query, rows, cols, schema)), func() { _ = rows.Close() }), nil
+}
+
+// queryArrow runs a query using [DB.arrowQuery] and a dedicated connection, which is closed once the returned reader is released
+func (db *DB) queryArrow(ctx context.Context, query string, args []any) (array.RecordReader, error) {
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var reader array.RecordReader
+	err = conn.Raw(func(driverConn any) (err error) {
+		reader, err = db.arrowQuery(ctx, driverConn, query, namedValues(args), db.rowsArrowSchema)
+		return err
+	})
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	return NewReleasingRecordReader(reader, func() { _ = conn.Close() }), nil
+}
+
+// arrowColumn is a column of the rows converted to arrow records
+type arrowColumn interface {
+	Name() string
+	ColumnType
+}
+
+func sqlArrowColumns(cols []*sql.ColumnType) []arrowColumn {
+	arrowCols := make([]arrowColumn, len(cols))
+	for i, col := range cols {
+		arrowCols[i] = col
+	}
+	return arrowCols
+}
+
+// driverColumn is a column of the rows of a driver, described using the optional interfaces of the rows
+type driverColumn struct {
+	rows  driver.Rows
+	index int
+	name  string
+}
+
+func (c driverColumn) Name() string {
+	return c.name
+}
+
+func (c driverColumn) DatabaseTypeName() string {
+	if rows, ok := c.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return rows.ColumnTypeDatabaseTypeName(c.index)
+	}
+	return ""
+}
+
+func (c driverColumn) DecimalSize() (precision, scale int64, ok bool) {
+	if rows, ok := c.rows.(driver.RowsColumnTypePrecisionScale); ok {
+		return rows.ColumnTypePrecisionScale(c.index)
+	}
+	return 0, 0, false
+}
+
+// rowsArrowSchema returns the schema of the records converted from the rows of a driver, using the column type mappings
+func (db *DB) rowsArrowSchema(rows driver.Rows) *arrow.Schema {
+	names := rows.Columns()
+	cols := make([]arrowColumn, len(names))
+	for i, name := range names {
+		cols[i] = driverColumn{rows: rows, index: i, name: name}
+	}
+	return db.arrowSchema(cols)
+}
+
+// arrowSchema returns the schema of the records converted from rows with the given columns, using the column type mappings
+func (db *DB) arrowSchema(cols []arrowColumn) *arrow.Schema {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		fields[i] = arrow.Field{
+			Name:     col.Name(),
+			Type:     arrowType(db.columnTypeMapper(col)),
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{"databaseTypeName"}, []string{col.DatabaseTypeName()}),
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+// arrowType returns the arrow type of a column for its rudder type
+func arrowType(rudderType string) arrow.DataType {
+	switch rudderType {
+	case "int":
+		return arrow.PrimitiveTypes.Int64
+	case "float":
+		return arrow.PrimitiveTypes.Float64
+	case "boolean":
+		return arrow.FixedWidthTypes.Boolean
+	case "datetime":
+		return arrow.FixedWidthTypes.Timestamp_us
+	default: // strings, json and any other type
+		return arrow.BinaryTypes.String
+	}
+}
+
+// arrowRecords converts the rows of the query to records of up to [arrowBatchSize] rows, closing the rows and recording the number of rows returned once done
+func (db *DB) arrowRecords(ctx context.Context, query string, rows *sql.Rows, cols []*sql.ColumnType, schema *arrow.Schema) iter.Seq2[arrow.Record, error] {
+	return func(yield func(arrow.Record, error) bool) {
+		defer func() { _ = rows.Close() }()
+		var returned int64
+		defer func() { db.RecordReturnedRows(ctx, query, returned) }()
+		builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer builder.Release()
+		values := make([]any, len(cols))
+		for i := range values {
+			values[i] = new(sqlconnect.NilAny)
+		}
+		for {
+			var n int
+			for ; n < arrowBatchSize && rows.Next(); n++ {
+				if err := rows.Scan(values...); err != nil {
+					yield(nil, fmt.Errorf("scanning row: %w", err))
+					return
+				}
+				for i, col := range cols {
+					value := db.jsonRowMapper(col.DatabaseTypeName(), values[i].(*sqlconnect.NilAny).Value)
+					if err := appendArrowValue(builder.Field(i), value); err != nil {
+						yield(nil, fmt.Errorf("converting value of column %s: %w", col.Name(), err))
+						return
+					}
+				}
+			}
+			if n < arrowBatchSize {
+				if err := rows.Err(); err != nil { // errors surfacing while iterating haven't been classified yet
+					yield(nil, db.ClassifyError(err))
+					return
+				}
+			}
+			returned += int64(n)
+			if n > 0 && !yield(builder.NewRecord(), nil) {
+				return
+			}
+			if n < arrowBatchSize {
+				return
+			}
+		}
+	}
+}
+
+// appendArrowValue appends a value, as returned by the json row mapper, to a builder of a type returned by [arrowType]
+func appendArrowValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.Int64Builder:
+		v, err := toInt64(value)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.Float64Builder:
+		v, err := toFloat64(value)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+	case *array.BooleanBuilder:
+		switch v := value.(type) {
+		case bool:
+			b.Append(v)
+		case string:
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			b.Append(parsed)
+		default:
+			n, err := toInt64(value)
+			if err != nil {
+				return err
+			}
+			b.Append(n != 0)
+		}
+	case *array.TimestampBuilder:
+		switch v := value.(type) {
+		case time.Time:
+			b.AppendTime(v)
+		case string:
+			return b.AppendValueFromString(v)
+		default:
+			return fmt.Errorf("unexpected value for timestamp: %T", value)
+		}
+	case *array.StringBuilder:
+		switch v := value.(type) {
+		case string:
+			b.Append(v)
+		case []byte:
+			b.Append(string(v))
+		case json.RawMessage:
+			b.Append(string(v))
+		case time.Time:
+			b.Append(v.Format(time.RFC3339Nano))
+		default:
+			j, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			b.Append(string(j))
+		}
+	default:
+		return fmt.Errorf("unexpected builder: %T", builder)
+	}
+	return nil
+}
+
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, fmt.Errorf("value out of range for int64: %d", v)
+		}
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("unexpected fractional value for int64: %v", v)
+		}
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	case []byte:
+		return strconv.ParseInt(string(v), 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected value for int64: %T", value)
+	}
+}
+
+func toFloat64(value any) (float64, error) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case []byte:
+		return strconv.ParseFloat(string(v), 64)
+	default:
+		n, err := toInt64(value)
+		if err != nil {
+			return 0, fmt.Errorf("unexpected value for float64: %T", value)
+		}
+		return float64(n), nil
+	}
+}
+
+// ArrowReaderFromRecords returns a reader of the records, using the schema of the first one, for drivers that don't provide the schema of their records upfront.
+// If there are no records, an empty reader with the given schema is returned, e.g. as returned by the rowsSchema function of [ArrowQuery].
+// The records are stopped once the reader is released, or immediately if an error is returned.
+func ArrowReaderFromRecords(records iter.Seq2[arrow.Record, error], emptySchema *arrow.Schema) (array.RecordReader, error) {
+	next, stop := iter.Pull2(records)
+	first, err, ok := next()
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	if !ok {
+		stop()
+		return array.NewRecordReader(emptySchema, nil)
+	}
+	return array.ReaderFromIter(first.Schema(), func(yield func(arrow.Record, error) bool) {
+		defer stop()
+		for record := first; ok; record, err, ok = next() {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(record, nil) {
+				return
+			}
+		}
+	}), nil
+}
+
+// NewReleasingRecordReader returns a reader calling release once the reader has been released
+func NewReleasingRecordReader(reader array.RecordReader, release func()) array.RecordReader {
+	r := &releasingRecordReader{RecordReader: reader, release: release}
+	r.refCount.Add(1)
+	return r
+}
+
+type releasingRecordReader struct {
+	array.RecordReader
+	refCount atomic.Int64
+	release  func()
+}
+
+func (r *releasingRecordReader) Retain() {
+	r.refCount.Add(1)
+}
+
+func (r *releasingRecordReader) Release() {
+	if r.refCount.Add(-1) == 0 {
+		r.RecordReader.Release()
+		r.release()
+	}
+}
+
+// namedValues converts the arguments of a query to the values passed to a driver, as [sql.DB] does for drivers without their own converters
+func namedValues(args []any) []driver.NamedValue {
+	values := make([]driver.NamedValue, len(args))
+	for i, arg := range args {
+		value := driver.NamedValue{Ordinal: i + 1, Value: arg}
+		if named, ok := arg.(sql.NamedArg); ok {
+			value.Name, value.Value = named.Name, named.Value
+		}
+		if converted, err := driver.DefaultParameterConverter.ConvertValue(value.Value); err == nil {
+			value.Value = converted
+		}
+		values[i] = value
+	}
+	return values
+}
@@ -0,0 +1,160 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQueryArrow(t *testing.T) {
+	ctx := context.Background()
+	intMapper := WithColumnTypeMapper(func(ColumnType) string { return "int" })
+
+	t.Run("converting rows", func(t *testing.T) {
+		db := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil }, intMapper)
+		defer func() { _ = db.Close() }()
+
+		reader, err := db.QueryArrow(ctx, "SELECT 1 AS c")
+		require.NoError(t, err)
+		require.Equal(t, arrow.PrimitiveTypes.Int64, reader.Schema().Field(0).Type, "it should use the column type mappings")
+		require.Equal(t, "c", reader.Schema().Field(0).Name)
+
+		require.True(t, reader.Next())
+		require.EqualValues(t, 1, reader.Record().NumRows())
+		require.Equal(t, []int64{1}, reader.Record().Column(0).(*array.Int64).Int64Values())
+		require.False(t, reader.Next())
+		require.NoError(t, reader.Err())
+		reader.Release()
+		require.Zero(t, db.Stats().InUse, "it should release the connection")
+	})
+
+	t.Run("released before reading", func(t *testing.T) {
+		db := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil }, intMapper)
+		defer func() { _ = db.Close() }()
+
+		reader, err := db.QueryArrow(ctx, "SELECT 1 AS c")
+		require.NoError(t, err)
+		require.Equal(t, 1, db.Stats().InUse)
+		reader.Release()
+		require.Zero(t, db.Stats().InUse, "it should release the connection")
+	})
+
+	t.Run("natively", func(t *testing.T) {
+		conn := &fakeConn{}
+		schema := arrow.NewSchema([]arrow.Field{{Name: "c", Type: arrow.BinaryTypes.String}}, nil)
+		var (
+			queryConn any
+			queryArgs []any
+		)
+		db := NewDB(sql.OpenDB(conn), func() error { return nil }, intMapper, WithArrowQuery(func(_ context.Context, driverConn any, query string, args []driver.NamedValue, rowsSchema func(driver.Rows) *arrow.Schema) (array.RecordReader, error) {
+			switch query {
+			case "SHOW TABLES":
+				return nil, fmt.Errorf("json results: %w", sqlconnect.ErrNotSupported)
+			case "SELECT c FROM empty":
+				noRecords := func(func(arrow.Record, error) bool) {}
+				return ArrowReaderFromRecords(noRecords, rowsSchema(&fakeRows{}))
+			}
+			queryConn = driverConn
+			for _, arg := range args {
+				queryArgs = append(queryArgs, arg.Value)
+			}
+			builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+			defer builder.Release()
+			builder.Field(0).(*array.StringBuilder).Append("native")
+			return array.NewRecordReader(schema, []arrow.Record{builder.NewRecord()})
+		}))
+		defer func() { _ = db.Close() }()
+		var ops []sqlconnect.Operation
+		intercepted := db.WithInterceptors(func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+			ops = append(ops, op)
+			return invoke(ctx, op)
+		})
+
+		reader, err := intercepted.QueryArrow(ctx, "SELECT ? AS c", 1)
+		require.NoError(t, err)
+		require.Same(t, conn, queryConn, "it should provide the driver connection")
+		require.Equal(t, []any{int64(1)}, queryArgs, "it should convert the arguments to driver values")
+		require.Len(t, ops, 1)
+		require.Equal(t, "QueryArrow", ops[0].Method)
+		require.Equal(t, 1, db.Stats().InUse, "it should reserve the connection while reading")
+		require.True(t, reader.Next())
+		require.Equal(t, "native", reader.Record().Column(0).(*array.String).Value(0))
+		require.False(t, reader.Next())
+		reader.Release()
+		require.Zero(t, db.Stats().InUse, "it should release the connection")
+
+		reader, err = intercepted.QueryArrow(ctx, "SHOW TABLES")
+		require.NoError(t, err)
+		defer reader.Release()
+		require.Equal(t, arrow.PrimitiveTypes.Int64, reader.Schema().Field(0).Type, "it should fall back to converting rows")
+		require.Equal(t, "SHOW TABLES", conn.lastQuery)
+
+		reader, err = intercepted.QueryArrow(ctx, "SELECT c FROM empty")
+		require.NoError(t, err)
+		defer reader.Release()
+		require.Equal(t, "SHOW TABLES", conn.lastQuery, "it should not execute queries without records again")
+		require.Equal(t, arrow.PrimitiveTypes.Int64, reader.Schema().Field(0).Type, "it should use the schema of the rows for queries without records")
+		require.False(t, reader.Next())
+		require.NoError(t, reader.Err())
+
+		queryConn = nil
+		reader, err = intercepted.QueryArrow(ctx, "INSERT INTO t VALUES (1) RETURNING c")
+		require.NoError(t, err)
+		defer reader.Release()
+		require.Nil(t, queryConn, "it should not run other than read-only queries natively, since they can't be executed again")
+		require.Equal(t, arrow.PrimitiveTypes.Int64, reader.Schema().Field(0).Type)
+	})
+}
+
+func TestAppendArrowValue(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	testCases := []struct {
+		rudderType string
+		value      any
+		expected   any
+	}{
+		{rudderType: "int", value: int32(1), expected: int64(1)},
+		{rudderType: "int", value: "2", expected: int64(2)},
+		{rudderType: "int", value: float64(3), expected: int64(3)},
+		{rudderType: "float", value: 1.5, expected: 1.5},
+		{rudderType: "float", value: int64(2), expected: float64(2)},
+		{rudderType: "boolean", value: true, expected: true},
+		{rudderType: "boolean", value: int64(0), expected: false},
+		{rudderType: "datetime", value: ts, expected: "2024-01-02 03:04:05.000006Z"},
+		{rudderType: "string", value: []byte("bytes"), expected: "bytes"},
+		{rudderType: "json", value: json.RawMessage(`{"a":1}`), expected: `{"a":1}`},
+		{rudderType: "array", value: []any{"a", "b"}, expected: `["a","b"]`},
+		{rudderType: "int", value: nil, expected: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("%s %v", tc.rudderType, tc.value), func(t *testing.T) {
+			builder := array.NewBuilder(memory.DefaultAllocator, arrowType(tc.rudderType))
+			defer builder.Release()
+			require.NoError(t, appendArrowValue(builder, tc.value))
+			arr := builder.NewArray()
+			defer arr.Release()
+			if tc.expected == nil {
+				require.True(t, arr.IsNull(0))
+				return
+			}
+			require.Equal(t, tc.expected, arr.GetOneForMarshal(0).(any))
+		})
+	}
+
+	t.Run("invalid value", func(t *testing.T) {
+		builder := array.NewBuilder(memory.DefaultAllocator, arrowType("int"))
+		defer builder.Release()
+		require.Error(t, appendArrowValue(builder, 1.5), "it should not truncate fractional values")
+	})
+}
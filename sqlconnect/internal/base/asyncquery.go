@@ -0,0 +1,120 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// AsyncQueries are the functions for running queries asynchronously, using the driver's support for submitting queries and fetching their results by id, see [WithAsyncQueries].
+// A zero value means that the driver cannot run queries asynchronously.
+type AsyncQueries struct {
+	// DatabaseType is the type of the database, for identifying the handles of its queries
+	DatabaseType string
+	// SubmitContext returns a context for submitting a query without waiting for it to complete, along with a function returning the id and location of the query once submitted.
+	// The function is called once the query has been submitted and may wait for the id until the context is done, which gets canceled right after and before closing the rows returned by the driver.
+	SubmitContext func(ctx context.Context) (context.Context, func() (id, location string))
+	// Status returns the status of a submitted query using a connection of the driver, as provided by [sql.Conn.Raw]
+	Status func(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error)
+	// FetchContext returns a context for fetching the results of a submitted query, by performing an empty query with it
+	FetchContext func(ctx context.Context, handle sqlconnect.QueryHandle) context.Context
+	// Cancel cancels a submitted query using a connection of the driver, as provided by [sql.Conn.Raw]
+	Cancel func(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) error
+}
+
+// SubmitQuery submits a query without waiting for it to complete, using [AsyncQueries.SubmitContext]
+func (db *DB) SubmitQuery(ctx context.Context, query string, params ...any) (sqlconnect.QueryHandle, error) {
+	ctx = WithOperation(ctx, "SubmitQuery")
+	if db.asyncQueries.SubmitContext == nil {
+		return sqlconnect.QueryHandle{}, fmt.Errorf("submitting query: %w", sqlconnect.ErrNotSupported)
+	}
+	// canceling the context once the query is submitted, so that the driver stops waiting for it
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	submitted := func() (string, string) { return "", "" }
+	res, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationQuery, SQL: query, Args: params}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		rows, err := RetryWithData(ctx, db, IsReadOnlyQuery(op.SQL), func() (*sql.Rows, error) {
+			var submitCtx context.Context
+			submitCtx, submitted = db.asyncQueries.SubmitContext(ctx)
+			return db.DB.QueryContext(submitCtx, op.SQL, op.Args...) // nolint:rowserrcheck
+		})
+		return sqlconnect.OperationResult{Rows: rows}, err
+	})
+	if err != nil {
+		return sqlconnect.QueryHandle{}, fmt.Errorf("submitting query: %w", err)
+	}
+	id, location := submitted()
+	cancel()
+	if res.Rows != nil {
+		_ = res.Rows.Close()
+	}
+	if id == "" {
+		return sqlconnect.QueryHandle{}, fmt.Errorf("submitting query: no query id reported by the driver")
+	}
+	return sqlconnect.QueryHandle{DatabaseType: db.asyncQueries.DatabaseType, ID: id, Location: location}, nil
+}
+
+// QueryStatus returns the status of a submitted query, using [AsyncQueries.Status]
+func (db *DB) QueryStatus(ctx context.Context, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error) {
+	if err := db.checkQueryHandle(handle); err != nil {
+		return sqlconnect.QueryStatus{}, fmt.Errorf("getting query status: %w", err)
+	}
+	var status sqlconnect.QueryStatus
+	err := db.withDriverConn(ctx, func(driverConn any) (err error) {
+		status, err = db.asyncQueries.Status(ctx, driverConn, handle)
+		return err
+	})
+	if err != nil {
+		return sqlconnect.QueryStatus{}, fmt.Errorf("getting query status: %w", err)
+	}
+	status.Err = db.ClassifyError(status.Err)
+	return status, nil
+}
+
+// FetchResults returns the results of a submitted query, by performing an empty query using the context returned by [AsyncQueries.FetchContext]
+func (db *DB) FetchResults(ctx context.Context, handle sqlconnect.QueryHandle) (*sql.Rows, error) {
+	ctx = WithOperation(ctx, "FetchResults")
+	if err := db.checkQueryHandle(handle); err != nil {
+		return nil, fmt.Errorf("fetching query results: %w", err)
+	}
+	return db.QueryContext(db.asyncQueries.FetchContext(ctx, handle), "")
+}
+
+// CancelQuery cancels a submitted query, using [AsyncQueries.Cancel]
+func (db *DB) CancelQuery(ctx context.Context, handle sqlconnect.QueryHandle) error {
+	if err := db.checkQueryHandle(handle); err != nil {
+		return fmt.Errorf("canceling query: %w", err)
+	}
+	err := db.withDriverConn(ctx, func(driverConn any) error {
+		return db.asyncQueries.Cancel(ctx, driverConn, handle)
+	})
+	if err != nil {
+		return fmt.Errorf("canceling query: %w", err)
+	}
+	return nil
+}
+
+// withDriverConn calls fn with a connection of the driver, retrying it for as long as it fails with retryable errors
+func (db *DB) withDriverConn(ctx context.Context, fn func(driverConn any) error) error {
+	return db.Retry(ctx, true, func() error {
+		conn, err := db.DB.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = conn.Close() }()
+		return conn.Raw(fn)
+	})
+}
+
+// checkQueryHandle returns an error if the db cannot run queries asynchronously or the handle doesn't identify a query of the db's type
+func (db *DB) checkQueryHandle(handle sqlconnect.QueryHandle) error {
+	if db.asyncQueries.SubmitContext == nil {
+		return sqlconnect.ErrNotSupported
+	}
+	if handle.DatabaseType != db.asyncQueries.DatabaseType || handle.ID == "" {
+		return fmt.Errorf("invalid %s query handle: %+v", db.asyncQueries.DatabaseType, handle)
+	}
+	return nil
+}
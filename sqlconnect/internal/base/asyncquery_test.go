@@ -0,0 +1,99 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestAsyncQueries(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not supported", func(t *testing.T) {
+		db := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil })
+		defer func() { _ = db.Close() }()
+
+		_, err := db.SubmitQuery(ctx, "SELECT 1")
+		require.ErrorIs(t, err, sqlconnect.ErrNotSupported)
+		_, err = db.QueryStatus(ctx, sqlconnect.QueryHandle{DatabaseType: "fake", ID: "q1"})
+		require.ErrorIs(t, err, sqlconnect.ErrNotSupported)
+		_, err = db.FetchResults(ctx, sqlconnect.QueryHandle{DatabaseType: "fake", ID: "q1"})
+		require.ErrorIs(t, err, sqlconnect.ErrNotSupported)
+		err = db.CancelQuery(ctx, sqlconnect.QueryHandle{DatabaseType: "fake", ID: "q1"})
+		require.ErrorIs(t, err, sqlconnect.ErrNotSupported)
+	})
+
+	type modeKey struct{}
+	conn := &fakeConn{}
+	var (
+		statusConn any
+		canceled   []string
+	)
+	db := NewDB(sql.OpenDB(conn), func() error { return nil }, WithAsyncQueries(AsyncQueries{
+		DatabaseType: "fake",
+		SubmitContext: func(ctx context.Context) (context.Context, func() (string, string)) {
+			return context.WithValue(ctx, modeKey{}, "submit"), func() (string, string) { return "q1", "eu" }
+		},
+		Status: func(_ context.Context, driverConn any, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error) {
+			statusConn = driverConn
+			return sqlconnect.QueryStatus{State: sqlconnect.QueryStateSucceeded}, nil
+		},
+		FetchContext: func(ctx context.Context, handle sqlconnect.QueryHandle) context.Context {
+			return context.WithValue(ctx, modeKey{}, "fetch "+handle.ID)
+		},
+		Cancel: func(_ context.Context, driverConn any, handle sqlconnect.QueryHandle) error {
+			if driverConn != conn {
+				return fmt.Errorf("unexpected driver connection: %T", driverConn)
+			}
+			canceled = append(canceled, handle.ID)
+			return nil
+		},
+	}))
+	defer func() { _ = db.Close() }()
+
+	var (
+		ops   []sqlconnect.Operation
+		modes []any
+	)
+	intercepted := db.WithInterceptors(func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+		ops = append(ops, op)
+		return invoke(ctx, op)
+	}, func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+		modes = append(modes, ctx.Value(modeKey{}))
+		return invoke(ctx, op)
+	})
+
+	handle, err := intercepted.SubmitQuery(ctx, "INSERT INTO t SELECT * FROM s")
+	require.NoError(t, err)
+	require.Equal(t, sqlconnect.QueryHandle{DatabaseType: "fake", ID: "q1", Location: "eu"}, handle)
+	require.Equal(t, "INSERT INTO t SELECT * FROM s", conn.lastQuery)
+	require.Len(t, ops, 1, "it should intercept the submitted query")
+	require.Equal(t, "SubmitQuery", ops[0].Method)
+
+	status, err := intercepted.QueryStatus(ctx, handle)
+	require.NoError(t, err)
+	require.Equal(t, sqlconnect.QueryStateSucceeded, status.State)
+	require.True(t, status.Done())
+	require.Same(t, conn, statusConn, "it should provide the driver connection")
+
+	rows, err := intercepted.FetchResults(ctx, handle)
+	require.NoError(t, err)
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Close())
+	require.Len(t, ops, 2, "it should intercept fetching the results")
+	require.Equal(t, "FetchResults", ops[1].Method)
+	require.Equal(t, []any{nil, "fetch q1"}, modes, "it should fetch the results using the fetch context, while the submit context is only passed to the driver")
+
+	require.NoError(t, intercepted.CancelQuery(ctx, handle))
+	require.Equal(t, []string{"q1"}, canceled, "it should cancel the query using the driver connection")
+
+	_, err = intercepted.QueryStatus(ctx, sqlconnect.QueryHandle{DatabaseType: "other", ID: "q1"})
+	require.ErrorContains(t, err, "invalid fake query handle", "it should reject handles of other databases")
+	err = intercepted.CancelQuery(ctx, sqlconnect.QueryHandle{DatabaseType: "fake"})
+	require.ErrorContains(t, err, "invalid fake query handle", "it should reject handles without an id")
+}
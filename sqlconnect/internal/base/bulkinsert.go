@@ -0,0 +1,92 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// BatchInserter inserts a single batch of rows into a relation
+type BatchInserter func(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error
+
+// InsertRows inserts rows into the given table using multi-row VALUES statements
+func (db *DB) InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], opts ...sqlconnect.Option) error {
+	ctx = WithOperation(ctx, "InsertRows", relation)
+	return InsertRows(ctx, relation, columns, rows, db.InsertValues, opts...)
+}
+
+// InsertValues inserts a batch of rows using a single multi-row VALUES statement, with values rendered as dialect-specific literals
+func (db *DB) InsertValues(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error {
+	ctx = WithOperation(ctx, "InsertValues", relation)
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	values := make([]string, 0, len(batch))
+	for _, row := range batch {
+		expr, err := db.Expressions().Literal(placeholders, row...)
+		if err != nil {
+			return fmt.Errorf("rendering row values: %w", err)
+		}
+		values = append(values, expr.String())
+	}
+	stmt := fmt.Sprintf("INSERT INTO %[1]s (%[2]s) VALUES %[3]s",
+		db.QuoteTable(relation),
+		strings.Join(lo.Map(columns, func(col string, _ int) string { return db.QuoteIdentifier(col) }), ", "),
+		strings.Join(values, ", "),
+	)
+	if _, err := db.ExecContext(ctx, stmt); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsertRows splits rows into batches according to the provided options and inserts them sequentially using the provided [BatchInserter].
+// Insertion stops at the first batch that fails, returning an [sqlconnect.InsertBatchError].
+func InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], inserter BatchInserter, opts ...sqlconnect.Option) error {
+	insertOpts, err := sqlconnect.NewInsertOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("inserting rows into %s: no columns provided", relation)
+	}
+	var (
+		batch    = make([][]any, 0, insertOpts.BatchSize)
+		batchIdx int
+		offset   int
+	)
+	batchError := func(err error) error {
+		return &sqlconnect.InsertBatchError{Relation: relation, Batch: batchIdx, Offset: offset, Size: len(batch), Err: err}
+	}
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return batchError(err)
+		}
+		if err := inserter(ctx, relation, columns, batch); err != nil {
+			return batchError(err)
+		}
+		offset += len(batch)
+		batchIdx++
+		clear(batch)
+		batch = batch[:0]
+		return nil
+	}
+	for row := range rows {
+		batch = append(batch, row)
+		if len(row) != len(columns) {
+			return batchError(fmt.Errorf("row %d has %d values, expected %d", offset+len(batch)-1, len(row), len(columns)))
+		}
+		if len(batch) == insertOpts.BatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
@@ -0,0 +1,73 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestInsertRows(t *testing.T) {
+	ctx := context.Background()
+	relation := sqlconnect.NewSchemaTableRef("schema", "table")
+	columns := []string{"c1", "c2"}
+	rows := [][]any{{1, "a"}, {2, "b"}, {3, "c"}, {4, "d"}, {5, "e"}}
+
+	t.Run("batches rows according to batch size", func(t *testing.T) {
+		var batches [][][]any
+		inserter := func(_ context.Context, _ sqlconnect.RelationRef, _ []string, batch [][]any) error {
+			batches = append(batches, slices.Clone(batch))
+			return nil
+		}
+		err := InsertRows(ctx, relation, columns, slices.Values(rows), inserter, sqlconnect.WithBatchSize(2))
+		require.NoError(t, err)
+		require.Equal(t, [][][]any{rows[0:2], rows[2:4], rows[4:5]}, batches)
+	})
+
+	t.Run("reports the failed batch", func(t *testing.T) {
+		insertErr := errors.New("insert failed")
+		var calls int
+		inserter := func(_ context.Context, _ sqlconnect.RelationRef, _ []string, batch [][]any) error {
+			calls++
+			if calls == 2 {
+				return insertErr
+			}
+			return nil
+		}
+		err := InsertRows(ctx, relation, columns, slices.Values(rows), inserter, sqlconnect.WithBatchSize(2))
+		require.ErrorIs(t, err, insertErr)
+		var batchErr *sqlconnect.InsertBatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.Equal(t, 1, batchErr.Batch)
+		require.Equal(t, 2, batchErr.Offset)
+		require.Equal(t, 2, batchErr.Size)
+		require.Equal(t, relation, batchErr.Relation)
+		require.Equal(t, 2, calls, "it should stop inserting after the first failure")
+	})
+
+	t.Run("rejects rows with wrong number of values", func(t *testing.T) {
+		inserter := func(context.Context, sqlconnect.RelationRef, []string, [][]any) error { return nil }
+		err := InsertRows(ctx, relation, columns, slices.Values([][]any{{1, "a"}, {2}}), inserter)
+		var batchErr *sqlconnect.InsertBatchError
+		require.ErrorAs(t, err, &batchErr)
+		require.ErrorContains(t, err, "row 1 has 1 values, expected 2")
+	})
+
+	t.Run("rejects empty columns", func(t *testing.T) {
+		inserter := func(context.Context, sqlconnect.RelationRef, []string, [][]any) error { return nil }
+		err := InsertRows(ctx, relation, nil, slices.Values(rows), inserter)
+		require.ErrorContains(t, err, "no columns provided")
+	})
+
+	t.Run("with context cancelled", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		inserter := func(context.Context, sqlconnect.RelationRef, []string, [][]any) error { return nil }
+		err := InsertRows(cancelledCtx, relation, columns, slices.Values(rows), inserter)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
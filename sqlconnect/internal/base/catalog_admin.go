@@ -12,8 +12,9 @@ import (
 
 // CurrentCatalog returns the current catalog
 func (db *DB) CurrentCatalog(ctx context.Context) (sqlconnect.CatalogRef, error) {
+	ctx = WithOperation(ctx, "CurrentCatalog")
 	var catalogName string
-	if err := db.QueryRowContext(ctx, db.sqlCommands.CurrentCatalog()).Scan(&catalogName); err != nil {
+	if err := db.QueryRowScan(ctx, db.sqlCommands.CurrentCatalog(), nil, &catalogName); err != nil {
 		return sqlconnect.CatalogRef{}, fmt.Errorf("getting current catalog: %w", err)
 	}
 	return sqlconnect.CatalogRef{Name: catalogName}, nil
@@ -21,6 +22,7 @@ func (db *DB) CurrentCatalog(ctx context.Context) (sqlconnect.CatalogRef, error)
 
 // ListCatalogs returns a list of catalogs
 func (db *DB) ListCatalogs(ctx context.Context) ([]sqlconnect.CatalogRef, error) {
+	ctx = WithOperation(ctx, "ListCatalogs")
 	var res []sqlconnect.CatalogRef
 	stmt, colName := db.sqlCommands.ListCatalogs()
 	rows, err := db.QueryContext(ctx, stmt)
@@ -60,7 +62,7 @@ func (db *DB) ListCatalogs(ctx context.Context) ([]sqlconnect.CatalogRef, error)
 		res = append(res, catalog)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating list catalogs: %w", err)
+		return nil, fmt.Errorf("iterating list catalogs: %w", db.ClassifyError(err))
 	}
 	return res, nil
 }
@@ -0,0 +1,182 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// ColumnDetailsColumns holds the names of the columns in a result set that point to each column detail.
+// Details with an empty column name are not available in the result set.
+type ColumnDetailsColumns struct {
+	Name          string
+	Type          string
+	Ordinal       string // if empty, the position of the row in the result set is used instead
+	Nullable      string
+	Precision     string // if empty, it is parsed from the column's raw type instead, e.g. NUMBER(38,0)
+	Scale         string // if empty, it is parsed from the column's raw type instead, e.g. NUMBER(38,0)
+	MaxLength     string // if empty, it is parsed from the column's raw type instead, e.g. VARCHAR(255)
+	Default       string
+	Comment       string
+	PrimaryKey    string
+	PartitionKey  string
+	ClusteringKey string
+}
+
+// InformationSchemaColumnDetails are the names of the columns holding each column detail in information_schema.columns, along with column_comment and is_primary_key
+var InformationSchemaColumnDetails = ColumnDetailsColumns{
+	Name:       "column_name",
+	Type:       "data_type",
+	Ordinal:    "ordinal_position",
+	Nullable:   "is_nullable",
+	Precision:  "numeric_precision",
+	Scale:      "numeric_scale",
+	MaxLength:  "character_maximum_length",
+	Default:    "column_default",
+	Comment:    "column_comment",
+	PrimaryKey: "is_primary_key",
+}
+
+// ListColumnDetails returns a list of columns for the given table along with their metadata
+func (db *DB) ListColumnDetails(ctx context.Context, relation sqlconnect.RelationRef) ([]sqlconnect.ColumnDetails, error) {
+	ctx = WithOperation(ctx, "ListColumnDetails", relation)
+	stmt, detailCols := db.sqlCommands.ListColumnDetails(UnquotedIdentifier(relation.Catalog), UnquotedIdentifier(relation.Schema), UnquotedIdentifier(relation.Name))
+	rows, err := db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("querying list column details for %s: %w", relation, err)
+	}
+	defer func() { _ = rows.Close() }()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("getting columns in list column details for %s: %w", relation, err)
+	}
+	cols = lo.Map(cols, func(col string, _ int) string { return strings.ToLower(col) })
+
+	// all values are scanned as strings and parsed afterwards, since their types differ between warehouses
+	values := make([]sql.NullString, len(cols))
+	scanValues := lo.Map(values, func(_ sql.NullString, i int) any { return &values[i] })
+	indexOf := func(col string) int {
+		if col == "" {
+			return -1
+		}
+		return lo.IndexOf(cols, strings.ToLower(col))
+	}
+	valueOf := func(col string) sql.NullString {
+		if idx := indexOf(col); idx != -1 {
+			return values[idx]
+		}
+		return sql.NullString{}
+	}
+	for _, col := range []string{detailCols.Name, detailCols.Type} {
+		if indexOf(col) == -1 {
+			return nil, fmt.Errorf("column %s not found in result set: %+v", col, cols)
+		}
+	}
+
+	var res []sqlconnect.ColumnDetails
+	for rows.Next() {
+		if err := rows.Scan(scanValues...); err != nil {
+			return nil, fmt.Errorf("scanning list column details for %s: %w", relation, err)
+		}
+		column := sqlconnect.ColumnDetails{
+			ColumnRef: sqlconnect.ColumnRef{
+				Name:    valueOf(detailCols.Name).String,
+				RawType: valueOf(detailCols.Type).String,
+			},
+			Ordinal:       len(res) + 1,
+			Nullable:      isTruthy(valueOf(detailCols.Nullable)),
+			Comment:       valueOf(detailCols.Comment).String,
+			PrimaryKey:    isTruthy(valueOf(detailCols.PrimaryKey)),
+			PartitionKey:  isTruthy(valueOf(detailCols.PartitionKey)),
+			ClusteringKey: isTruthy(valueOf(detailCols.ClusteringKey)),
+		}
+		column.Type = db.columnTypeMapper(colRefTypeAdapter{column.ColumnRef})
+		column.TypeTree = db.typeTreeMapper(colRefTypeAdapter{column.ColumnRef})
+		if ordinal, ok := parseInt(valueOf(detailCols.Ordinal)); ok {
+			column.Ordinal = int(ordinal)
+		}
+		if defaultValue := valueOf(detailCols.Default); defaultValue.Valid {
+			column.Default = &defaultValue.String
+		}
+		column.Precision, column.Scale, column.MaxLength = parseTypeModifiers(column.RawType)
+		if detailCols.Precision != "" {
+			column.Precision = intPtr(parseInt(valueOf(detailCols.Precision)))
+		}
+		if detailCols.Scale != "" {
+			column.Scale = intPtr(parseInt(valueOf(detailCols.Scale)))
+		}
+		if detailCols.MaxLength != "" {
+			column.MaxLength = intPtr(parseInt(valueOf(detailCols.MaxLength)))
+		}
+		res = append(res, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating list column details for %s: %w", relation, db.ClassifyError(err))
+	}
+
+	// check if relation exists before returning columns
+	if len(res) == 0 {
+		return nil, fmt.Errorf("cannot fetch column details for %s: relation does not exist", relation)
+	}
+	return res, nil
+}
+
+var typeModifiersRegex = regexp.MustCompile(`^\s*([a-zA-Z_ ]+?)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)`)
+
+// parseTypeModifiers parses the precision and scale of numeric types, e.g. DECIMAL(10,2), or the maximum length of character and binary types, e.g. VARCHAR(255)
+func parseTypeModifiers(rawType string) (precision, scale, maxLength *int64) {
+	matches := typeModifiersRegex.FindStringSubmatch(rawType)
+	if matches == nil {
+		return nil, nil, nil
+	}
+	typeName := strings.ToUpper(matches[1])
+	first, _ := strconv.ParseInt(matches[2], 10, 64)
+	switch {
+	case lo.Contains([]string{"DECIMAL", "DEC", "NUMERIC", "NUMBER", "BIGNUMERIC", "BIGDECIMAL"}, typeName):
+		var second int64
+		if matches[3] != "" {
+			second, _ = strconv.ParseInt(matches[3], 10, 64)
+		}
+		return &first, &second, nil
+	case lo.SomeBy([]string{"CHAR", "STRING", "TEXT", "BINARY", "BYTES"}, func(s string) bool { return strings.Contains(typeName, s) }):
+		return nil, nil, &first
+	}
+	return nil, nil, nil
+}
+
+// isTruthy returns true for values denoting a positive flag in the various warehouses, e.g. YES, Y, true or 1
+func isTruthy(v sql.NullString) bool {
+	if !v.Valid {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(v.String)) {
+	case "yes", "y", "true", "t", "1":
+		return true
+	}
+	return false
+}
+
+func parseInt(v sql.NullString) (int64, bool) {
+	if !v.Valid {
+		return 0, false
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(v.String), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return i, true
+}
+
+func intPtr(v int64, ok bool) *int64 {
+	if !ok {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,45 @@
+package base
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypeModifiers(t *testing.T) {
+	for _, tc := range []struct {
+		rawType   string
+		precision *int64
+		scale     *int64
+		maxLength *int64
+	}{
+		{rawType: "NUMBER(38,0)", precision: lo.ToPtr[int64](38), scale: lo.ToPtr[int64](0)},
+		{rawType: "decimal(10, 2)", precision: lo.ToPtr[int64](10), scale: lo.ToPtr[int64](2)},
+		{rawType: "NUMERIC(12)", precision: lo.ToPtr[int64](12), scale: lo.ToPtr[int64](0)},
+		{rawType: "VARCHAR(255)", maxLength: lo.ToPtr[int64](255)},
+		{rawType: "character varying(16)", maxLength: lo.ToPtr[int64](16)},
+		{rawType: "STRING(10)", maxLength: lo.ToPtr[int64](10)},
+		{rawType: "TIMESTAMP(6) WITH TIME ZONE"},
+		{rawType: "INT64"},
+		{rawType: "text"},
+	} {
+		t.Run(tc.rawType, func(t *testing.T) {
+			precision, scale, maxLength := parseTypeModifiers(tc.rawType)
+			require.Equal(t, tc.precision, precision, "precision")
+			require.Equal(t, tc.scale, scale, "scale")
+			require.Equal(t, tc.maxLength, maxLength, "max length")
+		})
+	}
+}
+
+func TestIsTruthy(t *testing.T) {
+	for _, v := range []string{"YES", "y", "true", "T", "1"} {
+		require.True(t, isTruthy(sql.NullString{String: v, Valid: true}), v)
+	}
+	for _, v := range []string{"NO", "n", "false", "0", ""} {
+		require.False(t, isTruthy(sql.NullString{String: v, Valid: true}), v)
+	}
+	require.False(t, isTruthy(sql.NullString{}), "null")
+}
@@ -1,13 +1,19 @@
 package base
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
 
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
 )
 
 func NewDB(db *sql.DB, tunnelCloser func() error, opts ...Option) *DB {
@@ -15,12 +21,19 @@ func NewDB(db *sql.DB, tunnelCloser func() error, opts ...Option) *DB {
 		DB:           db,
 		Dialect:      Dialect{},
 		tunnelCloser: tunnelCloser,
+		retryPolicy:  &atomic.Pointer[sqlconnect.RetryPolicy]{},
 		columnTypeMapper: func(c ColumnType) string {
 			return c.DatabaseTypeName()
 		},
 		jsonRowMapper: func(databaseTypeName string, value any) any {
 			return value
 		},
+		typeTreeMapper: func(ColumnType) *sqlconnect.TypeNode {
+			return nil
+		},
+		errorClassifier: func(error) []error {
+			return nil
+		},
 		sqlCommands: SQLCommands{
 			CurrentCatalog: func() string {
 				return "SELECT current_catalog"
@@ -51,6 +64,15 @@ func NewDB(db *sql.DB, tunnelCloser func() error, opts ...Option) *DB {
 			CreateTestTable: func(table QuotedIdentifier) string {
 				return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %[1]s (c1 INT, c2 VARCHAR(255))", table)
 			},
+			CreateTable: func(table QuotedIdentifier, columns []string, primaryKey []QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []QuotedIdentifier) (string, error) {
+				if len(partitionBy) > 0 {
+					return "", fmt.Errorf("partitioning tables: %w", sqlconnect.ErrNotSupported)
+				}
+				if len(clusterBy) > 0 {
+					return "", fmt.Errorf("clustering tables: %w", sqlconnect.ErrNotSupported)
+				}
+				return CreateTableCommand(table, ifNotExists, slices.Concat(columns, PrimaryKeyConstraint(primaryKey))), nil
+			},
 			ListTables: func(catalog, schema UnquotedIdentifier, prefix string) []lo.Tuple2[string, string] {
 				stmt := fmt.Sprintf("SELECT table_name FROM information_schema.tables WHERE table_schema = '%[1]s'", EscapeSqlString(schema))
 				if catalog != "" {
@@ -77,6 +99,30 @@ func NewDB(db *sql.DB, tunnelCloser func() error, opts ...Option) *DB {
 				}
 				return stmt + " ORDER BY ordinal_position ASC", "column_name", "data_type"
 			},
+			ListColumnDetails: func(catalog, schema, table UnquotedIdentifier) (string, ColumnDetailsColumns) {
+				stmt := fmt.Sprintf(`SELECT c.column_name, c.data_type, c.ordinal_position, c.is_nullable, c.numeric_precision, c.numeric_scale, c.character_maximum_length, c.column_default,
+					col_description(format('%%I.%%I', c.table_schema, c.table_name)::regclass, c.ordinal_position) AS column_comment,
+					EXISTS (
+						SELECT 1 FROM information_schema.table_constraints tc
+						JOIN information_schema.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name
+						WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name AND kcu.column_name = c.column_name
+					) AS is_primary_key
+					FROM information_schema.columns c WHERE c.table_schema = '%[1]s' AND c.table_name = '%[2]s'`, EscapeSqlString(schema), EscapeSqlString(table))
+				if catalog != "" {
+					stmt += fmt.Sprintf(" AND c.table_catalog = '%[1]s'", EscapeSqlString(catalog))
+				}
+				return stmt + " ORDER BY c.ordinal_position ASC", InformationSchemaColumnDetails
+			},
+			DescribeQuery: func(sql string) (string, string, string) {
+				if !IsSelectQuery(sql) { // other statements can't be wrapped, thus they can only be described by executing them
+					return "", "", ""
+				}
+				// a query with LIMIT 0 is only planned, without reading any data
+				return fmt.Sprintf("SELECT * FROM (%[1]s) AS sqlconnect_query LIMIT 0", TrimQuery(sql)), "", ""
+			},
+			ExplainQuery: func(sql string) (string, func(string) (sqlconnect.QueryEstimate, error)) {
+				return "EXPLAIN " + TrimQuery(sql), ParseTextQueryPlan
+			},
 			CountTableRows: func(table QuotedIdentifier) string { return fmt.Sprintf("SELECT COUNT(*) FROM %[1]s", table) },
 			DropTable:      func(table QuotedIdentifier) string { return fmt.Sprintf("DROP TABLE IF EXISTS %[1]s", table) },
 			TruncateTable:  func(table QuotedIdentifier) string { return fmt.Sprintf("TRUNCATE TABLE %[1]s", table) },
@@ -86,27 +132,72 @@ func NewDB(db *sql.DB, tunnelCloser func() error, opts ...Option) *DB {
 			MoveTable: func(schema, oldName, newName QuotedIdentifier) string {
 				return fmt.Sprintf("CREATE TABLE %[1]s.%[3]s AS SELECT * FROM %[1]s.%[2]s", schema, oldName, newName)
 			},
+			AddColumns: func(table QuotedIdentifier, columns []string) []string {
+				return []string{fmt.Sprintf("ALTER TABLE %[1]s %[2]s", table, strings.Join(lo.Map(columns, func(col string, _ int) string { return "ADD COLUMN " + col }), ", "))}
+			},
+			DropColumns: func(table QuotedIdentifier, columns []QuotedIdentifier) []string {
+				return []string{fmt.Sprintf("ALTER TABLE %[1]s %[2]s", table, strings.Join(lo.Map(columns, func(col QuotedIdentifier, _ int) string { return "DROP COLUMN " + string(col) }), ", "))}
+			},
+			RenameColumn: func(table, oldName, newName QuotedIdentifier) string {
+				return fmt.Sprintf("ALTER TABLE %[1]s RENAME COLUMN %[2]s TO %[3]s", table, oldName, newName)
+			},
+			AlterColumnType: func(table, column QuotedIdentifier, _, _, ddlType string) ([]string, error) {
+				return []string{fmt.Sprintf("ALTER TABLE %[1]s ALTER COLUMN %[2]s TYPE %[3]s USING %[2]s::%[3]s", table, column, ddlType)}, nil
+			},
+			Merge: func(target, source QuotedIdentifier, keyColumns, updateColumns []QuotedIdentifier) []string {
+				columns := JoinQuotedIdentifiers(slices.Concat(keyColumns, updateColumns), "")
+				action := "DO NOTHING"
+				if len(updateColumns) > 0 {
+					action = "DO UPDATE SET " + strings.Join(lo.Map(updateColumns, func(col QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("%[1]s = EXCLUDED.%[1]s", col)
+					}), ", ")
+				}
+				return []string{
+					fmt.Sprintf("INSERT INTO %[1]s (%[3]s) SELECT %[3]s FROM %[2]s ON CONFLICT (%[4]s) %[5]s", target, source, columns, JoinQuotedIdentifiers(keyColumns, ""), action),
+				}
+			},
 		},
 	}
 	for _, opt := range opts {
 		opt(d)
 	}
+	d.poolMetricsCloser = telemetry.RegisterPoolMetrics(db, d.telemetryAttributes...)
 	return d
 }
 
 type DB struct {
 	*sql.DB
 	sqlconnect.Dialect
-	tunnelCloser func() error // closer for the ssh tunnel to be called on close
+	tunnelCloser func() error         // closer for the ssh tunnel to be called on close
+	sshTunnel    sqlconnect.SSHTunnel // the ssh tunnel the db connects through, if any
+
+	columnTypeMapper    func(ColumnType) string // map from database type to rudder type
+	jsonRowMapper       func(databaseTypeName string, value any) any
+	typeTreeMapper      func(ColumnType) *sqlconnect.TypeNode   // map from database type to a type tree, for nested types
+	columnDDLTypes      map[string]string                       // map from rudder type to database type, used for creating tables
+	errorClassifier     ErrorClassifier                         // classifies driver errors into warehouse-agnostic error classes
+	retryPolicy         *atomic.Pointer[sqlconnect.RetryPolicy] // policy for retrying idempotent operations, storing nil if retries are disabled
+	interceptors        []sqlconnect.Interceptor                // interceptors to run around queries and statements
+	telemetryAttributes []attribute.KeyValue                    // attributes identifying the database in spans and metrics, i.e. db.system and db.namespace
+	poolMetricsCloser   func() error                            // stops reporting the connection pool metrics
+	sqlCommands         SQLCommands
+
+	queryTagContext func(ctx context.Context, tags map[string]string) context.Context // applies query tags to the context passed to the driver, if the driver supports them natively
+	queryTagSession func(tags map[string]string) (set, reset string)                  // returns the statements for tagging a session, if query tags are session settings
+
+	asyncQueries AsyncQueries // functions for running queries asynchronously, if supported by the driver
+	arrowQuery   ArrowQuery   // runs queries returning their results natively as arrow records, if supported by the driver
+}
 
-	columnTypeMapper func(ColumnType) string // map from database type to rudder type
-	jsonRowMapper    func(databaseTypeName string, value any) any
-	sqlCommands      SQLCommands
+// SSHTunnel returns the ssh tunnel the db connects through, see [WithSSHTunnel]
+func (d *DB) SSHTunnel() sqlconnect.SSHTunnel {
+	return d.sshTunnel
 }
 
 // Close closes the db and the tunnel
 func (d *DB) Close() error {
 	return errors.Join(
+		d.poolMetricsCloser(),
 		d.DB.Close(),     // first close the db
 		d.tunnelCloser(), // then close the tunnel
 	)
@@ -152,12 +243,25 @@ type (
 		DropSchema func(schema QuotedIdentifier) string
 		// Provides the SQL command to create a test table
 		CreateTestTable func(table QuotedIdentifier) string
+		// Provides the SQL command to create a table using the provided column definitions and primary key, optionally partitioned and clustered by the provided columns.
+		// Returns an error if an option is not supported.
+		CreateTable func(table QuotedIdentifier, columns []string, primaryKey []QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []QuotedIdentifier) (string, error)
 		// Provides the SQL command(s) to list tables in a schema, optionally filtered by catalog and/or prefix
 		ListTables func(catalog, schema UnquotedIdentifier, prefix string) (sqlAndColumnNamePairs []lo.Tuple2[string, string])
 		// Provides the SQL command to check if a table exists, optionally within a catalog
 		TableExists func(catalog, schema, table UnquotedIdentifier) string
 		// Provides the SQL command to list all columns in a table along with the column names in the result set that point to the name and type
 		ListColumns func(catalog, schema, table UnquotedIdentifier) (sql, nameCol, typeCol string)
+		// Provides the SQL command to list all columns in a table along with their metadata and the names of the columns in the result set that point to each detail
+		ListColumnDetails func(catalog, schema, table UnquotedIdentifier) (sql string, columns ColumnDetailsColumns)
+		// Provides the SQL command to describe the columns of a sql query without executing it.
+		// If the name and type columns are empty, the columns of the command's result set are used instead.
+		// An empty command means that the query cannot be described without executing it.
+		DescribeQuery func(sql string) (stmt, nameCol, typeCol string)
+		// Provides the SQL command to explain a sql query without executing it, along with the function for extracting an estimate out of the resulting plan.
+		// The plan is the first column of the command's result set, with multiple rows joined by newlines.
+		// A nil function means that the warehouse cannot estimate queries.
+		ExplainQuery func(sql string) (stmt string, parsePlan func(plan string) (sqlconnect.QueryEstimate, error))
 		// Provides the SQL command to count the rows in a table
 		CountTableRows func(table QuotedIdentifier) string
 		// Provides the SQL command to drop a table
@@ -168,5 +272,17 @@ type (
 		RenameTable func(schema, oldName, newName QuotedIdentifier) string
 		// Provides the SQL command to move a table
 		MoveTable func(schema, oldName, newName QuotedIdentifier) string
+		// Provides the SQL command(s) to add the provided column definitions to a table
+		AddColumns func(table QuotedIdentifier, columns []string) []string
+		// Provides the SQL command(s) to drop columns from a table
+		DropColumns func(table QuotedIdentifier, columns []QuotedIdentifier) []string
+		// Provides the SQL command to rename a column
+		RenameColumn func(table, oldName, newName QuotedIdentifier) string
+		// Provides the SQL command(s) to change the type of a column, given its current and new rudder types along with the new database type.
+		// Returns an error wrapping [sqlconnect.ErrNotSupported] if the column's type cannot be changed.
+		AlterColumnType func(table, column QuotedIdentifier, fromType, toType, ddlType string) ([]string, error)
+		// Provides the SQL command(s) to merge the rows of a source table into a target table, matching rows on the key columns.
+		// Multiple commands are executed within a single transaction.
+		Merge func(target, source QuotedIdentifier, keyColumns, updateColumns []QuotedIdentifier) []string
 	}
 )
@@ -0,0 +1,31 @@
+package base
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestSSHTunnel(t *testing.T) {
+	db := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil })
+	defer func() { _ = db.Close() }()
+	require.Nil(t, db.SSHTunnel(), "it should report no tunnel if none is configured")
+
+	tunnel := fakeSSHTunnel{fingerprint: "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s"}
+	tunnelled := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil }, WithSSHTunnel(tunnel))
+	defer func() { _ = tunnelled.Close() }()
+	require.Equal(t, tunnel, tunnelled.SSHTunnel())
+	require.Equal(t, tunnel, tunnelled.WithInterceptors().SSHTunnel(), "it should share the tunnel with copies of the db")
+}
+
+type fakeSSHTunnel struct {
+	fingerprint string
+}
+
+func (t fakeSSHTunnel) HostKeyFingerprint() string                      { return t.fingerprint }
+func (t fakeSSHTunnel) JumpHostKeyFingerprints() []string               { return nil }
+func (t fakeSSHTunnel) Status() sqlconnect.SSHTunnelStatus              { return sqlconnect.SSHTunnelStatus{} }
+func (t fakeSSHTunnel) OnStatusChange(func(sqlconnect.SSHTunnelStatus)) {}
@@ -1,8 +1,14 @@
 package base
 
 import (
+	"context"
+	"fmt"
+	"regexp"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 )
 
@@ -30,6 +36,41 @@ func WithColumnTypeMapper(columnTypeMapper func(ColumnType) string) Option {
 	}
 }
 
+// WithTypeTreeMapper sets the mapper used for parsing the type trees of nested columns
+func WithTypeTreeMapper(typeTreeMapper func(ColumnType) *sqlconnect.TypeNode) Option {
+	return func(db *DB) {
+		db.typeTreeMapper = typeTreeMapper
+	}
+}
+
+// WithColumnDDLTypes sets the database types to be used for each rudder type when creating tables, see [ColumnDDLTypes]
+func WithColumnDDLTypes(columnDDLTypes map[string]string) Option {
+	return func(db *DB) {
+		db.columnDDLTypes = columnDDLTypes
+	}
+}
+
+// ColumnDDLTypes derives the database types to be used for each rudder type when creating tables from the column type mappings, i.e. by reversing the mappings of the provided database types,
+// so that tables created from rudder types report the same rudder types back. Parameters of the database types, e.g. the length of VARCHAR(255), are ignored when looking them up in the mappings.
+// It panics if a database type isn't mapped or if more than one database type maps to the same rudder type.
+func ColumnDDLTypes(columnTypeMappings map[string]string, databaseTypes ...string) map[string]string {
+	columnDDLTypes := make(map[string]string, len(databaseTypes))
+	for _, databaseType := range databaseTypes {
+		rudderType, ok := columnTypeMappings[typeParameters.ReplaceAllString(databaseType, "")]
+		if !ok {
+			panic(fmt.Sprintf("database type %s is not mapped to any rudder type", databaseType))
+		}
+		if other, ok := columnDDLTypes[rudderType]; ok {
+			panic(fmt.Sprintf("database types %s and %s are both mapped to rudder type %s", other, databaseType, rudderType))
+		}
+		columnDDLTypes[rudderType] = databaseType
+	}
+	return columnDDLTypes
+}
+
+// typeParameters matches the parameters of a database type, e.g. (255) in VARCHAR(255)
+var typeParameters = regexp.MustCompile(`\(.*?\)`)
+
 // WithJsonRowMapper sets the json row mapper for the client
 func WithJsonRowMapper(jsonRowMapper func(string, any) any) Option {
 	return func(db *DB) {
@@ -37,6 +78,63 @@ func WithJsonRowMapper(jsonRowMapper func(string, any) any) Option {
 	}
 }
 
+// WithErrorClassifier sets the classifier used for wrapping driver errors into warehouse-agnostic error classes
+func WithErrorClassifier(errorClassifier ErrorClassifier) Option {
+	return func(db *DB) {
+		db.errorClassifier = errorClassifier
+	}
+}
+
+// WithTelemetry sets the db.system and db.namespace attributes reported in the client's spans and metrics, e.g. "postgresql" and the name of the database
+func WithTelemetry(system, namespace string) Option {
+	return func(db *DB) {
+		db.telemetryAttributes = []attribute.KeyValue{semconv.DBSystemKey.String(system)}
+		if namespace != "" {
+			db.telemetryAttributes = append(db.telemetryAttributes, semconv.DBNamespaceKey.String(namespace))
+		}
+	}
+}
+
+// WithSSHTunnel sets the ssh tunnel the db connects through, which is closed using the tunnel closer of [NewDB]
+func WithSSHTunnel(tunnel sqlconnect.SSHTunnel) Option {
+	return func(db *DB) {
+		db.sshTunnel = tunnel
+	}
+}
+
+// WithQueryTagContext sets the function applying the query tags of a context to the context passed to the driver, for drivers supporting query tags natively.
+// Otherwise, queries and statements are tagged using a leading sql comment, see [QueryTagComment].
+func WithQueryTagContext(queryTagContext func(ctx context.Context, tags map[string]string) context.Context) Option {
+	return func(db *DB) {
+		db.queryTagContext = queryTagContext
+	}
+}
+
+// WithQueryTagSession sets the function returning the statements setting and resetting the query tags of a session, e.g. for setting Redshift's query_group.
+// Statements are executed using a dedicated connection whose session is tagged for their duration, while queries are tagged using a leading sql comment,
+// since the connection cannot be reset before their rows are consumed.
+func WithQueryTagSession(queryTagSession func(tags map[string]string) (set, reset string)) Option {
+	return func(db *DB) {
+		db.queryTagSession = queryTagSession
+	}
+}
+
+// WithAsyncQueries sets the functions for running queries asynchronously, for drivers supporting submitting queries and fetching their results by id.
+// Otherwise, [DB.SubmitQuery], [DB.QueryStatus], [DB.FetchResults] and [DB.CancelQuery] return [sqlconnect.ErrNotSupported].
+func WithAsyncQueries(asyncQueries AsyncQueries) Option {
+	return func(db *DB) {
+		db.asyncQueries = asyncQueries
+	}
+}
+
+// WithArrowQuery sets the function for running queries returning their results natively as arrow records, for drivers supporting it.
+// Otherwise, [DB.QueryArrow] converts the rows of queries into arrow records using the column type mappings.
+func WithArrowQuery(arrowQuery ArrowQuery) Option {
+	return func(db *DB) {
+		db.arrowQuery = arrowQuery
+	}
+}
+
 // WithDialect sets the dialect for the client
 func WithDialect(dialect sqlconnect.Dialect) Option {
 	return func(db *DB) {
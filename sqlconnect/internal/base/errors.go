@@ -0,0 +1,112 @@
+package base
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// ErrorClassifier returns the warehouse-agnostic error classes a driver error belongs to, e.g. [sqlconnect.ErrRelationNotFound], or nothing if it cannot be classified
+type ErrorClassifier func(err error) []error
+
+// ClassifyError wraps the error into the error classes returned by the db's [ErrorClassifier], along with the ones returned by [ClassifyCommonError].
+// Errors that have already been classified are returned as is.
+func (db *DB) ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := errors.AsType[*classifiedError](err); ok {
+		return err
+	}
+	classes := lo.Uniq(append(db.errorClassifier(err), ClassifyCommonError(err)...))
+	if len(classes) == 0 {
+		return err
+	}
+	return &classifiedError{err: err, classes: classes}
+}
+
+// classifiedError is an error along with the error classes it belongs to, matching both the original error and its classes
+type classifiedError struct {
+	err     error
+	classes []error
+}
+
+func (e *classifiedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *classifiedError) Unwrap() []error {
+	return append([]error{e.err}, e.classes...)
+}
+
+// ClassifyCommonError classifies errors that are common to all drivers, i.e. context deadlines and broken connections
+func ClassifyCommonError(err error) []error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return []error{sqlconnect.ErrQueryTimeout}
+	case errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE):
+		return []error{sqlconnect.ErrRetryable}
+	}
+	if netErr, ok := errors.AsType[net.Error](err); ok && netErr.Timeout() {
+		return []error{sqlconnect.ErrRetryable}
+	}
+	return nil
+}
+
+// ClassifySQLState classifies an error using its ANSI SQLSTATE code, as reported by postgres and most other warehouses
+func ClassifySQLState(state string) []error {
+	switch state {
+	case "42501":
+		return []error{sqlconnect.ErrPermissionDenied}
+	case "42P01", "42S02":
+		return []error{sqlconnect.ErrRelationNotFound}
+	case "3F000", "3D000":
+		return []error{sqlconnect.ErrSchemaNotFound}
+	case "42601":
+		return []error{sqlconnect.ErrSyntax}
+	case "53300": // too many connections
+		return []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}
+	case "40001", "40P01", // serialization failure, deadlock
+		"57P01", "57P02", "57P03": // server shutting down or starting up
+		return []error{sqlconnect.ErrRetryable}
+	}
+	if len(state) == 5 {
+		switch state[:2] {
+		case "28": // invalid authorization
+			return []error{sqlconnect.ErrAuthentication}
+		case "08", "53": // connection exception, insufficient resources
+			return []error{sqlconnect.ErrRetryable}
+		}
+	}
+	return nil
+}
+
+// ClassifyHTTPStatus classifies an error using the status code of the http response that caused it, for warehouses accessed over http
+func ClassifyHTTPStatus(statusCode int) []error {
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return []error{sqlconnect.ErrAuthentication}
+	case http.StatusForbidden:
+		return []error{sqlconnect.ErrPermissionDenied}
+	case http.StatusRequestTimeout:
+		return []error{sqlconnect.ErrQueryTimeout, sqlconnect.ErrRetryable}
+	case http.StatusTooManyRequests:
+		return []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return []error{sqlconnect.ErrRetryable}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package base
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	driverErr := errors.New("relation does not exist")
+	db := NewDB(nil, func() error { return nil }, WithErrorClassifier(func(err error) []error {
+		if errors.Is(err, driverErr) {
+			return []error{sqlconnect.ErrRelationNotFound}
+		}
+		return nil
+	}))
+
+	t.Run("nil error", func(t *testing.T) {
+		require.NoError(t, db.ClassifyError(nil))
+	})
+
+	t.Run("classified error", func(t *testing.T) {
+		err := db.ClassifyError(fmt.Errorf("querying: %w", driverErr))
+		require.ErrorIs(t, err, sqlconnect.ErrRelationNotFound)
+		require.ErrorIs(t, err, driverErr)
+		require.NotErrorIs(t, err, sqlconnect.ErrRetryable)
+		require.Equal(t, "querying: relation does not exist", err.Error())
+		require.Same(t, err, db.ClassifyError(err), "it should not classify an error twice")
+	})
+
+	t.Run("common error", func(t *testing.T) {
+		err := db.ClassifyError(fmt.Errorf("querying: %w", driver.ErrBadConn))
+		require.ErrorIs(t, err, sqlconnect.ErrRetryable)
+		require.ErrorIs(t, db.ClassifyError(context.DeadlineExceeded), sqlconnect.ErrQueryTimeout)
+	})
+
+	t.Run("unclassified error", func(t *testing.T) {
+		err := errors.New("other")
+		require.Same(t, err, db.ClassifyError(err))
+	})
+}
+
+func TestClassifySQLState(t *testing.T) {
+	require.Equal(t, []error{sqlconnect.ErrRelationNotFound}, ClassifySQLState("42P01"))
+	require.Equal(t, []error{sqlconnect.ErrAuthentication}, ClassifySQLState("28P01"))
+	require.Equal(t, []error{sqlconnect.ErrRetryable}, ClassifySQLState("08006"))
+	require.Empty(t, ClassifySQLState("22012"))
+}
+
+func TestClassifyHTTPStatus(t *testing.T) {
+	require.Equal(t, []error{sqlconnect.ErrAuthentication}, ClassifyHTTPStatus(401))
+	require.Equal(t, []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}, ClassifyHTTPStatus(429))
+	require.Empty(t, ClassifyHTTPStatus(404))
+}
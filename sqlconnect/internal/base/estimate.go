@@ -0,0 +1,124 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/util"
+)
+
+// EstimateQuery returns an estimate of the work the given sql query will perform, by explaining it using [SQLCommands.ExplainQuery]
+func (db *DB) EstimateQuery(ctx context.Context, sql string) (sqlconnect.QueryEstimate, error) {
+	ctx = WithOperation(ctx, "EstimateQuery")
+	if db.sqlCommands.ExplainQuery == nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("estimating query: %w", sqlconnect.ErrNotSupported)
+	}
+	// the explain statement may be sent using the simple query protocol, which executes every statement following the explained one
+	if len(util.SplitStatements(sql)) > 1 {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("estimating query with multiple statements: %w", sqlconnect.ErrNotSupported)
+	}
+	stmt, parsePlan := db.sqlCommands.ExplainQuery(sql)
+	plan, err := db.queryPlan(ctx, stmt)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, err
+	}
+	estimate, err := parsePlan(plan)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("parsing query plan: %w", err)
+	}
+	estimate.Plan = plan
+	return estimate, nil
+}
+
+// queryPlan executes the given explain statement and returns the first column of its result set, joining multiple rows with newlines
+func (db *DB) queryPlan(ctx context.Context, stmt string) (string, error) {
+	rows, err := db.QueryContext(ctx, stmt)
+	if err != nil {
+		return "", fmt.Errorf("querying explain sql query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	cols, err := rows.Columns()
+	if err != nil {
+		return "", fmt.Errorf("getting columns in explain sql query: %w", err)
+	}
+	var (
+		line     sql.NullString
+		otherCol sqlconnect.NilAny
+		lines    []string
+	)
+	scanValues := make([]any, len(cols))
+	for i := range cols {
+		if i == 0 {
+			scanValues[i] = &line
+		} else {
+			scanValues[i] = &otherCol
+		}
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanValues...); err != nil {
+			return "", fmt.Errorf("scanning explain sql query: %w", err)
+		}
+		if line.Valid {
+			lines = append(lines, line.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("iterating explain sql query: %w", db.ClassifyError(err))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// textPlanNodeRegex matches the estimates of a node in a postgres or redshift text plan, e.g. Seq Scan on t  (cost=0.00..35.50 rows=2550 width=4)
+var textPlanNodeRegex = regexp.MustCompile(`\(cost=[0-9.]+\.\.([0-9.]+) rows=([0-9]+) width=[0-9]+\)`)
+
+// ParseTextQueryPlan extracts an estimate out of a postgres or redshift plan in text format, using the estimates of its root node
+func ParseTextQueryPlan(plan string) (sqlconnect.QueryEstimate, error) {
+	match := textPlanNodeRegex.FindStringSubmatch(plan)
+	if match == nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("no cost estimates found in plan: %q", plan)
+	}
+	cost, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("parsing cost %q: %w", match[1], err)
+	}
+	rows, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("parsing rows %q: %w", match[2], err)
+	}
+	return sqlconnect.QueryEstimate{Rows: &rows, Cost: &cost}, nil
+}
+
+// byteSizeRegex matches a byte size along with its unit, e.g. 25B, 1.2kB, 3.0 MiB or 5K
+var byteSizeRegex = regexp.MustCompile(`^([0-9]*\.?[0-9]+(?:[eE][+-]?[0-9]+)?)\s*([a-zA-Z]*)$`)
+
+// byteSizeUnits maps the lowercased prefixes of byte size units to their exponents, all units being powers of 1024
+var byteSizeUnits = map[string]float64{"": 0, "k": 1, "m": 2, "g": 3, "t": 4, "p": 5, "e": 6}
+
+// ParseByteSize parses a human-readable byte size, as reported by query planners, e.g. 25B, 1.2kB, 3.0 MiB or 5K.
+// Units are always interpreted as powers of 1024.
+func ParseByteSize(size string) (int64, error) {
+	match := byteSizeRegex.FindStringSubmatch(strings.TrimSpace(size))
+	if match == nil {
+		return 0, fmt.Errorf("invalid byte size: %q", size)
+	}
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing byte size %q: %w", size, err)
+	}
+	unit := strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(match[2]), "b"), "i")
+	exponent, ok := byteSizeUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("invalid byte size unit: %q", size)
+	}
+	value *= math.Pow(1024, exponent)
+	if value >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size out of range: %q", size)
+	}
+	return int64(value), nil
+}
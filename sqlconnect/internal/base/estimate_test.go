@@ -0,0 +1,71 @@
+package base
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestEstimateQuery(t *testing.T) {
+	db := NewDB(nil, func() error { return nil })
+	_, err := db.EstimateQuery(context.Background(), "SELECT 1; DROP TABLE t")
+	require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should reject multiple statements, without explaining or executing any of them")
+}
+
+func TestParseTextQueryPlan(t *testing.T) {
+	t.Run("postgres", func(t *testing.T) {
+		estimate, err := ParseTextQueryPlan("Hash Join  (cost=1.09..27.42 rows=6 width=36)\n  Hash Cond: (a.id = b.id)\n  ->  Seq Scan on a  (cost=0.00..22.70 rows=1270 width=36)")
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 6, *estimate.Rows)
+		require.NotNil(t, estimate.Cost)
+		require.InDelta(t, 27.42, *estimate.Cost, 0.001)
+		require.Nil(t, estimate.Bytes)
+		require.Nil(t, estimate.Partitions)
+	})
+
+	t.Run("redshift", func(t *testing.T) {
+		estimate, err := ParseTextQueryPlan("XN Seq Scan on t  (cost=0.00..0.05 rows=5 width=4)\n----- Tables missing statistics: t -----")
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 5, *estimate.Rows)
+		require.NotNil(t, estimate.Cost)
+		require.InDelta(t, 0.05, *estimate.Cost, 0.001)
+	})
+
+	t.Run("without estimates", func(t *testing.T) {
+		_, err := ParseTextQueryPlan("Result")
+		require.ErrorContains(t, err, "no cost estimates found in plan")
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	for _, tc := range []struct {
+		size     string
+		expected int64
+	}{
+		{size: "80", expected: 80},
+		{size: "25B", expected: 25},
+		{size: "5K", expected: 5 * 1024},
+		{size: "1.5kB", expected: 1536},
+		{size: "2.0 KiB", expected: 2048},
+		{size: "3MB", expected: 3 * 1024 * 1024},
+		{size: "1G", expected: 1024 * 1024 * 1024},
+	} {
+		t.Run(tc.size, func(t *testing.T) {
+			size, err := ParseByteSize(tc.size)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, size)
+		})
+	}
+
+	for _, size := range []string{"", "?", "1.0 XB", "8.0 EiB"} {
+		t.Run("invalid "+size, func(t *testing.T) {
+			_, err := ParseByteSize(size)
+			require.Error(t, err)
+		})
+	}
+}
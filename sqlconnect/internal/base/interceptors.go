@@ -0,0 +1,141 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors after the db's own interceptors
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) *DB {
+	clone := *db
+	clone.interceptors = slices.Concat(db.interceptors, interceptors)
+	return &clone
+}
+
+type operationKey struct{}
+
+// operationInfo describes the [DB] method performing the operations executed with a context
+type operationInfo struct {
+	method    string
+	relations []sqlconnect.RelationRef
+}
+
+// WithOperation returns a context annotated with the [DB] method performing any queries or statements executed with it, along with the relations it refers to, for reporting them to interceptors
+func WithOperation(ctx context.Context, method string, relations ...sqlconnect.RelationRef) context.Context {
+	return context.WithValue(ctx, operationKey{}, operationInfo{method: method, relations: relations})
+}
+
+// intercept performs the operation by running the db's interceptors around the given invoker, which is instrumented for reporting the operation as performed, i.e. after any changes made by the interceptors,
+// and tagged with the context's query tags
+func (db *DB) intercept(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+	if info, ok := ctx.Value(operationKey{}).(operationInfo); ok {
+		op.Method, op.Relations = info.method, info.relations
+	}
+	return sqlconnect.ChainInterceptors(db.interceptors, db.instrument(db.tagQuery(invoke)))(ctx, op)
+}
+
+// QueryContext overrides [sql.DB.QueryContext] for intercepting it, classifying its errors and retrying read-only queries
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	res, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationQuery, SQL: query, Args: args}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		rows, err := RetryWithData(ctx, db, IsReadOnlyQuery(op.SQL), func() (*sql.Rows, error) {
+			return db.DB.QueryContext(ctx, op.SQL, op.Args...) // nolint:rowserrcheck
+		})
+		return sqlconnect.OperationResult{Rows: rows}, err
+	})
+	return res.Rows, err
+}
+
+// Query overrides [sql.DB.Query] for intercepting it, classifying its errors and retrying read-only queries
+func (db *DB) Query(query string, args ...any) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+// ExecContext overrides [sql.DB.ExecContext] for intercepting it, classifying its errors and retrying read-only statements
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationExec, SQL: query, Args: args}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		result, err := RetryWithData(ctx, db, IsReadOnlyQuery(op.SQL), func() (sql.Result, error) {
+			return db.DB.ExecContext(ctx, op.SQL, op.Args...)
+		})
+		return sqlconnect.OperationResult{Result: result}, err
+	})
+	return res.Result, err
+}
+
+// Exec overrides [sql.DB.Exec] for intercepting it, classifying its errors and retrying read-only statements
+func (db *DB) Exec(query string, args ...any) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+// PrepareContext overrides [sql.DB.PrepareContext] for intercepting it, classifying and retrying its errors
+func (db *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	res, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationPrepare, SQL: query}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		stmt, err := RetryWithData(ctx, db, true, func() (*sql.Stmt, error) {
+			return db.DB.PrepareContext(ctx, op.SQL)
+		})
+		return sqlconnect.OperationResult{Stmt: stmt}, err
+	})
+	return res.Stmt, err
+}
+
+// Prepare overrides [sql.DB.Prepare] for intercepting it, classifying and retrying its errors
+func (db *DB) Prepare(query string) (*sql.Stmt, error) {
+	return db.PrepareContext(context.Background(), query)
+}
+
+// BeginTx overrides [sql.DB.BeginTx] for intercepting it, classifying and retrying its errors
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	res, err := db.intercept(ctx, sqlconnect.Operation{Kind: sqlconnect.OperationBeginTx, TxOptions: opts}, func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		tx, err := RetryWithData(ctx, db, true, func() (*sql.Tx, error) {
+			return db.DB.BeginTx(ctx, op.TxOptions)
+		})
+		return sqlconnect.OperationResult{Tx: tx}, err
+	})
+	return res.Tx, err
+}
+
+// Begin overrides [sql.DB.Begin] for intercepting it, classifying and retrying its errors
+func (db *DB) Begin() (*sql.Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
+
+// Conn overrides [sql.DB.Conn] for classifying and retrying its errors
+func (db *DB) Conn(ctx context.Context) (*sql.Conn, error) {
+	return RetryWithData(ctx, db, true, func() (*sql.Conn, error) {
+		return db.DB.Conn(ctx)
+	})
+}
+
+// PingContext overrides [sql.DB.PingContext] for classifying and retrying its errors
+func (db *DB) PingContext(ctx context.Context) error {
+	return db.Retry(ctx, true, func() error {
+		return db.DB.PingContext(ctx)
+	})
+}
+
+// Ping overrides [sql.DB.Ping] for classifying and retrying its errors
+func (db *DB) Ping() error {
+	return db.PingContext(context.Background())
+}
+
+// QueryRowScan runs a query expected to return a single row, scanning the row into dest, or returns [sql.ErrNoRows] if there are no rows.
+// Unlike [sql.DB.QueryRowContext], the query goes through [DB.QueryContext], thus it is intercepted and retried.
+func (db *DB) QueryRowScan(ctx context.Context, query string, args []any, dest ...any) error {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rows.Close() }()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return db.ClassifyError(err)
+		}
+		return sql.ErrNoRows
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return err
+	}
+	return db.ClassifyError(rows.Close())
+}
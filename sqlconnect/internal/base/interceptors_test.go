@@ -0,0 +1,121 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestInterceptors(t *testing.T) {
+	ctx := context.Background()
+	conn := &fakeConn{}
+	db := NewDB(sql.OpenDB(conn), func() error { return nil })
+	defer func() { _ = db.Close() }()
+
+	var ops []sqlconnect.Operation
+	recorder := func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+		ops = append(ops, op)
+		return invoke(ctx, op)
+	}
+	intercepted := db.WithInterceptors(recorder)
+
+	t.Run("internal operation", func(t *testing.T) {
+		ops = nil
+		table := sqlconnect.NewRelationRef("t", sqlconnect.WithSchema("s"))
+		count, err := intercepted.CountTableRows(ctx, table)
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+		require.Len(t, ops, 1, "it should intercept the query performed internally")
+		require.Equal(t, sqlconnect.OperationQuery, ops[0].Kind)
+		require.Equal(t, `SELECT COUNT(*) FROM "s"."t"`, ops[0].SQL)
+		require.Equal(t, "CountTableRows", ops[0].Method)
+		require.Equal(t, []sqlconnect.RelationRef{table}, ops[0].Relations)
+	})
+
+	t.Run("direct operation", func(t *testing.T) {
+		ops = nil
+		_, err := intercepted.ExecContext(ctx, "DELETE FROM t WHERE id = ?", 1)
+		require.NoError(t, err)
+		require.Equal(t, []sqlconnect.Operation{{Kind: sqlconnect.OperationExec, SQL: "DELETE FROM t WHERE id = ?", Args: []any{1}}}, ops)
+	})
+
+	t.Run("without interceptors", func(t *testing.T) {
+		ops = nil
+		_, err := db.ExecContext(ctx, "DELETE FROM t")
+		require.NoError(t, err)
+		require.Empty(t, ops, "it should not affect the original db")
+	})
+
+	t.Run("rewriting queries", func(t *testing.T) {
+		rewriter := db.WithInterceptors(func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+			op.SQL = "/* tagged */ " + op.SQL
+			return invoke(ctx, op)
+		})
+		_, err := rewriter.ExecContext(ctx, "DELETE FROM t")
+		require.NoError(t, err)
+		require.Equal(t, "/* tagged */ DELETE FROM t", conn.lastQuery)
+	})
+
+	t.Run("rejecting operations", func(t *testing.T) {
+		errRejected := errors.New("rejected")
+		guarded := intercepted.WithInterceptors(func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+			if op.Kind == sqlconnect.OperationExec {
+				return sqlconnect.OperationResult{}, errRejected
+			}
+			return invoke(ctx, op)
+		})
+		conn.lastQuery, ops = "", nil
+		_, err := guarded.ExecContext(ctx, "DROP TABLE t")
+		require.ErrorIs(t, err, errRejected)
+		require.Empty(t, conn.lastQuery, "it should not perform a rejected operation")
+		require.Len(t, ops, 1, "it should run the db's own interceptors first")
+	})
+}
+
+// fakeConn is a driver connection returning a single row with a single value of 1 for every query
+type fakeConn struct {
+	lastQuery string
+	queries   []string // all queries and statements received
+}
+
+func (c *fakeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *fakeConn) Driver() driver.Driver                        { return nil }
+func (c *fakeConn) Prepare(string) (driver.Stmt, error)          { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                                 { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *fakeConn) Commit() error                                { return nil }
+func (c *fakeConn) Rollback() error                              { return nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.lastQuery = query
+	c.queries = append(c.queries, query)
+	return &fakeRows{}, nil
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.lastQuery = query
+	c.queries = append(c.queries, query)
+	return driver.RowsAffected(1), nil
+}
+
+type fakeRows struct {
+	done bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"c"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
@@ -0,0 +1,76 @@
+package base
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// MergeTable merges the rows of the source table into the target table, matching rows on the key columns
+func (db *DB) MergeTable(ctx context.Context, target, source sqlconnect.RelationRef, keyColumns, updateColumns []string, opts ...sqlconnect.Option) error {
+	ctx = WithOperation(ctx, "MergeTable", target, source)
+	if _, err := sqlconnect.NewMergeOptions(opts...); err != nil {
+		return err
+	}
+	if len(keyColumns) == 0 {
+		return fmt.Errorf("merging table %s into %s: no key columns provided", source, target)
+	}
+	quote := func(col string, _ int) QuotedIdentifier { return QuotedIdentifier(db.QuoteIdentifier(col)) }
+	stmts := db.sqlCommands.Merge(
+		QuotedIdentifier(db.QuoteTable(target)),
+		QuotedIdentifier(db.QuoteTable(source)),
+		lo.Map(keyColumns, quote),
+		lo.Map(updateColumns, quote),
+	)
+	if len(stmts) == 1 {
+		if _, err := db.ExecContext(ctx, stmts[0]); err != nil {
+			return fmt.Errorf("merging table %s into %s: %w", source, target, err)
+		}
+		return nil
+	}
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction for merging table %s into %s: %w", source, target, err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("merging table %s into %s: %w", source, target, db.ClassifyError(err))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing merge of table %s into %s: %w", source, target, db.ClassifyError(err))
+	}
+	return nil
+}
+
+// MergeInto provides a standard MERGE INTO command, updating matched rows and inserting the ones not matched
+func MergeInto(target, source QuotedIdentifier, keyColumns, updateColumns []QuotedIdentifier) []string {
+	columns := slices.Concat(keyColumns, updateColumns)
+	on := strings.Join(lo.Map(keyColumns, func(col QuotedIdentifier, _ int) string {
+		return fmt.Sprintf("t.%[1]s = s.%[1]s", col)
+	}), " AND ")
+	stmt := fmt.Sprintf("MERGE INTO %[1]s t USING %[2]s s ON %[3]s", target, source, on)
+	if len(updateColumns) > 0 {
+		stmt += " WHEN MATCHED THEN UPDATE SET " + strings.Join(lo.Map(updateColumns, func(col QuotedIdentifier, _ int) string {
+			return fmt.Sprintf("%[1]s = s.%[1]s", col)
+		}), ", ")
+	}
+	stmt += fmt.Sprintf(" WHEN NOT MATCHED THEN INSERT (%[1]s) VALUES (%[2]s)", JoinQuotedIdentifiers(columns, ""), JoinQuotedIdentifiers(columns, "s"))
+	return []string{stmt}
+}
+
+// JoinQuotedIdentifiers joins identifiers in a comma separated list, optionally qualifying each one of them
+func JoinQuotedIdentifiers(identifiers []QuotedIdentifier, qualifier string) string {
+	return strings.Join(lo.Map(identifiers, func(identifier QuotedIdentifier, _ int) string {
+		if qualifier != "" {
+			return qualifier + "." + string(identifier)
+		}
+		return string(identifier)
+	}), ", ")
+}
@@ -0,0 +1,23 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeInto(t *testing.T) {
+	t.Run("with update columns", func(t *testing.T) {
+		stmts := MergeInto(`"s"."target"`, `"s"."source"`, []QuotedIdentifier{`"id"`}, []QuotedIdentifier{`"name"`, `"age"`})
+		require.Equal(t, []string{
+			`MERGE INTO "s"."target" t USING "s"."source" s ON t."id" = s."id" WHEN MATCHED THEN UPDATE SET "name" = s."name", "age" = s."age" WHEN NOT MATCHED THEN INSERT ("id", "name", "age") VALUES (s."id", s."name", s."age")`,
+		}, stmts)
+	})
+
+	t.Run("without update columns", func(t *testing.T) {
+		stmts := MergeInto(`"target"`, `"source"`, []QuotedIdentifier{`"id1"`, `"id2"`}, nil)
+		require.Equal(t, []string{
+			`MERGE INTO "target" t USING "source" s ON t."id1" = s."id1" AND t."id2" = s."id2" WHEN NOT MATCHED THEN INSERT ("id1", "id2") VALUES (s."id1", s."id2")`,
+		}, stmts)
+	})
+}
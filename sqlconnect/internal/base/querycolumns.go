@@ -0,0 +1,156 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// QueryColumn is a column in the result set of a sql query
+type QueryColumn interface {
+	Name() string
+	ColumnType
+}
+
+// NewQueryColumn returns a [QueryColumn] with the given name and database type
+func NewQueryColumn(name, databaseTypeName string) QueryColumn {
+	return queryColumn{name: name, databaseTypeName: databaseTypeName}
+}
+
+type queryColumn struct {
+	name             string
+	databaseTypeName string
+}
+
+func (c queryColumn) Name() string             { return c.name }
+func (c queryColumn) DatabaseTypeName() string { return c.databaseTypeName }
+func (c queryColumn) DecimalSize() (precision, scale int64, ok bool) {
+	return 0, 0, false
+}
+
+// TrimQuery removes any leading or trailing whitespace and trailing semicolons from a sql query, so that it can be embedded in another statement
+func TrimQuery(sql string) string {
+	return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(sql), ";"))
+}
+
+// selectKeywordRegex matches the first keyword of sql queries that can be used as a subquery
+var selectKeywordRegex = regexp.MustCompile(`(?i)^(SELECT|WITH|VALUES|TABLE)\b`)
+
+// IsSelectQuery returns true if the sql query is a query that can be used as a subquery, e.g. for wrapping it in a SELECT with LIMIT 0
+func IsSelectQuery(sql string) bool {
+	return selectKeywordRegex.MatchString(strings.TrimSpace(leadingCommentsRegex.ReplaceAllString(sql, "")))
+}
+
+// ListColumnsForSqlQuery returns a list of columns for the given sql query, describing it using [SQLCommands.DescribeQuery]
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.ListColumnsForSqlQueryUsing(ctx, sql, db.DescribeQuery, opts...)
+}
+
+// ListColumnsForSqlQueryUsing returns a list of columns for the given sql query, using the provided describe function for retrieving the query's columns without executing it.
+// If describing the query fails and [sqlconnect.WithExecuteFallback] is provided, the query is executed instead.
+func (db *DB) ListColumnsForSqlQueryUsing(ctx context.Context, sql string, describe func(ctx context.Context, sql string) ([]QueryColumn, error), opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	ctx = WithOperation(ctx, "ListColumnsForSqlQuery")
+	options, err := sqlconnect.NewColumnsForSqlQueryOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := describe(ctx, sql)
+	if err != nil {
+		if !options.ExecuteFallback || ctx.Err() != nil {
+			return nil, fmt.Errorf("describing sql query: %w", db.ClassifyError(err))
+		}
+		if columns, err = db.QueryResultColumns(ctx, sql); err != nil {
+			return nil, err
+		}
+	}
+	return lo.Map(columns, func(col QueryColumn, _ int) sqlconnect.ColumnRef {
+		return sqlconnect.ColumnRef{
+			Name:     col.Name(),
+			Type:     db.columnTypeMapper(col),
+			RawType:  col.DatabaseTypeName(),
+			TypeTree: db.typeTreeMapper(col),
+		}
+	}), nil
+}
+
+// DescribeQuery returns the columns of the given sql query without executing it, using [SQLCommands.DescribeQuery]
+func (db *DB) DescribeQuery(ctx context.Context, sql string) ([]QueryColumn, error) {
+	stmt, nameCol, typeCol := db.sqlCommands.DescribeQuery(sql)
+	if stmt == "" {
+		return nil, fmt.Errorf("describing sql query without executing it: %w", sqlconnect.ErrNotSupported)
+	}
+	if nameCol == "" && typeCol == "" {
+		return db.QueryResultColumns(ctx, stmt)
+	}
+	rows, err := db.QueryContext(ctx, stmt)
+	if err != nil {
+		return nil, fmt.Errorf("querying describe sql query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return ScanQueryColumns(rows, nameCol, typeCol)
+}
+
+// QueryResultColumns executes the given sql query and returns the columns of its result set, without reading any of its rows
+func (db *DB) QueryResultColumns(ctx context.Context, query string) ([]QueryColumn, error) {
+	rows, err := db.QueryContext(ctx, query) // nolint:rowserrcheck
+	if err != nil {
+		return nil, fmt.Errorf("querying list columns for sql query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	colTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("getting column information in list columns for sql query: %w", err)
+	}
+	return lo.Map(colTypes, func(col *sql.ColumnType, _ int) QueryColumn { return col }), nil
+}
+
+// ScanQueryColumns reads the columns of a described sql query from the given rows, using the columns in the result set that point to each column's name and type
+func ScanQueryColumns(rows *sql.Rows, nameCol, typeCol string) ([]QueryColumn, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("getting columns in describe sql query: %w", err)
+	}
+	cols = lo.Map(cols, func(col string, _ int) string { return strings.ToLower(col) })
+	nameColIdx := lo.IndexOf(cols, strings.ToLower(nameCol))
+	if nameColIdx == -1 {
+		return nil, fmt.Errorf("column %s not found in result set: %+v", nameCol, cols)
+	}
+	typeColIdx := lo.IndexOf(cols, strings.ToLower(typeCol))
+	if typeColIdx == -1 {
+		return nil, fmt.Errorf("column %s not found in result set: %+v", typeCol, cols)
+	}
+
+	var (
+		name, databaseTypeName string
+		otherCol               sqlconnect.NilAny
+		res                    []QueryColumn
+	)
+	scanValues := make([]any, len(cols))
+	for i := range cols {
+		switch i {
+		case nameColIdx:
+			scanValues[i] = &name
+		case typeColIdx:
+			scanValues[i] = &databaseTypeName
+		default:
+			scanValues[i] = &otherCol
+		}
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanValues...); err != nil {
+			return nil, fmt.Errorf("scanning describe sql query: %w", err)
+		}
+		res = append(res, NewQueryColumn(name, databaseTypeName))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating describe sql query: %w", err)
+	}
+	return res, nil
+}
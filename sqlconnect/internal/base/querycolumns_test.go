@@ -0,0 +1,71 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestListColumnsForSqlQueryUsing(t *testing.T) {
+	ctx := context.Background()
+	db := NewDB(nil, func() error { return nil }, WithColumnTypeMappings(map[string]string{"int4": "int"}))
+
+	t.Run("maps described columns", func(t *testing.T) {
+		var described string
+		columns, err := db.ListColumnsForSqlQueryUsing(ctx, "SELECT 1 AS c1", func(_ context.Context, sql string) ([]QueryColumn, error) {
+			described = sql
+			return []QueryColumn{NewQueryColumn("c1", "INT4")}, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "SELECT 1 AS c1", described)
+		require.Equal(t, []sqlconnect.ColumnRef{{Name: "c1", Type: "int", RawType: "INT4"}}, columns)
+	})
+
+	t.Run("doesn't execute the query without execute fallback", func(t *testing.T) {
+		describeErr := errors.New("describe failed")
+		_, err := db.ListColumnsForSqlQueryUsing(ctx, "SHOW TABLES", func(context.Context, string) ([]QueryColumn, error) {
+			return nil, describeErr
+		})
+		require.ErrorIs(t, err, describeErr)
+	})
+
+	t.Run("rejects unsupported options", func(t *testing.T) {
+		_, err := db.ListColumnsForSqlQueryUsing(ctx, "SELECT 1", func(context.Context, string) ([]QueryColumn, error) {
+			return nil, nil
+		}, sqlconnect.WithBatchSize(10))
+		require.Error(t, err)
+	})
+}
+
+func TestTrimQuery(t *testing.T) {
+	require.Equal(t, "SELECT 1", TrimQuery("  SELECT 1 ; \n"))
+	require.Equal(t, "SELECT ';'", TrimQuery("SELECT ';';;"))
+}
+
+func TestIsSelectQuery(t *testing.T) {
+	for _, sql := range []string{"SELECT 1", " select 1", "WITH t AS (SELECT 1) SELECT * FROM t", "(SELECT 1) UNION (SELECT 2)", "-- comment\nVALUES (1)", "/* tag */ TABLE t"} {
+		require.Truef(t, IsSelectQuery(sql), "it should recognise %q as a query", sql)
+	}
+	for _, sql := range []string{"SHOW TABLES", "INSERT INTO t VALUES (1)", "DELETE FROM t", "EXPLAIN SELECT 1", "SELECTED"} {
+		require.Falsef(t, IsSelectQuery(sql), "it should not recognise %q as a query", sql)
+	}
+}
+
+func TestDescribeQueryCommand(t *testing.T) {
+	db := NewDB(nil, func() error { return nil })
+
+	stmt, _, _ := db.sqlCommands.DescribeQuery("SELECT 1;")
+	require.Equal(t, "SELECT * FROM (SELECT 1) AS sqlconnect_query LIMIT 0", stmt, "it should wrap queries in a SELECT with LIMIT 0")
+
+	for _, sql := range []string{"SHOW TABLES", "DELETE FROM t RETURNING id", "EXPLAIN ANALYZE SELECT 1", "CALL p()"} {
+		stmt, _, _ = db.sqlCommands.DescribeQuery(sql)
+		require.Emptyf(t, stmt, "it should not describe %q, which can't be wrapped", sql)
+	}
+
+	_, err := db.DescribeQuery(context.Background(), "DELETE FROM t RETURNING id")
+	require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should not execute statements that can't be wrapped")
+}
@@ -0,0 +1,72 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// QueryTagComment formats query tags as a sql comment using the [sqlcommenter] format, e.g. /*customer='acme',job='daily-sync'*/
+//
+// [sqlcommenter]: https://google.github.io/sqlcommenter/spec/
+func QueryTagComment(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, fmt.Sprintf("%s='%s'", sqlcommenterEscape(k), sqlcommenterEscape(tags[k])))
+	}
+	return "/*" + strings.Join(pairs, ",") + "*/"
+}
+
+// sqlcommenterEscape url-encodes a key or value of a sqlcommenter comment, thus it cannot contain any quotes or terminate the comment
+func sqlcommenterEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// tagQuery returns an invoker applying the query tags of the context to every operation performed by the given invoker, see [sqlconnect.WithQueryTag]
+func (db *DB) tagQuery(invoke sqlconnect.Invoker) sqlconnect.Invoker {
+	return func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		tags := sqlconnect.QueryTag(ctx)
+		if len(tags) == 0 || op.Kind == sqlconnect.OperationBeginTx {
+			return invoke(ctx, op)
+		}
+		switch {
+		case db.queryTagContext != nil:
+			return invoke(db.queryTagContext(ctx, tags), op)
+		case db.queryTagSession != nil && op.Kind == sqlconnect.OperationExec:
+			result, err := RetryWithData(ctx, db, IsReadOnlyQuery(op.SQL), func() (sql.Result, error) {
+				return db.execWithSessionTags(ctx, tags, op)
+			})
+			return sqlconnect.OperationResult{Result: result}, err
+		}
+		op.SQL = QueryTagComment(tags) + " " + op.SQL
+		return invoke(ctx, op)
+	}
+}
+
+// execWithSessionTags executes a statement using a dedicated connection, whose session is tagged for the duration of the statement
+func (db *DB) execWithSessionTags(ctx context.Context, tags map[string]string, op sqlconnect.Operation) (sql.Result, error) {
+	conn, err := db.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+	set, reset := db.queryTagSession(tags)
+	if _, err := conn.ExecContext(ctx, set); err != nil {
+		return nil, fmt.Errorf("setting query tags: %w", err)
+	}
+	result, err := conn.ExecContext(ctx, op.SQL, op.Args...)
+	if _, resetErr := conn.ExecContext(context.WithoutCancel(ctx), reset); resetErr != nil {
+		// discard the connection, so that its tags don't leak to other statements
+		_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		err = errors.Join(err, fmt.Errorf("resetting query tags: %w", resetErr))
+	}
+	return result, err
+}
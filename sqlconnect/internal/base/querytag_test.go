@@ -0,0 +1,67 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQueryTagComment(t *testing.T) {
+	comment := QueryTagComment(map[string]string{"job": "daily sync", "customer": "it's */ acme"})
+	require.Equal(t, `/*customer='it%27s%20%2A%2F%20acme',job='daily%20sync'*/`, comment, "it should sort and escape the tags")
+}
+
+func TestQueryTags(t *testing.T) {
+	ctx := sqlconnect.WithQueryTag(context.Background(), map[string]string{"customer": "acme"})
+
+	t.Run("sql comment", func(t *testing.T) {
+		conn := &fakeConn{}
+		db := NewDB(sql.OpenDB(conn), func() error { return nil })
+		defer func() { _ = db.Close() }()
+
+		_, err := db.ExecContext(ctx, "DELETE FROM t")
+		require.NoError(t, err)
+		require.Equal(t, "/*customer='acme'*/ DELETE FROM t", conn.lastQuery)
+
+		_, err = db.ExecContext(context.Background(), "DELETE FROM t")
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM t", conn.lastQuery, "it should not tag statements without tags")
+	})
+
+	t.Run("context", func(t *testing.T) {
+		type key struct{}
+		conn := &fakeConn{}
+		var tagged map[string]string
+		db := NewDB(sql.OpenDB(conn), func() error { return nil }, WithQueryTagContext(func(ctx context.Context, tags map[string]string) context.Context {
+			tagged = tags
+			return context.WithValue(ctx, key{}, tags)
+		}))
+		defer func() { _ = db.Close() }()
+
+		_, err := db.ExecContext(ctx, "DELETE FROM t")
+		require.NoError(t, err)
+		require.Equal(t, "DELETE FROM t", conn.lastQuery, "it should not modify the statement")
+		require.Equal(t, map[string]string{"customer": "acme"}, tagged)
+	})
+
+	t.Run("session", func(t *testing.T) {
+		conn := &fakeConn{}
+		db := NewDB(sql.OpenDB(conn), func() error { return nil }, WithQueryTagSession(func(tags map[string]string) (string, string) {
+			return "SET tag TO '" + tags["customer"] + "'", "RESET tag"
+		}))
+		defer func() { _ = db.Close() }()
+
+		_, err := db.ExecContext(ctx, "DELETE FROM t")
+		require.NoError(t, err)
+		require.Equal(t, []string{"SET tag TO 'acme'", "DELETE FROM t", "RESET tag"}, conn.queries, "it should tag the session for the duration of the statement")
+
+		conn.queries = nil
+		_, err = db.QueryContext(ctx, "SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"/*customer='acme'*/ SELECT 1"}, conn.queries, "it should tag queries using a comment")
+	})
+}
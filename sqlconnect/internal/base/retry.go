@@ -0,0 +1,74 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/cenkalti/backoff/v4"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// SetRetryPolicy sets the policy for retrying idempotent operations that fail with [sqlconnect.ErrRetryable] errors
+func (db *DB) SetRetryPolicy(policy sqlconnect.RetryPolicy) {
+	db.retryPolicy.Store(&policy)
+}
+
+// Retry runs the operation, retrying it according to the db's [sqlconnect.RetryPolicy] for as long as it fails with a [sqlconnect.ErrRetryable] error.
+// If the operation is not idempotent, it is only retried if the context has been marked using [sqlconnect.WithIdempotent].
+// The error returned is always classified.
+func (db *DB) Retry(ctx context.Context, idempotent bool, operation func() error) error {
+	_, err := RetryWithData(ctx, db, idempotent, func() (struct{}, error) {
+		return struct{}{}, operation()
+	})
+	return err
+}
+
+// RetryWithData is like [DB.Retry] for operations returning a value
+func RetryWithData[T any](ctx context.Context, db *DB, idempotent bool, operation func() (T, error)) (T, error) {
+	policy := db.retryPolicy.Load()
+	if policy == nil || policy.MaxAttempts < 2 || !(idempotent || sqlconnect.IsIdempotent(ctx)) {
+		res, err := operation()
+		return res, db.ClassifyError(err)
+	}
+	return backoff.RetryWithData(func() (T, error) {
+		res, err := operation()
+		if err = db.ClassifyError(err); err != nil && !errors.Is(err, sqlconnect.ErrRetryable) {
+			return res, backoff.Permanent(err)
+		}
+		return res, err
+	}, backoff.WithContext(backoff.WithMaxRetries(newBackOff(*policy), uint64(policy.MaxAttempts-1)), ctx))
+}
+
+// newBackOff returns a new exponential backoff for the given policy, using the backoff's defaults for any values not provided
+func newBackOff(policy sqlconnect.RetryPolicy) backoff.BackOff {
+	var opts []backoff.ExponentialBackOffOpts
+	if policy.InitialInterval > 0 {
+		opts = append(opts, backoff.WithInitialInterval(policy.InitialInterval))
+	}
+	if policy.MaxInterval > 0 {
+		opts = append(opts, backoff.WithMaxInterval(policy.MaxInterval))
+	}
+	if policy.Multiplier > 0 {
+		opts = append(opts, backoff.WithMultiplier(policy.Multiplier))
+	}
+	return backoff.NewExponentialBackOff(append(opts, backoff.WithMaxElapsedTime(policy.MaxElapsedTime))...)
+}
+
+var (
+	// leadingCommentsRegex matches any leading whitespace, comments and opening parentheses of a sql query
+	leadingCommentsRegex = regexp.MustCompile(`^(\s|\(|--[^\n]*(\n|$)|/\*(.|\n)*?\*/)*`)
+	// readOnlyKeywordRegex matches the first keyword of read-only sql queries
+	readOnlyKeywordRegex = regexp.MustCompile(`(?i)^(SELECT|WITH|SHOW|DESCRIBE|DESC|EXPLAIN|VALUES)\b`)
+	// writeKeywordRegex matches keywords that may cause a query starting with a read-only keyword to modify data, e.g. data-modifying CTEs or SELECT INTO
+	writeKeywordRegex = regexp.MustCompile(`(?i)\b(INSERT|UPDATE|DELETE|MERGE|INTO|ANALYZE)\b`)
+)
+
+// IsReadOnlyQuery returns true if the sql query is known to only read data, thus it is safe to retry it.
+// It is conservative, i.e. a read-only query may not be recognised as such, e.g. if it references a column named after a write keyword.
+func IsReadOnlyQuery(sql string) bool {
+	sql = strings.TrimSpace(leadingCommentsRegex.ReplaceAllString(sql, ""))
+	return readOnlyKeywordRegex.MatchString(sql) && !writeKeywordRegex.MatchString(sql)
+}
@@ -0,0 +1,113 @@
+package base
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestRetryWithData(t *testing.T) {
+	ctx := context.Background()
+	transientErr := errors.New("transient")
+	permanentErr := errors.New("permanent")
+	db := NewDB(nil, func() error { return nil }, WithErrorClassifier(func(err error) []error {
+		if errors.Is(err, transientErr) {
+			return []error{sqlconnect.ErrRetryable}
+		}
+		return nil
+	}))
+	// failing returns an operation failing with the given error for the given number of attempts before succeeding
+	failing := func(attempts *int, failures int, err error) func() (int, error) {
+		return func() (int, error) {
+			*attempts++
+			if *attempts <= failures {
+				return 0, err
+			}
+			return *attempts, nil
+		}
+	}
+
+	t.Run("without retry policy", func(t *testing.T) {
+		var attempts int
+		_, err := RetryWithData(ctx, db, true, failing(&attempts, 1, transientErr))
+		require.ErrorIs(t, err, sqlconnect.ErrRetryable, "it should classify the error")
+		require.Equal(t, 1, attempts, "it should not retry the operation")
+	})
+
+	db.SetRetryPolicy(sqlconnect.RetryPolicy{MaxAttempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond})
+
+	t.Run("retryable error", func(t *testing.T) {
+		var attempts int
+		res, err := RetryWithData(ctx, db, true, failing(&attempts, 2, transientErr))
+		require.NoError(t, err)
+		require.Equal(t, 3, res, "it should retry the operation until it succeeds")
+	})
+
+	t.Run("exhausted attempts", func(t *testing.T) {
+		var attempts int
+		_, err := RetryWithData(ctx, db, true, failing(&attempts, 3, transientErr))
+		require.ErrorIs(t, err, transientErr)
+		require.ErrorIs(t, err, sqlconnect.ErrRetryable)
+		require.Equal(t, 3, attempts, "it should stop after the maximum number of attempts")
+	})
+
+	t.Run("permanent error", func(t *testing.T) {
+		var attempts int
+		_, err := RetryWithData(ctx, db, true, failing(&attempts, 1, permanentErr))
+		require.ErrorIs(t, err, permanentErr)
+		require.Equal(t, 1, attempts, "it should not retry a non-retryable error")
+	})
+
+	t.Run("non idempotent operation", func(t *testing.T) {
+		var attempts int
+		_, err := RetryWithData(ctx, db, false, failing(&attempts, 1, transientErr))
+		require.ErrorIs(t, err, transientErr)
+		require.Equal(t, 1, attempts, "it should not retry a non idempotent operation")
+
+		attempts = 0
+		_, err = RetryWithData(sqlconnect.WithIdempotent(ctx), db, false, failing(&attempts, 1, transientErr))
+		require.NoError(t, err, "it should retry a non idempotent operation if the caller opts in")
+		require.Equal(t, 2, attempts)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		var attempts int
+		_, err := RetryWithData(cancelledCtx, db, true, failing(&attempts, 3, transientErr))
+		require.Error(t, err)
+		require.Equal(t, 1, attempts, "it should not retry after the context is cancelled")
+	})
+}
+
+func TestIsReadOnlyQuery(t *testing.T) {
+	for _, tc := range []struct {
+		sql      string
+		readOnly bool
+	}{
+		{sql: "SELECT 1", readOnly: true},
+		{sql: "  select * from t;", readOnly: true},
+		{sql: "(SELECT 1) UNION (SELECT 2)", readOnly: true},
+		{sql: "-- comment\n/* another\ncomment */ SELECT 1", readOnly: true},
+		{sql: "WITH cte AS (SELECT 1) SELECT * FROM cte", readOnly: true},
+		{sql: "SHOW TABLES", readOnly: true},
+		{sql: "DESCRIBE TABLE t", readOnly: true},
+		{sql: "EXPLAIN SELECT 1", readOnly: true},
+		{sql: "EXPLAIN ANALYZE DELETE FROM t"},
+		{sql: "WITH deleted AS (DELETE FROM t RETURNING *) SELECT * FROM deleted"},
+		{sql: "SELECT * INTO t2 FROM t"},
+		{sql: "INSERT INTO t SELECT 1"},
+		{sql: "CREATE TABLE t AS SELECT 1"},
+		{sql: "SELECTED"},
+		{sql: ""},
+	} {
+		t.Run(tc.sql, func(t *testing.T) {
+			require.Equal(t, tc.readOnly, IsReadOnlyQuery(tc.sql))
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 // CreateSchema creates a schema
 func (db *DB) CreateSchema(ctx context.Context, schema sqlconnect.SchemaRef) error {
+	ctx = WithOperation(ctx, "CreateSchema")
 	if _, err := db.ExecContext(ctx, db.sqlCommands.CreateSchema(QuotedIdentifier(db.QuoteIdentifier(schema.Name)))); err != nil {
 		return fmt.Errorf("creating schema %s: %w", schema, err)
 	}
@@ -20,6 +21,7 @@ func (db *DB) CreateSchema(ctx context.Context, schema sqlconnect.SchemaRef) err
 
 // ListSchemas returns a list of schemas, optionally filtered by a single catalog
 func (db *DB) ListSchemas(ctx context.Context, opts ...sqlconnect.Option) ([]sqlconnect.SchemaRef, error) {
+	ctx = WithOperation(ctx, "ListSchemas")
 	filterCatalogOpts, err := sqlconnect.NewFilterOptions(opts...)
 	if err != nil {
 		return nil, err
@@ -63,13 +65,14 @@ func (db *DB) ListSchemas(ctx context.Context, opts ...sqlconnect.Option) ([]sql
 		res = append(res, schema)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating list schemas: %w", err)
+		return nil, fmt.Errorf("iterating list schemas: %w", db.ClassifyError(err))
 	}
 	return res, nil
 }
 
 // SchemaExists returns true if the schema exists
 func (db *DB) SchemaExists(ctx context.Context, schemaRef sqlconnect.SchemaRef, opts ...sqlconnect.Option) (bool, error) {
+	ctx = WithOperation(ctx, "SchemaExists")
 	filterCatalogOpts, err := sqlconnect.NewFilterOptions(opts...)
 	if err != nil {
 		return false, err
@@ -81,13 +84,14 @@ func (db *DB) SchemaExists(ctx context.Context, schemaRef sqlconnect.SchemaRef,
 	defer func() { _ = rows.Close() }()
 	exists := rows.Next()
 	if err := rows.Err(); err != nil {
-		return false, fmt.Errorf("iterating schema exists: %w", err)
+		return false, fmt.Errorf("iterating schema exists: %w", db.ClassifyError(err))
 	}
 	return exists, nil
 }
 
 // DropSchema drops a schema
 func (db *DB) DropSchema(ctx context.Context, schemaRef sqlconnect.SchemaRef) error {
+	ctx = WithOperation(ctx, "DropSchema")
 	if _, err := db.ExecContext(ctx, db.sqlCommands.DropSchema(QuotedIdentifier(db.QuoteIdentifier(schemaRef.Name)))); err != nil {
 		return fmt.Errorf("dropping schema: %w", err)
 	}
@@ -2,6 +2,7 @@ package base
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -12,12 +13,82 @@ import (
 
 // CreateTestTable creates a test table
 func (db *DB) CreateTestTable(ctx context.Context, table sqlconnect.RelationRef) error {
+	ctx = WithOperation(ctx, "CreateTestTable", table)
 	_, err := db.ExecContext(ctx, db.sqlCommands.CreateTestTable(QuotedIdentifier(db.QuoteTable(table))))
 	return err
 }
 
+// CreateTable creates a table with the provided column definitions
+func (db *DB) CreateTable(ctx context.Context, relation sqlconnect.RelationRef, columns []sqlconnect.ColumnDef, opts ...sqlconnect.Option) error {
+	ctx = WithOperation(ctx, "CreateTable", relation)
+	createOpts, err := sqlconnect.NewCreateTableOptions(opts...)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("creating table %s: no columns provided", relation)
+	}
+	quote := func(col string, _ int) QuotedIdentifier { return QuotedIdentifier(db.QuoteIdentifier(col)) }
+	var (
+		definitions = make([]string, 0, len(columns))
+		primaryKey  []QuotedIdentifier
+	)
+	for _, column := range columns {
+		definition, err := db.columnDefinition(column)
+		if err != nil {
+			return fmt.Errorf("creating table %s: %w", relation, err)
+		}
+		definitions = append(definitions, definition)
+		if column.PrimaryKey {
+			primaryKey = append(primaryKey, QuotedIdentifier(db.QuoteIdentifier(column.Name)))
+		}
+	}
+	stmt, err := db.sqlCommands.CreateTable(
+		QuotedIdentifier(db.QuoteTable(relation)),
+		definitions,
+		primaryKey,
+		createOpts.IfNotExists,
+		lo.Map(createOpts.PartitionBy, quote),
+		lo.Map(createOpts.ClusterBy, quote),
+	)
+	if err != nil {
+		return fmt.Errorf("creating table %s: %w", relation, err)
+	}
+	if _, err := db.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("creating table %s: %w", relation, err)
+	}
+	return nil
+}
+
+// columnDefinition provides the definition of a column using the native type for its rudder type
+func (db *DB) columnDefinition(column sqlconnect.ColumnDef) (string, error) {
+	ddlType, ok := db.columnDDLTypes[column.Type]
+	if !ok {
+		return "", fmt.Errorf("column %s of type %q: %w", column.Name, column.Type, sqlconnect.ErrNotSupported)
+	}
+	definition := db.QuoteIdentifier(column.Name) + " " + ddlType
+	if column.NotNull || column.PrimaryKey {
+		definition += " NOT NULL"
+	}
+	return definition, nil
+}
+
+// CreateTableCommand provides a CREATE TABLE command using the provided column definitions and table constraints
+func CreateTableCommand(table QuotedIdentifier, ifNotExists bool, definitions []string) string {
+	return fmt.Sprintf("CREATE TABLE %[1]s%[2]s (%[3]s)", lo.Ternary(ifNotExists, "IF NOT EXISTS ", ""), table, strings.Join(definitions, ", "))
+}
+
+// PrimaryKeyConstraint provides the primary key constraint for the provided columns, if any
+func PrimaryKeyConstraint(columns []QuotedIdentifier) []string {
+	if len(columns) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("PRIMARY KEY (%s)", JoinQuotedIdentifiers(columns, ""))}
+}
+
 // ListTables returns a list of tables in the given schema, optionally filtered by prefix
 func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts ...sqlconnect.Option) ([]sqlconnect.RelationRef, error) {
+	ctx = WithOperation(ctx, "ListTables")
 	listOpts, err := sqlconnect.NewTableListOptions(opts...)
 	if err != nil {
 		return nil, err
@@ -74,7 +145,7 @@ func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts
 			res = append(res, makeRef(name))
 		}
 		if err := rows.Err(); err != nil {
-			return nil, fmt.Errorf("iterating list tables: %w", err)
+			return nil, fmt.Errorf("iterating list tables: %w", db.ClassifyError(err))
 		}
 	}
 	return res, nil
@@ -82,6 +153,7 @@ func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts
 
 // TableExists returns true if the table exists
 func (db *DB) TableExists(ctx context.Context, relation sqlconnect.RelationRef) (bool, error) {
+	ctx = WithOperation(ctx, "TableExists", relation)
 	stmt := db.sqlCommands.TableExists(UnquotedIdentifier(relation.Catalog), UnquotedIdentifier(relation.Schema), UnquotedIdentifier(relation.Name))
 	rows, err := db.QueryContext(ctx, stmt)
 	if err != nil {
@@ -92,13 +164,14 @@ func (db *DB) TableExists(ctx context.Context, relation sqlconnect.RelationRef)
 		return true, nil
 	}
 	if err := rows.Err(); err != nil {
-		return false, fmt.Errorf("iterating table %s exists: %w", relation, err)
+		return false, fmt.Errorf("iterating table %s exists: %w", relation, db.ClassifyError(err))
 	}
 	return false, nil
 }
 
 // ListColumns returns a list of columns for the given table
 func (db *DB) ListColumns(ctx context.Context, relation sqlconnect.RelationRef) ([]sqlconnect.ColumnRef, error) {
+	ctx = WithOperation(ctx, "ListColumns", relation)
 	var res []sqlconnect.ColumnRef
 	stmt, nameCol, typeCol := db.sqlCommands.ListColumns(UnquotedIdentifier(relation.Catalog), UnquotedIdentifier(relation.Schema), UnquotedIdentifier(relation.Name))
 	columns, err := db.QueryContext(ctx, stmt)
@@ -139,11 +212,12 @@ func (db *DB) ListColumns(ctx context.Context, relation sqlconnect.RelationRef)
 			return nil, fmt.Errorf("scanning list columns for %s: %w", relation.String(), err)
 		}
 		column.Type = db.columnTypeMapper(colRefTypeAdapter{column})
+		column.TypeTree = db.typeTreeMapper(colRefTypeAdapter{column})
 		res = append(res, column)
 	}
 
 	if err := columns.Err(); err != nil {
-		return nil, fmt.Errorf("iterating list columns for %s: %w", relation.String(), err)
+		return nil, fmt.Errorf("iterating list columns for %s: %w", relation.String(), db.ClassifyError(err))
 	}
 
 	// check if relation exists before returning columns
@@ -154,33 +228,11 @@ func (db *DB) ListColumns(ctx context.Context, relation sqlconnect.RelationRef)
 	return res, nil
 }
 
-// ListColumnsForSqlQuery returns a list of columns for the given sql query
-func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string) ([]sqlconnect.ColumnRef, error) {
-	var res []sqlconnect.ColumnRef
-	rows, err := db.QueryContext(ctx, sql) // nolint:rowserrcheck
-	if err != nil {
-		return nil, fmt.Errorf("querying list columns for sql query: %w", err)
-	}
-	defer func() { _ = rows.Close() }()
-
-	colTypes, err := rows.ColumnTypes()
-	if err != nil {
-		return nil, fmt.Errorf("getting column information in list columns for sql query: %w", err)
-	}
-	for _, col := range colTypes {
-		res = append(res, sqlconnect.ColumnRef{
-			Name:    col.Name(),
-			Type:    db.columnTypeMapper(col),
-			RawType: col.DatabaseTypeName(),
-		})
-	}
-	return res, nil
-}
-
 // CountTableRows returns the number of rows in the given table
 func (c *DB) CountTableRows(ctx context.Context, relation sqlconnect.RelationRef) (int, error) {
+	ctx = WithOperation(ctx, "CountTableRows", relation)
 	var count int
-	if err := c.QueryRowContext(ctx, c.sqlCommands.CountTableRows(QuotedIdentifier(c.QuoteTable(relation)))).Scan(&count); err != nil {
+	if err := c.QueryRowScan(ctx, c.sqlCommands.CountTableRows(QuotedIdentifier(c.QuoteTable(relation))), nil, &count); err != nil {
 		return 0, fmt.Errorf("counting table rows for %s: %w", relation.String(), err)
 	}
 	return count, nil
@@ -188,6 +240,7 @@ func (c *DB) CountTableRows(ctx context.Context, relation sqlconnect.RelationRef
 
 // DropTable drops a table
 func (db *DB) DropTable(ctx context.Context, ref sqlconnect.RelationRef) error {
+	ctx = WithOperation(ctx, "DropTable", ref)
 	if _, err := db.ExecContext(ctx, db.sqlCommands.DropTable(QuotedIdentifier(db.QuoteTable(ref)))); err != nil {
 		return fmt.Errorf("dropping table %s: %w", ref.String(), err)
 	}
@@ -196,6 +249,7 @@ func (db *DB) DropTable(ctx context.Context, ref sqlconnect.RelationRef) error {
 
 // TruncateTable truncates a table
 func (db *DB) TruncateTable(ctx context.Context, ref sqlconnect.RelationRef) error {
+	ctx = WithOperation(ctx, "TruncateTable", ref)
 	if _, err := db.ExecContext(ctx, db.sqlCommands.TruncateTable(QuotedIdentifier(db.QuoteTable(ref)))); err != nil {
 		return fmt.Errorf("truncating table %s: %w", ref.String(), err)
 	}
@@ -204,6 +258,7 @@ func (db *DB) TruncateTable(ctx context.Context, ref sqlconnect.RelationRef) err
 
 // RenameTable renames a table
 func (db *DB) RenameTable(ctx context.Context, oldRef, newRef sqlconnect.RelationRef) error {
+	ctx = WithOperation(ctx, "RenameTable", oldRef, newRef)
 	if oldRef.Schema != newRef.Schema {
 		return fmt.Errorf("moving table to another schema not supported, oldRef: %s newRef: %s", oldRef, newRef)
 	}
@@ -215,6 +270,7 @@ func (db *DB) RenameTable(ctx context.Context, oldRef, newRef sqlconnect.Relatio
 
 // MoveTable copies the old table's contents to the new table and drops the old table. Returns [ErrDropOldTablePostCopy] if the old table could not be dropped after the copy.
 func (db *DB) MoveTable(ctx context.Context, oldRef, newRef sqlconnect.RelationRef) error {
+	ctx = WithOperation(ctx, "MoveTable", oldRef, newRef)
 	if oldRef.Schema != newRef.Schema {
 		return fmt.Errorf("moving table to another schema not supported, oldRef: %s newRef: %s", oldRef, newRef)
 	}
@@ -229,13 +285,106 @@ func (db *DB) MoveTable(ctx context.Context, oldRef, newRef sqlconnect.RelationR
 
 // CreateTableFromQuery creates a table from the results of a query
 func (db *DB) CreateTableFromQuery(ctx context.Context, table sqlconnect.RelationRef, query string) error {
+	ctx = WithOperation(ctx, "CreateTableFromQuery", table)
 	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE %[1]s as (%[2]s)`, db.QuoteTable(table), query))
 	return err
 }
 
 // GetRowCountForQuery returns the number of rows returned by the query
 func (db *DB) GetRowCountForQuery(ctx context.Context, query string, params ...any) (int, error) {
+	ctx = WithOperation(ctx, "GetRowCountForQuery")
 	var count int
-	err := db.QueryRowContext(ctx, query, params...).Scan(&count)
+	err := db.QueryRowScan(ctx, query, params, &count)
 	return count, err
 }
+
+// AddColumns adds columns to a table
+func (db *DB) AddColumns(ctx context.Context, relation sqlconnect.RelationRef, columns []sqlconnect.ColumnDef) error {
+	ctx = WithOperation(ctx, "AddColumns", relation)
+	if len(columns) == 0 {
+		return fmt.Errorf("adding columns to %s: no columns provided", relation)
+	}
+	definitions := make([]string, 0, len(columns))
+	for _, column := range columns {
+		if column.PrimaryKey {
+			return fmt.Errorf("adding primary key column %s to %s: %w", column.Name, relation, sqlconnect.ErrNotSupported)
+		}
+		definition, err := db.columnDefinition(column)
+		if err != nil {
+			return fmt.Errorf("adding columns to %s: %w", relation, err)
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, stmt := range db.sqlCommands.AddColumns(QuotedIdentifier(db.QuoteTable(relation)), definitions) {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("adding columns to %s: %w", relation, err)
+		}
+	}
+	return nil
+}
+
+// DropColumns drops columns from a table
+func (db *DB) DropColumns(ctx context.Context, relation sqlconnect.RelationRef, columns []string) error {
+	ctx = WithOperation(ctx, "DropColumns", relation)
+	if len(columns) == 0 {
+		return fmt.Errorf("dropping columns from %s: no columns provided", relation)
+	}
+	quote := func(col string, _ int) QuotedIdentifier { return QuotedIdentifier(db.QuoteIdentifier(col)) }
+	for _, stmt := range db.sqlCommands.DropColumns(QuotedIdentifier(db.QuoteTable(relation)), lo.Map(columns, quote)) {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("dropping columns from %s: %w", relation, err)
+		}
+	}
+	return nil
+}
+
+// RenameColumn renames a column of a table
+func (db *DB) RenameColumn(ctx context.Context, relation sqlconnect.RelationRef, oldName, newName string) error {
+	ctx = WithOperation(ctx, "RenameColumn", relation)
+	if _, err := db.ExecContext(ctx, db.sqlCommands.RenameColumn(QuotedIdentifier(db.QuoteTable(relation)), QuotedIdentifier(db.QuoteIdentifier(oldName)), QuotedIdentifier(db.QuoteIdentifier(newName)))); err != nil {
+		return fmt.Errorf("renaming column %s to %s in %s: %w", oldName, newName, relation, err)
+	}
+	return nil
+}
+
+// AlterColumnType changes the type of a column, if the column's current type can be changed to the new one, using [SQLCommands.AlterColumnType]
+func (db *DB) AlterColumnType(ctx context.Context, relation sqlconnect.RelationRef, column, newType string) error {
+	return db.AlterColumnTypeUsing(ctx, relation, column, newType, func(_ context.Context, table, column QuotedIdentifier, fromType, toType, ddlType string) ([]string, error) {
+		return db.sqlCommands.AlterColumnType(table, column, fromType, toType, ddlType)
+	})
+}
+
+// AlterColumnTypeUsing changes the type of a column, using the provided function for building the statements that change it, e.g. for warehouses that need to look up the column's current definition.
+// Errors of the function wrapping [sqlconnect.ErrNotSupported] are reported as a [sqlconnect.ColumnTypeChangeError].
+func (db *DB) AlterColumnTypeUsing(ctx context.Context, relation sqlconnect.RelationRef, column, newType string, alter func(ctx context.Context, table, column QuotedIdentifier, fromType, toType, ddlType string) ([]string, error)) error {
+	ctx = WithOperation(ctx, "AlterColumnType", relation)
+	ddlType, ok := db.columnDDLTypes[newType]
+	if !ok {
+		return fmt.Errorf("altering column %s in %s to type %q: %w", column, relation, newType, sqlconnect.ErrNotSupported)
+	}
+	columns, err := db.ListColumns(ctx, relation)
+	if err != nil {
+		return fmt.Errorf("altering column %s in %s: %w", column, relation, err)
+	}
+	normalisedColumn := db.NormaliseIdentifier(column)
+	current, ok := lo.Find(columns, func(col sqlconnect.ColumnRef) bool { return db.NormaliseIdentifier(col.Name) == normalisedColumn })
+	if !ok {
+		return fmt.Errorf("altering column %s in %s: column does not exist", column, relation)
+	}
+	if current.Type == newType {
+		return nil
+	}
+	stmts, err := alter(ctx, QuotedIdentifier(db.QuoteTable(relation)), QuotedIdentifier(db.QuoteIdentifier(column)), current.Type, newType, ddlType)
+	if errors.Is(err, sqlconnect.ErrNotSupported) {
+		return &sqlconnect.ColumnTypeChangeError{Relation: relation, Column: column, FromType: current.Type, ToType: newType, Err: err}
+	}
+	if err != nil {
+		return fmt.Errorf("altering column %s in %s: %w", column, relation, err)
+	}
+	for _, stmt := range stmts {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("altering column %s in %s from %s to %s: %w", column, relation, current.Type, newType, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTableCommand(t *testing.T) {
+	t.Run("with primary key", func(t *testing.T) {
+		stmt := CreateTableCommand(`"s"."t"`, false, append([]string{`"id" bigint NOT NULL`, `"name" text`}, PrimaryKeyConstraint([]QuotedIdentifier{`"id"`})...))
+		require.Equal(t, `CREATE TABLE "s"."t" ("id" bigint NOT NULL, "name" text, PRIMARY KEY ("id"))`, stmt)
+	})
+
+	t.Run("if not exists without primary key", func(t *testing.T) {
+		stmt := CreateTableCommand(`"t"`, true, append([]string{`"name" text`}, PrimaryKeyConstraint(nil)...))
+		require.Equal(t, `CREATE TABLE IF NOT EXISTS "t" ("name" text)`, stmt)
+	})
+}
+
+func TestColumnDDLTypes(t *testing.T) {
+	mappings := map[string]string{"BIGINT": "int", "INT": "int", "VARCHAR": "string", "BOOLEAN": "boolean"}
+
+	for _, tc := range []struct {
+		name          string
+		databaseTypes []string
+		expected      map[string]string
+		panic         string
+	}{
+		{name: "reverse mappings", databaseTypes: []string{"BIGINT", "VARCHAR(255)"}, expected: map[string]string{"int": "BIGINT", "string": "VARCHAR(255)"}},
+		{name: "unmapped type", databaseTypes: []string{"BIGINT", "JSON"}, panic: "database type JSON is not mapped to any rudder type"},
+		{name: "rudder type mapped twice", databaseTypes: []string{"BIGINT", "INT"}, panic: "database types BIGINT and INT are both mapped to rudder type int"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.panic != "" {
+				require.PanicsWithValue(t, tc.panic, func() { ColumnDDLTypes(mappings, tc.databaseTypes...) }, "it should reject ambiguous or incomplete mappings")
+				return
+			}
+			require.Equal(t, tc.expected, ColumnDDLTypes(mappings, tc.databaseTypes...), "it should reverse the mappings, ignoring type parameters")
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package base
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+// methodKey is the attribute reporting the [DB] method performing an operation, if any
+const methodKey = attribute.Key("sqlconnect.method")
+
+// instrument returns an invoker reporting a span, along with duration and error metrics, for every operation performed by the given invoker.
+// For queries, the span ends as soon as the query's rows are available, i.e. it doesn't include the time spent reading them.
+func (db *DB) instrument(invoke sqlconnect.Invoker) sqlconnect.Invoker {
+	return func(ctx context.Context, op sqlconnect.Operation) (sqlconnect.OperationResult, error) {
+		operationName := telemetry.OperationName(op.SQL)
+		if op.Kind == sqlconnect.OperationBeginTx {
+			operationName = "BEGIN"
+		}
+		attrs := append(db.telemetryAttributes[:len(db.telemetryAttributes):len(db.telemetryAttributes)], semconv.DBOperationNameKey.String(operationName))
+		spanAttrs := append(attrs[:len(attrs):len(attrs)], semconv.DBQueryTextKey.String(telemetry.SanitizeQuery(op.SQL)))
+		spanName := operationName
+		if op.Method != "" {
+			spanName = op.Method
+			spanAttrs = append(spanAttrs, methodKey.String(op.Method))
+		}
+		if len(op.Relations) == 1 {
+			spanAttrs = append(spanAttrs, semconv.DBCollectionNameKey.String(op.Relations[0].Name))
+		}
+
+		ctx, span := telemetry.StartSpan(ctx, spanName, spanAttrs...)
+		start := time.Now()
+		res, err := invoke(ctx, op)
+		telemetry.RecordOperation(ctx, time.Since(start), err, attrs...)
+		telemetry.EndSpan(span, err)
+		return res, err
+	}
+}
+
+// RecordReturnedRows records the number of rows returned by the sql query, once they have been read through the package's helpers, e.g. [sqlconnect.QuerySeq]
+func (db *DB) RecordReturnedRows(ctx context.Context, query string, rows int64) {
+	attrs := append(db.telemetryAttributes[:len(db.telemetryAttributes):len(db.telemetryAttributes)], semconv.DBOperationNameKey.String(telemetry.OperationName(query)))
+	telemetry.RecordReturnedRows(ctx, rows, attrs...)
+}
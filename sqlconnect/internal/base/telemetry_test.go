@@ -0,0 +1,91 @@
+package base
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestTelemetry(t *testing.T) {
+	tracerProvider, meterProvider := otel.GetTracerProvider(), otel.GetMeterProvider()
+	t.Cleanup(func() {
+		otel.SetTracerProvider(tracerProvider)
+		otel.SetMeterProvider(meterProvider)
+	})
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	metrics := sdkmetric.NewManualReader()
+	otel.SetMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(metrics)))
+
+	ctx := context.Background()
+	db := NewDB(sql.OpenDB(&fakeConn{}), func() error { return nil }, WithTelemetry("postgresql", "db"))
+	defer func() { _ = db.Close() }()
+
+	table := sqlconnect.NewRelationRef("t", sqlconnect.WithSchema("s"))
+	_, err := db.CountTableRows(ctx, table)
+	require.NoError(t, err)
+
+	errRejected := sqlconnect.ErrPermissionDenied
+	rejecting := db.WithInterceptors(func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+		return invoke(ctx, op)
+	}, func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+		op.SQL = "DELETE FROM t WHERE id = 42"
+		res, err := invoke(ctx, op)
+		return res, errors.Join(err, errRejected)
+	})
+	_, err = rejecting.ExecContext(ctx, "DELETE FROM t")
+	require.ErrorIs(t, err, errRejected)
+	db.RecordReturnedRows(ctx, "SELECT * FROM t", 42)
+
+	t.Run("spans", func(t *testing.T) {
+		ended := spans.Ended()
+		require.Len(t, ended, 2)
+
+		require.Equal(t, "CountTableRows", ended[0].Name())
+		require.ElementsMatch(t, []attribute.KeyValue{
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.namespace", "db"),
+			attribute.String("db.operation.name", "SELECT"),
+			attribute.String("db.query.text", `SELECT COUNT(*) FROM "s"."t"`),
+			attribute.String("db.collection.name", "t"),
+			attribute.String("sqlconnect.method", "CountTableRows"),
+		}, ended[0].Attributes())
+		require.Equal(t, codes.Unset, ended[0].Status().Code)
+
+		require.Equal(t, "DELETE", ended[1].Name())
+		require.Contains(t, ended[1].Attributes(), attribute.String("db.query.text", "DELETE FROM t WHERE id = ?"), "it should report the sanitized query as rewritten by the interceptors")
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		var rm metricdata.ResourceMetrics
+		require.NoError(t, metrics.Collect(ctx, &rm))
+		require.Len(t, rm.ScopeMetrics, 1)
+		byName := map[string]metricdata.Metrics{}
+		for _, m := range rm.ScopeMetrics[0].Metrics {
+			byName[m.Name] = m
+		}
+
+		duration := byName["db.client.operation.duration"].Data.(metricdata.Histogram[float64]).DataPoints
+		require.Len(t, duration, 2, "it should record the duration of both operations")
+
+		connections := byName["db.client.connection.count"].Data.(metricdata.Gauge[int64]).DataPoints
+		require.Len(t, connections, 2, "it should report both idle and used connections")
+
+		returned := byName["db.client.response.returned_rows"].Data.(metricdata.Histogram[int64]).DataPoints
+		require.Len(t, returned, 1)
+		require.EqualValues(t, 42, returned[0].Sum, "it should record the number of rows returned")
+		require.Contains(t, returned[0].Attributes.ToSlice(), attribute.String("db.operation.name", "SELECT"))
+	})
+}
@@ -0,0 +1,259 @@
+package base
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// ParseTypeTree parses a database type into a type tree, e.g.
+//
+//	ARRAY<STRUCT<a INT64 NOT NULL, b STRING>> (bigquery)
+//	array<struct<a:int,b:map<string,int>>> (databricks)
+//	array(row(a integer, "b c" varchar)) (trino)
+//	OBJECT(a NUMBER, b ARRAY(VARCHAR)) (snowflake)
+//
+// Nested types can be declared using either angle brackets or parentheses, and struct fields can be separated from their types with either a colon or whitespace.
+// Nested types reported without their nested types, e.g. a bare ARRAY without its element type, result in nodes of the right kind without any nested nodes.
+func ParseTypeTree(rawType string) *sqlconnect.TypeNode {
+	p := &typeParser{input: rawType}
+	node := p.parseType()
+	return &node
+}
+
+// NestedTypeTree parses a database type into a type tree using [ParseTypeTree], returning nil if the type is a scalar.
+// Any of the given variant types found in the tree, e.g. VARIANT or SUPER, are reported as [sqlconnect.TypeKindVariant].
+func NestedTypeTree(rawType string, variantTypes ...string) *sqlconnect.TypeNode {
+	tree := ParseTypeTree(rawType)
+	WalkTypeTree(tree, func(node *sqlconnect.TypeNode) {
+		if node.Kind == sqlconnect.TypeKindScalar && slices.ContainsFunc(variantTypes, func(variantType string) bool { return strings.EqualFold(variantType, node.RawType) }) {
+			node.Kind = sqlconnect.TypeKindVariant
+		}
+	})
+	if !IsNestedType(tree) {
+		return nil
+	}
+	return tree
+}
+
+// IsNestedType returns true if the node is anything other than a scalar, i.e. an array, struct, map or variant
+func IsNestedType(node *sqlconnect.TypeNode) bool {
+	return node != nil && node.Kind != sqlconnect.TypeKindScalar
+}
+
+// WalkTypeTree calls fn for the node and all of its nested nodes, parents before their children
+func WalkTypeTree(node *sqlconnect.TypeNode, fn func(node *sqlconnect.TypeNode)) {
+	if node == nil {
+		return
+	}
+	fn(node)
+	WalkTypeTree(node.Element, fn)
+	WalkTypeTree(node.Key, fn)
+	WalkTypeTree(node.Value, fn)
+	for i := range node.Fields {
+		WalkTypeTree(&node.Fields[i], fn)
+	}
+}
+
+// nestedTypeKinds maps the names of nested types to their kinds
+var nestedTypeKinds = map[string]sqlconnect.TypeKind{
+	"ARRAY":  sqlconnect.TypeKindArray,
+	"MAP":    sqlconnect.TypeKindMap,
+	"STRUCT": sqlconnect.TypeKindStruct,
+	"ROW":    sqlconnect.TypeKindStruct,
+	"RECORD": sqlconnect.TypeKindStruct,
+	"OBJECT": sqlconnect.TypeKindStruct,
+}
+
+// typeModifierWords are words that may follow a type's name as part of the type itself, e.g. double precision or timestamp with time zone
+var typeModifierWords = []string{"PRECISION", "VARYING", "WITH", "WITHOUT", "TIME", "ZONE", "LOCAL"}
+
+type typeParser struct {
+	input string
+	pos   int
+}
+
+// parseType parses a type starting at the current position, along with any NOT NULL and COMMENT clauses that follow it
+func (p *typeParser) parseType() sqlconnect.TypeNode {
+	p.skipSpaces()
+	start := p.pos
+	name, _ := p.identifier()
+	node := sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, Nullable: true}
+	if kind, ok := nestedTypeKinds[strings.ToUpper(name)]; ok { // nested types might be reported without their nested types, e.g. a bare ARRAY
+		node.Kind = kind
+	}
+	if open := p.peek(); open == '<' || open == '(' {
+		p.pos++
+		closing := closingBracket(open)
+		switch node.Kind {
+		case sqlconnect.TypeKindArray:
+			element := p.parseType()
+			node.Element = &element
+		case sqlconnect.TypeKindMap:
+			key := p.parseType()
+			node.Key = &key
+			if p.consume(',') {
+				value := p.parseType()
+				node.Value = &value
+			}
+		case sqlconnect.TypeKindStruct:
+			for p.skipSpaces(); p.peek() != closing && p.peek() != 0; {
+				node.Fields = append(node.Fields, p.parseField())
+				if !p.consume(',') {
+					break
+				}
+			}
+		}
+		p.skipUntil(closing)
+	}
+	end := p.pos
+	for {
+		p.skipSpaces()
+		pos := p.pos
+		word, quoted := p.identifier()
+		switch upper := strings.ToUpper(word); {
+		case word == "" || quoted:
+			p.pos = pos
+			node.RawType = strings.TrimSpace(p.input[start:end])
+			return node
+		case upper == "NOT":
+			if next, _ := p.identifier(); strings.EqualFold(next, "NULL") {
+				node.Nullable = false
+			}
+		case upper == "COMMENT":
+			p.skipSpaces()
+			p.stringLiteral()
+		case upper == "NULL":
+		default: // part of the type's name, e.g. character varying(255)
+			if p.peek() == '(' {
+				p.pos++
+				p.skipUntil(')')
+			}
+			end = p.pos
+		}
+	}
+}
+
+// parseField parses a struct field, i.e. a name followed by a type, or an anonymous field consisting only of a type, e.g. trino's row(integer, varchar)
+func (p *typeParser) parseField() sqlconnect.TypeNode {
+	p.skipSpaces()
+	start := p.pos
+	name, quoted := p.identifier()
+	p.skipSpaces()
+	if p.consume(':') {
+		field := p.parseType()
+		field.Name = name
+		return field
+	}
+	if next := p.peek(); quoted || (name != "" && next != ',' && next != '<' && next != '(' && next != ')' && next != '>' && next != 0) {
+		pos := p.pos
+		nextWord, _ := p.identifier()
+		p.pos = pos
+		if quoted || !isTypeModifierWord(nextWord) {
+			field := p.parseType()
+			field.Name = name
+			return field
+		}
+	}
+	p.pos = start
+	return p.parseType()
+}
+
+// identifier reads a plain or quoted identifier, returning whether it was quoted
+func (p *typeParser) identifier() (string, bool) {
+	p.skipSpaces()
+	if q := p.peek(); q == '`' || q == '"' {
+		p.pos++
+		var sb strings.Builder
+		for p.pos < len(p.input) {
+			c := p.input[p.pos]
+			p.pos++
+			if c == q {
+				if p.peek() != q { // doubled quotes are escaped quotes
+					break
+				}
+				p.pos++
+			}
+			sb.WriteByte(c)
+		}
+		return sb.String(), true
+	}
+	start := p.pos
+	for p.pos < len(p.input) && isIdentifierChar(p.input[p.pos]) {
+		p.pos++
+	}
+	return p.input[start:p.pos], false
+}
+
+// stringLiteral skips a single-quoted string literal
+func (p *typeParser) stringLiteral() {
+	if !p.consume('\'') {
+		return
+	}
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		p.pos++
+		if c == '\\' {
+			p.pos = min(p.pos+1, len(p.input)) // a trailing backslash has nothing to escape
+		} else if c == '\'' {
+			return
+		}
+	}
+}
+
+// skipUntil skips past the given closing bracket, along with any balanced brackets found before it
+func (p *typeParser) skipUntil(closing byte) {
+	for depth := 0; p.pos < len(p.input); {
+		c := p.input[p.pos]
+		p.pos++
+		switch {
+		case c == '\'':
+			p.pos--
+			p.stringLiteral()
+		case c == '<' || c == '(':
+			depth++
+		case (c == '>' || c == ')') && depth > 0:
+			depth--
+		case c == closing:
+			return
+		}
+	}
+}
+
+func (p *typeParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typeParser) peek() byte {
+	if p.pos < len(p.input) {
+		return p.input[p.pos]
+	}
+	return 0
+}
+
+func (p *typeParser) skipSpaces() {
+	for p.pos < len(p.input) && strings.ContainsRune(" \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+func closingBracket(open byte) byte {
+	if open == '<' {
+		return '>'
+	}
+	return ')'
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+func isTypeModifierWord(word string) bool {
+	return slices.ContainsFunc(typeModifierWords, func(w string) bool { return strings.EqualFold(w, word) })
+}
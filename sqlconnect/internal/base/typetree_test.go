@@ -0,0 +1,133 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestParseTypeTree(t *testing.T) {
+	scalar := func(name, rawType string) sqlconnect.TypeNode {
+		return sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: rawType, Name: name, Nullable: true}
+	}
+
+	t.Run("scalar", func(t *testing.T) {
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "DECIMAL(10,2)", Nullable: true}, ParseTypeTree("DECIMAL(10,2)"))
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "timestamp(6) with time zone", Nullable: true}, ParseTypeTree("timestamp(6) with time zone"))
+		require.False(t, IsNestedType(ParseTypeTree("STRING")))
+	})
+
+	t.Run("bigquery", func(t *testing.T) {
+		tree := ParseTypeTree("ARRAY<STRUCT<a INT64 NOT NULL, b STRUCT<c STRING, d NUMERIC(10, 2)>>>")
+		require.Equal(t, &sqlconnect.TypeNode{
+			Kind:     sqlconnect.TypeKindArray,
+			RawType:  "ARRAY<STRUCT<a INT64 NOT NULL, b STRUCT<c STRING, d NUMERIC(10, 2)>>>",
+			Nullable: true,
+			Element: &sqlconnect.TypeNode{
+				Kind:     sqlconnect.TypeKindStruct,
+				RawType:  "STRUCT<a INT64 NOT NULL, b STRUCT<c STRING, d NUMERIC(10, 2)>>",
+				Nullable: true,
+				Fields: []sqlconnect.TypeNode{
+					{Kind: sqlconnect.TypeKindScalar, RawType: "INT64", Name: "a"},
+					{
+						Kind:     sqlconnect.TypeKindStruct,
+						RawType:  "STRUCT<c STRING, d NUMERIC(10, 2)>",
+						Name:     "b",
+						Nullable: true,
+						Fields:   []sqlconnect.TypeNode{scalar("c", "STRING"), scalar("d", "NUMERIC(10, 2)")},
+					},
+				},
+			},
+		}, tree)
+		require.True(t, IsNestedType(tree))
+	})
+
+	t.Run("databricks", func(t *testing.T) {
+		tree := ParseTypeTree("struct<a:int NOT NULL COMMENT 'a, <b>',`b c`:map<string,array<bigint>>>")
+		require.Equal(t, &sqlconnect.TypeNode{
+			Kind:     sqlconnect.TypeKindStruct,
+			RawType:  "struct<a:int NOT NULL COMMENT 'a, <b>',`b c`:map<string,array<bigint>>>",
+			Nullable: true,
+			Fields: []sqlconnect.TypeNode{
+				{Kind: sqlconnect.TypeKindScalar, RawType: "int", Name: "a"},
+				{
+					Kind:     sqlconnect.TypeKindMap,
+					RawType:  "map<string,array<bigint>>",
+					Name:     "b c",
+					Nullable: true,
+					Key:      &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "string", Nullable: true},
+					Value: &sqlconnect.TypeNode{
+						Kind:     sqlconnect.TypeKindArray,
+						RawType:  "array<bigint>",
+						Nullable: true,
+						Element:  &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "bigint", Nullable: true},
+					},
+				},
+			},
+		}, tree)
+	})
+
+	t.Run("trino", func(t *testing.T) {
+		tree := ParseTypeTree(`row(a integer, "b ""c""" varchar(10), d double precision, row(timestamp(3) with time zone))`)
+		require.Equal(t, sqlconnect.TypeKindStruct, tree.Kind)
+		require.Equal(t, []sqlconnect.TypeNode{
+			scalar("a", "integer"),
+			scalar(`b "c"`, "varchar(10)"),
+			scalar("d", "double precision"),
+			{
+				Kind:     sqlconnect.TypeKindStruct,
+				RawType:  "row(timestamp(3) with time zone)",
+				Nullable: true,
+				Fields:   []sqlconnect.TypeNode{scalar("", "timestamp(3) with time zone")},
+			},
+		}, tree.Fields)
+	})
+
+	t.Run("without nested types", func(t *testing.T) {
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindArray, RawType: "ARRAY", Nullable: true}, ParseTypeTree("ARRAY"))
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindStruct, RawType: "RECORD", Nullable: true}, ParseTypeTree("RECORD"))
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindStruct, RawType: "OBJECT()", Nullable: true}, ParseTypeTree("OBJECT()"))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		require.NotPanics(t, func() { ParseTypeTree("('\\") }, "it should not read past a trailing backslash of a string literal")
+		require.NotPanics(t, func() { ParseTypeTree("struct<a:int COMMENT 'unterminated") })
+	})
+
+	t.Run("nested type tree", func(t *testing.T) {
+		require.Nil(t, NestedTypeTree("INT64"), "it should return nil for scalars")
+		require.Nil(t, NestedTypeTree("super"), "it should return nil for variants that are not declared")
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindVariant, RawType: "super", Nullable: true}, NestedTypeTree("super", "SUPER"))
+		require.Equal(t, &sqlconnect.TypeNode{
+			Kind:     sqlconnect.TypeKindArray,
+			RawType:  "array<variant>",
+			Nullable: true,
+			Element:  &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindVariant, RawType: "variant", Nullable: true},
+		}, NestedTypeTree("array<variant>", "VARIANT"))
+	})
+
+	t.Run("field paths", func(t *testing.T) {
+		tree := ParseTypeTree("STRUCT<a STRUCT<b INT64, c ARRAY<STRUCT<d STRING>>>, e MAP<STRING, STRUCT<f INT64>>>")
+		require.Equal(t, []string{"a", "a.b", "a.c", "e"}, tree.FieldPaths())
+		require.Nil(t, ParseTypeTree("INT64").FieldPaths())
+	})
+}
+
+func FuzzParseTypeTree(f *testing.F) {
+	for _, rawType := range []string{
+		"DECIMAL(10,2)",
+		"ARRAY<STRUCT<a INT64 NOT NULL, b STRUCT<c STRING, d NUMERIC(10, 2)>>>",
+		"struct<a:int NOT NULL COMMENT 'a, <b>',`b c`:map<string,array<bigint>>>",
+		`row(a integer, "b ""c""" varchar(10), d double precision, row(timestamp(3) with time zone))`,
+		"('\\",
+	} {
+		f.Add(rawType)
+	}
+	f.Fuzz(func(t *testing.T, rawType string) {
+		tree := ParseTypeTree(rawType)
+		require.NotNil(t, tree)
+		_ = tree.FieldPaths()
+	})
+}
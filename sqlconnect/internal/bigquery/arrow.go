@@ -0,0 +1,28 @@
+package bigquery
+
+import (
+	"context"
+	sqldriver "database/sql/driver"
+	"fmt"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/bigquery/driver"
+)
+
+// queryArrow runs a query reading its results as arrow records using the storage read api, until the returned reader is released
+func queryArrow(ctx context.Context, driverConn any, query string, args []sqldriver.NamedValue, _ func(sqldriver.Rows) *arrow.Schema) (array.RecordReader, error) {
+	conn, ok := driverConn.(driver.BigQueryConnection)
+	if !ok {
+		return nil, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	reader, err := conn.QueryArrow(ctx, query, args)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return base.NewReleasingRecordReader(reader, cancel), nil
+}
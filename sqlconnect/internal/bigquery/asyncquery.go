@@ -0,0 +1,57 @@
+package bigquery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/bigquery/driver"
+)
+
+// asyncQueries runs queries asynchronously using query jobs, which are identified by their ids and locations
+var asyncQueries = base.AsyncQueries{
+	DatabaseType: DatabaseType,
+	SubmitContext: func(ctx context.Context) (context.Context, func() (string, string)) {
+		var jobID, location string
+		return driver.WithAsyncMode(ctx, func(id, loc string) { jobID, location = id, loc }), func() (string, string) {
+			return jobID, location
+		}
+	},
+	Status: jobStatus,
+	FetchContext: func(ctx context.Context, handle sqlconnect.QueryHandle) context.Context {
+		return driver.WithFetchResultByID(ctx, handle.ID, handle.Location)
+	},
+	Cancel: func(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) error {
+		conn, ok := driverConn.(driver.BigQueryConnection)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection: %T", driverConn)
+		}
+		return conn.CancelJob(ctx, handle.ID, handle.Location)
+	},
+}
+
+// jobStatus returns the status of a query job
+func jobStatus(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error) {
+	conn, ok := driverConn.(driver.BigQueryConnection)
+	if !ok {
+		return sqlconnect.QueryStatus{}, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	status, err := conn.JobStatus(ctx, handle.ID, handle.Location)
+	if err != nil {
+		return sqlconnect.QueryStatus{}, err
+	}
+	if status.State != bigquery.Done {
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateRunning}, nil
+	}
+	if err := status.Err(); err != nil {
+		if bqErr, ok := errors.AsType[*bigquery.Error](err); ok && bqErr.Reason == "stopped" { // the job has been canceled
+			return sqlconnect.QueryStatus{State: sqlconnect.QueryStateCanceled, Err: err}, nil
+		}
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateFailed, Err: err}, nil
+	}
+	return sqlconnect.QueryStatus{State: sqlconnect.QueryStateSucceeded}, nil
+}
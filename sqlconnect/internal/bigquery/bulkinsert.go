@@ -0,0 +1,46 @@
+package bigquery
+
+import (
+	"context"
+	"iter"
+
+	"cloud.google.com/go/bigquery"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// InsertRows inserts rows into the given table using the bigquery client's streaming inserter.
+// Note that rows inserted this way are not available for DML operations (e.g. [TRUNCATE]) while they are still in the streaming buffer.
+func (db *DB) InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], opts ...sqlconnect.Option) error {
+	return base.InsertRows(ctx, relation, columns, rows, db.putRows, opts...)
+}
+
+// putRows inserts a batch of rows using a single streaming insert request
+func (db *DB) putRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error {
+	return db.WithBigqueryClient(ctx, func(c *bigquery.Client) error {
+		dataset := c.Dataset(relation.Schema)
+		if relation.Catalog != "" {
+			dataset = c.DatasetInProject(relation.Catalog, relation.Schema)
+		}
+		savers := make([]bigquery.ValueSaver, len(batch))
+		for i, row := range batch {
+			savers[i] = rowSaver{columns: columns, values: row}
+		}
+		return dataset.Table(relation.Name).Inserter().Put(ctx, savers)
+	})
+}
+
+// rowSaver is a [bigquery.ValueSaver] for a row of values following the order of the provided columns
+type rowSaver struct {
+	columns []string
+	values  []any
+}
+
+func (r rowSaver) Save() (map[string]bigquery.Value, string, error) {
+	row := make(map[string]bigquery.Value, len(r.columns))
+	for i, col := range r.columns {
+		row[col] = r.values[i]
+	}
+	return row, "", nil // an empty insertID lets the client generate one for best-effort deduplication
+}
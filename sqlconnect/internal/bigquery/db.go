@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/samber/lo"
@@ -22,6 +23,11 @@ const (
 
 // NewDB creates a new bigquery db client
 func NewDB(configJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), configJSON)
+}
+
+// NewDBContext creates a new bigquery db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, configJSON json.RawMessage) (*DB, error) {
 	var config Config
 	err := config.Parse(configJSON)
 	if err != nil {
@@ -40,6 +46,13 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 			base.WithDialect(newDialect()),
 			base.WithColumnTypeMapper(getColumnTypeMapper(config)),
 			base.WithJsonRowMapper(getJonRowMapper(config)),
+			base.WithTypeTreeMapper(typeTreeMapper),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("bigquery", config.ProjectID),
+			base.WithQueryTagContext(driver.WithLabels),
+			base.WithAsyncQueries(asyncQueries),
+			base.WithArrowQuery(queryArrow),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.CreateTestTable = func(table base.QuotedIdentifier) string {
 					return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %[1]s (c1 INT, c2 STRING)", table)
@@ -72,6 +85,52 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 					return stmt, "column_name", "data_type"
 				}
 
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					stmt := fmt.Sprintf("SELECT c.column_name, c.data_type, c.ordinal_position, c.is_nullable, NULLIF(c.column_default, 'NULL') AS column_default, p.description AS column_comment, c.is_partitioning_column, c.clustering_ordinal_position IS NOT NULL AS is_clustering_key, "+
+						"EXISTS (SELECT 1 FROM `%[1]s`.INFORMATION_SCHEMA.TABLE_CONSTRAINTS tc JOIN `%[1]s`.INFORMATION_SCHEMA.KEY_COLUMN_USAGE kcu ON kcu.constraint_name = tc.constraint_name WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_name = c.table_name AND kcu.column_name = c.column_name) AS is_primary_key "+
+						"FROM `%[1]s`.INFORMATION_SCHEMA.COLUMNS c LEFT JOIN `%[1]s`.INFORMATION_SCHEMA.COLUMN_FIELD_PATHS p ON p.table_name = c.table_name AND p.column_name = c.column_name AND p.field_path = c.column_name "+
+						"WHERE c.table_name = '%[2]s'", schema, base.EscapeSqlString(table))
+					if catalog != "" {
+						stmt += fmt.Sprintf(" AND c.table_catalog = '%[1]s'", base.EscapeSqlString(catalog))
+					}
+					// precision, scale and max length are parsed from the data type, e.g. NUMERIC(10, 2)
+					return stmt + " ORDER BY c.ordinal_position ASC", base.ColumnDetailsColumns{
+						Name:          "column_name",
+						Type:          "data_type",
+						Ordinal:       "ordinal_position",
+						Nullable:      "is_nullable",
+						Default:       "column_default",
+						Comment:       "column_comment",
+						PrimaryKey:    "is_primary_key",
+						PartitionKey:  "is_partitioning_column",
+						ClusteringKey: "is_clustering_key",
+					}
+				}
+				cmds.CreateTable = func(table base.QuotedIdentifier, columns []string, primaryKey []base.QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []base.QuotedIdentifier) (string, error) {
+					definitions := columns
+					if len(primaryKey) > 0 { // bigquery only supports unenforced primary keys
+						definitions = append(slices.Clone(columns), fmt.Sprintf("PRIMARY KEY (%s) NOT ENFORCED", base.JoinQuotedIdentifiers(primaryKey, "")))
+					}
+					stmt := base.CreateTableCommand(table, ifNotExists, definitions)
+					switch len(partitionBy) {
+					case 0:
+					case 1: // daily partitioning by a timestamp column, see [DB.CreateTable]
+						stmt += fmt.Sprintf(" PARTITION BY DATE(%s)", partitionBy[0])
+					default:
+						return "", fmt.Errorf("partitioning tables by more than one column: %w", sqlconnect.ErrNotSupported)
+					}
+					if len(clusterBy) > 0 {
+						stmt += fmt.Sprintf(" CLUSTER BY %s", base.JoinQuotedIdentifiers(clusterBy, ""))
+					}
+					return stmt, nil
+				}
+				cmds.AlterColumnType = func(table, column base.QuotedIdentifier, fromType, toType, ddlType string) ([]string, error) {
+					if fromType != "int" || toType != "float" { // INT64 can only be widened to NUMERIC, BIGNUMERIC or FLOAT64
+						return nil, fmt.Errorf("bigquery only supports widening int columns to float: %w", sqlconnect.ErrNotSupported)
+					}
+					return []string{fmt.Sprintf("ALTER TABLE %[1]s ALTER COLUMN %[2]s SET DATA TYPE %[3]s", table, column, ddlType)}, nil
+				}
+				cmds.Merge = base.MergeInto
 				return cmds
 			}),
 		),
@@ -79,8 +138,8 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -88,19 +147,25 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 // WithBigqueryClient runs the provided function by providing access to a native bigquery client, the underlying client that is used by the bigquery driver
+// Errors returned by the function are classified using [DB.ClassifyError].
 func (db *DB) WithBigqueryClient(ctx context.Context, f func(*bigquery.Client) error) error {
 	sqlconn, err := db.Conn(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = sqlconn.Close() }()
-	return sqlconn.Raw(func(driverConn any) error {
+	return db.ClassifyError(sqlconn.Raw(func(driverConn any) error {
 		if c, ok := driverConn.(bqclient); ok {
 			return f(c.BigqueryClient())
 		}
 		return fmt.Errorf("invalid driver connection")
-	})
+	}))
 }
 
 type bqclient interface {
@@ -0,0 +1,63 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+// QueryArrow runs a query returning a reader of its results as arrow records, which keeps reading them using the context.
+// Results are read using the storage read api, thus the query runs using a separate client which has it enabled.
+func (connection *bigQueryConnection) QueryArrow(ctx context.Context, query string, args []driver.NamedValue) (_ array.RecordReader, err error) {
+	statement := &bigQueryStatement{connection, query}
+	ctx, span := statement.startSpan(ctx)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	readClient, err := connection.storageReadClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("creating storage read client: %w", err)
+	}
+	q := readClient.Query(query)
+	if q.Parameters, err = statement.buildParameters(convertParameters(args)); err != nil {
+		return nil, err
+	}
+	q.Labels = labels(ctx)
+
+	rowIterator, err := readQuery(ctx, span, q)
+	if err != nil {
+		return nil, err
+	}
+	arrowIterator, err := rowIterator.ArrowIterator()
+	if err != nil {
+		return nil, err
+	}
+	reader, err := ipc.NewReader(bigquery.NewArrowIteratorReader(arrowIterator))
+	if err != nil {
+		return nil, fmt.Errorf("reading arrow schema: %w", err)
+	}
+	return reader, nil
+}
+
+// storageReadClient returns the client of the connection using the storage read api, creating it if needed
+func (connection *bigQueryConnection) storageReadClient(ctx context.Context) (*bigquery.Client, error) {
+	if connection.readClient != nil {
+		return connection.readClient, nil
+	}
+	ctx = context.WithoutCancel(ctx) // the client outlives the query it is created for
+	client, err := bigquery.NewClient(ctx, connection.client.Project(), connection.opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := client.EnableStorageReadClient(ctx, connection.opts...); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	connection.readClient = client
+	return client, nil
+}
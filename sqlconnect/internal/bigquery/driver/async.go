@@ -0,0 +1,88 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/apache/arrow-go/v18/arrow/array"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+type (
+	asyncModeKey       struct{}
+	fetchResultByIDKey struct{}
+)
+
+// jobRef identifies a job by its id and location
+type jobRef struct {
+	id       string
+	location string
+}
+
+// BigQueryConnection exposes the job operations of the driver's connections, along with reading results as arrow records, which can be reached using [sql.Conn.Raw]
+type BigQueryConnection interface {
+	// JobStatus returns the status of a job
+	JobStatus(ctx context.Context, jobID, location string) (*bigquery.JobStatus, error)
+	// CancelJob requests a running job to be canceled, without waiting for it to stop
+	CancelJob(ctx context.Context, jobID, location string) error
+	// QueryArrow runs a query returning a reader of its results as arrow records, which keeps reading them using the context
+	QueryArrow(ctx context.Context, query string, args []driver.NamedValue) (array.RecordReader, error)
+}
+
+// WithAsyncMode returns a context for submitting queries as jobs without waiting for them to complete, calling onSubmit with the id and location of each job created.
+// Queries performed with the context return no rows.
+func WithAsyncMode(ctx context.Context, onSubmit func(jobID, location string)) context.Context {
+	return context.WithValue(ctx, asyncModeKey{}, onSubmit)
+}
+
+// WithFetchResultByID returns a context for fetching the results of a query job, by performing an empty query with it.
+// The job is waited for if it hasn't completed yet.
+func WithFetchResultByID(ctx context.Context, jobID, location string) context.Context {
+	return context.WithValue(ctx, fetchResultByIDKey{}, jobRef{id: jobID, location: location})
+}
+
+// JobStatus returns the status of a job
+func (connection *bigQueryConnection) JobStatus(ctx context.Context, jobID, location string) (*bigquery.JobStatus, error) {
+	job, err := connection.client.JobFromIDLocation(ctx, jobID, location)
+	if err != nil {
+		return nil, err
+	}
+	return job.LastStatus(), nil
+}
+
+// CancelJob requests a running job to be canceled, without waiting for it to stop
+func (connection *bigQueryConnection) CancelJob(ctx context.Context, jobID, location string) error {
+	job, err := connection.client.JobFromIDLocation(ctx, jobID, location)
+	if err != nil {
+		return err
+	}
+	return job.Cancel(ctx)
+}
+
+// fetchJobResults waits for a query job to complete and returns its results
+func (connection *bigQueryConnection) fetchJobResults(ctx context.Context, ref jobRef) (_ driver.Rows, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "bigquery.Job.Read", append(connection.telemetryAttributes(), jobIDKey.String(ref.id))...)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	job, err := connection.client.JobFromIDLocation(ctx, ref.id, ref.location)
+	if err != nil {
+		return nil, err
+	}
+	rowIterator, err := job.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &bigQueryRows{
+		source: createSourceFromRowIterator(rowIterator),
+	}, nil
+}
+
+// noRows are the rows of a query submitted in async mode
+type noRows struct{}
+
+func (noRows) Columns() []string         { return nil }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
@@ -3,8 +3,10 @@ package driver
 import (
 	"database/sql/driver"
 	"fmt"
+	"strings"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/samber/lo"
 )
 
 type bigQuerySchema interface {
@@ -34,18 +36,33 @@ func (columns bigQueryColumns) ColumnNames() []string {
 func (columns bigQueryColumns) ColumnTypeDatabaseTypeName(index int) string {
 	if index > -1 && len(columns.columns) > index {
 		column := columns.columns[index]
-		if column.FieldSchema.Repeated {
-			// Carry the element type (e.g. ARRAY<STRING>) so the result-set/live path matches what
-			// INFORMATION_SCHEMA reports on the catalog path; element-aware type mapping (string
-			// arrays → the array rudder-type) depends on it. A bare "ARRAY" collapses to json.
-			return fmt.Sprintf("ARRAY<%s>", column.FieldSchema.Type)
-		}
-		return string(column.FieldSchema.Type)
+		return FieldSchemaType(column.FieldSchema)
 	}
 
 	return ""
 }
 
+// FieldSchemaType returns the type of a field in the same format INFORMATION_SCHEMA reports it, e.g. ARRAY<STRUCT<a INTEGER, b STRING>>
+func FieldSchemaType(field *bigquery.FieldSchema) string {
+	fieldType := string(field.Type)
+	if field.Type == bigquery.RecordFieldType {
+		// Carry the nested fields so that type trees can be parsed from query results as well
+		fieldType = "STRUCT<" + strings.Join(lo.Map(field.Schema, func(nested *bigquery.FieldSchema, _ int) string {
+			if nested.Required {
+				return fmt.Sprintf("%s %s NOT NULL", nested.Name, FieldSchemaType(nested))
+			}
+			return fmt.Sprintf("%s %s", nested.Name, FieldSchemaType(nested))
+		}), ", ") + ">"
+	}
+	if field.Repeated {
+		// Carry the element type (e.g. ARRAY<STRING>) so the result-set/live path matches what
+		// INFORMATION_SCHEMA reports on the catalog path; element-aware type mapping (string
+		// arrays → the array rudder-type) depends on it. A bare "ARRAY" collapses to json.
+		return fmt.Sprintf("ARRAY<%s>", fieldType)
+	}
+	return fieldType
+}
+
 type bigQueryColumn struct {
 	Name        string
 	FieldSchema *bigquery.FieldSchema
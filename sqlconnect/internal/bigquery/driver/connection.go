@@ -8,13 +8,16 @@ import (
 
 	"cloud.google.com/go/bigquery"
 	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
 )
 
 type bigQueryConnection struct {
-	ctx    context.Context
-	client *bigquery.Client
-	closed bool
-	bad    bool
+	ctx        context.Context
+	client     *bigquery.Client
+	opts       []option.ClientOption // the options of the client, for creating its storage read client
+	readClient *bigquery.Client      // a client using the storage read api, created on demand for reading results as arrow records
+	closed     bool
+	bad        bool
 }
 
 func (connection *bigQueryConnection) GetContext() context.Context {
@@ -52,6 +55,9 @@ func (connection *bigQueryConnection) Close() error {
 		return driver.ErrBadConn
 	}
 	connection.closed = true
+	if connection.readClient != nil {
+		_ = connection.readClient.Close()
+	}
 	return connection.client.Close()
 }
 
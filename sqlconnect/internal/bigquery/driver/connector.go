@@ -29,6 +29,7 @@ func (c *bigQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
 	return &bigQueryConnection{
 		ctx:    ctx,
 		client: client,
+		opts:   c.opts,
 	}, nil
 }
 
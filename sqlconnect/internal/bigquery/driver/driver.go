@@ -30,7 +30,8 @@ func (b bigQueryDriver) Open(uri string) (driver.Conn, error) {
 
 	ctx := context.Background()
 
-	client, err := bigquery.NewClient(ctx, config.projectID, optionsFor(config)...)
+	opts := optionsFor(config)
+	client, err := bigquery.NewClient(ctx, config.projectID, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -38,6 +39,7 @@ func (b bigQueryDriver) Open(uri string) (driver.Conn, error) {
 	return &bigQueryConnection{
 		ctx:    ctx,
 		client: client,
+		opts:   opts,
 	}, nil
 }
 
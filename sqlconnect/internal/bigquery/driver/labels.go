@@ -0,0 +1,50 @@
+package driver
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxLabelLength is the maximum length of the keys and values of BigQuery labels
+const maxLabelLength = 63
+
+type labelsKey struct{}
+
+// WithLabels returns a context whose queries are run using jobs labelled with the given labels.
+// Keys and values are converted to valid labels, i.e. lowercased, with any unsupported characters replaced by underscores and truncated to 63 characters.
+func WithLabels(ctx context.Context, labels map[string]string) context.Context {
+	converted := make(map[string]string, len(labels))
+	for k, v := range labels {
+		k = labelString(k)
+		if r, _ := utf8.DecodeRuneInString(k); !unicode.IsLetter(r) {
+			k = labelString("l_" + k) // keys must start with a letter
+		}
+		converted[k] = labelString(v)
+	}
+	return context.WithValue(ctx, labelsKey{}, converted)
+}
+
+// labels returns the labels of the context, as set by [WithLabels]
+func labels(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(labelsKey{}).(map[string]string)
+	return labels
+}
+
+// labelString converts a string to a valid label key or value
+func labelString(s string) string {
+	var sb strings.Builder
+	var n int
+	for _, r := range strings.ToLower(s) {
+		if n == maxLabelLength {
+			break
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			r = '_'
+		}
+		sb.WriteRune(r)
+		n++
+	}
+	return sb.String()
+}
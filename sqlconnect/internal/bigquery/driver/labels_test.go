@@ -0,0 +1,22 @@
+package driver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLabels(t *testing.T) {
+	ctx := WithLabels(context.Background(), map[string]string{
+		"Customer": "ACME Corp",
+		"1st":      "job/sync",
+		"long":     strings.Repeat("x", 100),
+	})
+	require.Equal(t, map[string]string{
+		"customer": "acme_corp",
+		"l_1st":    "job_sync",
+		"long":     strings.Repeat("x", 63),
+	}, labels(ctx))
+}
@@ -5,10 +5,14 @@ import (
 	"database/sql/driver"
 	"regexp"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"github.com/samber/lo"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
 )
 
 var namedParamsRegexp = regexp.MustCompile(`@[\w]+`)
@@ -35,13 +39,17 @@ func (bigQueryStatement) CheckNamedValue(*driver.NamedValue) error {
 	return nil
 }
 
-func (statement *bigQueryStatement) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+func (statement *bigQueryStatement) ExecContext(ctx context.Context, args []driver.NamedValue) (_ driver.Result, err error) {
+	ctx, span := statement.startSpan(ctx)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	query, err := statement.buildQuery(convertParameters(args))
 	if err != nil {
 		return nil, err
 	}
+	query.Labels = labels(ctx)
 
-	rowIterator, err := query.Read(ctx)
+	rowIterator, err := readQuery(ctx, span, query)
 	if err != nil {
 		return nil, err
 	}
@@ -49,13 +57,30 @@ func (statement *bigQueryStatement) ExecContext(ctx context.Context, args []driv
 	return &bigQueryResult{rowIterator}, nil
 }
 
-func (statement *bigQueryStatement) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+func (statement *bigQueryStatement) QueryContext(ctx context.Context, args []driver.NamedValue) (_ driver.Rows, err error) {
+	if ref, ok := ctx.Value(fetchResultByIDKey{}).(jobRef); ok {
+		return statement.connection.fetchJobResults(ctx, ref)
+	}
+	ctx, span := statement.startSpan(ctx)
+	defer func() { telemetry.EndSpan(span, err) }()
+
 	query, err := statement.buildQuery(convertParameters(args))
 	if err != nil {
 		return nil, err
 	}
+	query.Labels = labels(ctx)
+
+	if onSubmit, ok := ctx.Value(asyncModeKey{}).(func(string, string)); ok {
+		job, err := query.Run(ctx)
+		if err != nil {
+			return nil, err
+		}
+		span.SetAttributes(jobIDKey.String(job.ID()))
+		onSubmit(job.ID(), job.Location())
+		return noRows{}, nil
+	}
 
-	rowIterator, err := query.Read(ctx)
+	rowIterator, err := readQuery(ctx, span, query)
 	if err != nil {
 		return nil, err
 	}
@@ -65,6 +90,23 @@ func (statement *bigQueryStatement) QueryContext(ctx context.Context, args []dri
 	}, nil
 }
 
+// readQuery runs a query job and waits for its results, canceling the job if the context is done before it completes.
+// [bigquery.Query.Read] is not used, since it does not expose the job of a query that is still running when the context is done, leaving it running in the warehouse.
+func readQuery(ctx context.Context, span trace.Span, query *bigquery.Query) (*bigquery.RowIterator, error) {
+	job, err := query.Run(ctx)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(jobIDKey.String(job.ID()))
+	stop := context.AfterFunc(ctx, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = job.Cancel(ctx)
+	})
+	defer stop()
+	return job.Read(ctx)
+}
+
 func (statement bigQueryStatement) Exec(args []driver.Value) (driver.Result, error) {
 	return nil, driver.ErrSkip
 }
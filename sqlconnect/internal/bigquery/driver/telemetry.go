@@ -0,0 +1,26 @@
+package driver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+const jobIDKey = attribute.Key("gcp.bigquery.job.id") // the id of the job running a query
+
+// telemetryAttributes returns the attributes identifying the database in spans and metrics
+func (connection *bigQueryConnection) telemetryAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{semconv.DBSystemKey.String("bigquery"), semconv.DBNamespaceKey.String(connection.client.Project())}
+}
+
+// startSpan starts a span for running the statement's query
+func (statement *bigQueryStatement) startSpan(ctx context.Context) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, "bigquery.Query", append(statement.connection.telemetryAttributes(),
+		semconv.DBOperationNameKey.String(telemetry.OperationName(statement.query)),
+		semconv.DBQueryTextKey.String(telemetry.SanitizeQuery(statement.query)),
+	)...)
+}
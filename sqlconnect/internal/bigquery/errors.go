@@ -0,0 +1,56 @@
+package bigquery
+
+import (
+	"errors"
+	"strings"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/googleapi"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// classifyError classifies bigquery errors using the reasons reported along with them, falling back to the status code of the response for api errors without a known reason.
+// See https://cloud.google.com/bigquery/docs/error-messages
+func classifyError(err error) []error {
+	if bqErr, ok := errors.AsType[*bigquery.Error](err); ok {
+		return classifyErrorReason(bqErr.Reason, bqErr.Message)
+	}
+	if apiErr, ok := errors.AsType[*googleapi.Error](err); ok {
+		for _, item := range apiErr.Errors {
+			if classes := classifyErrorReason(item.Reason, item.Message); len(classes) > 0 {
+				return classes
+			}
+		}
+		return base.ClassifyHTTPStatus(apiErr.Code)
+	}
+	return nil
+}
+
+func classifyErrorReason(reason, message string) []error {
+	switch reason {
+	case "authError":
+		return []error{sqlconnect.ErrAuthentication}
+	case "accessDenied":
+		return []error{sqlconnect.ErrPermissionDenied}
+	case "notFound":
+		if strings.Contains(message, "Not found: Dataset") || strings.Contains(message, "Not found: Project") {
+			return []error{sqlconnect.ErrSchemaNotFound}
+		}
+		return []error{sqlconnect.ErrRelationNotFound}
+	case "invalidQuery":
+		if strings.Contains(message, "Syntax error") {
+			return []error{sqlconnect.ErrSyntax}
+		}
+	case "timeout":
+		return []error{sqlconnect.ErrQueryTimeout}
+	case "quotaExceeded":
+		return []error{sqlconnect.ErrQuotaExceeded}
+	case "rateLimitExceeded", "jobRateLimitExceeded":
+		return []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}
+	case "backendError", "internalError":
+		return []error{sqlconnect.ErrRetryable}
+	}
+	return nil
+}
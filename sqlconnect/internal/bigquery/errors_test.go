@@ -0,0 +1,39 @@
+package bigquery
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	apiError := func(code int, reason, message string) error {
+		return &googleapi.Error{Code: code, Message: message, Errors: []googleapi.ErrorItem{{Reason: reason, Message: message}}}
+	}
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "table not found", err: apiError(http.StatusNotFound, "notFound", "Not found: Table project:dataset.table was not found in location US"), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "dataset not found", err: apiError(http.StatusNotFound, "notFound", "Not found: Dataset project:dataset was not found in location US"), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "syntax error", err: apiError(http.StatusBadRequest, "invalidQuery", "Syntax error: Unexpected identifier \"SELEC\" at [1:1]"), expected: []error{sqlconnect.ErrSyntax}},
+		{name: "other invalid query", err: apiError(http.StatusBadRequest, "invalidQuery", "Unrecognized name: c3 at [1:8]")},
+		{name: "access denied", err: apiError(http.StatusForbidden, "accessDenied", "Access Denied: Table project:dataset.table"), expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "rate limit exceeded", err: apiError(http.StatusForbidden, "rateLimitExceeded", "Exceeded rate limits"), expected: []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}},
+		{name: "unauthorized without reason", err: &googleapi.Error{Code: http.StatusUnauthorized}, expected: []error{sqlconnect.ErrAuthentication}},
+		{name: "job error", err: fmt.Errorf("waiting for job: %w", &bigquery.Error{Reason: "backendError", Message: "Backend error"}), expected: []error{sqlconnect.ErrRetryable}},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package bigquery_test
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -26,6 +27,9 @@ func TestBigqueryDB(t *testing.T) {
 		integrationtest.Options{
 			LegacySupport:                  true,
 			SpecialCharactersInQuotedTable: "-",
+			LongRunningQuery: func(marker string) string {
+				return fmt.Sprintf("SELECT '%s', COUNT(*) FROM UNNEST(GENERATE_ARRAY(1, 100000)) x CROSS JOIN UNNEST(GENERATE_ARRAY(1, 100000)) y CROSS JOIN UNNEST(GENERATE_ARRAY(1, 100000)) z WHERE x + y + z = 0", marker)
+			},
 		},
 	)
 }
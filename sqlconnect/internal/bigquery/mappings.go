@@ -10,6 +10,7 @@ import (
 	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/civil"
 
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
@@ -54,6 +55,9 @@ var columnTypeMappings = map[string]string{
 	"RECORD": "unsupported",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "INT64", "FLOAT64", "STRING", "TIMESTAMP", "BOOL", "JSON")
+
 var re = regexp.MustCompile(`(\(.+\)|<.+>)`) // remove type parameters [<>] and size constraints [()]
 
 // stringElementArray matches ARRAY<STRING|BYTES ...> — string-element arrays map to the array
@@ -73,6 +77,18 @@ func columnTypeMapper(columnType base.ColumnType) string {
 	return databaseTypeName
 }
 
+// typeTreeMapper parses the type trees of ARRAY and STRUCT columns.
+// Since bigquery arrays cannot contain null elements, array elements are never nullable.
+func typeTreeMapper(columnType base.ColumnType) *sqlconnect.TypeNode {
+	tree := base.NestedTypeTree(columnType.DatabaseTypeName())
+	base.WalkTypeTree(tree, func(node *sqlconnect.TypeNode) {
+		if node.Element != nil {
+			node.Element.Nullable = false
+		}
+	})
+	return tree
+}
+
 // jsonRowMapper maps a row's scanned column to a json object's field
 func jsonRowMapper(databaseTypeName string, value any) any {
 	switch v := value.(type) {
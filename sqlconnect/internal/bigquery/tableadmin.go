@@ -0,0 +1,91 @@
+package bigquery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/bigquery/driver"
+)
+
+// CreateTable overrides the base implementation to only partition tables by datetime columns, since bigquery partitions tables by the date of TIMESTAMP columns,
+// while partitioning by integer columns requires a range of values
+func (db *DB) CreateTable(ctx context.Context, relation sqlconnect.RelationRef, columns []sqlconnect.ColumnDef, opts ...sqlconnect.Option) error {
+	createOpts, err := sqlconnect.NewCreateTableOptions(opts...)
+	if err != nil {
+		return err
+	}
+	for _, name := range createOpts.PartitionBy {
+		if column, ok := lo.Find(columns, func(column sqlconnect.ColumnDef) bool { return column.Name == name }); ok && column.Type != "datetime" {
+			return fmt.Errorf("creating table %s partitioned by column %s of type %q: %w", relation, name, column.Type, sqlconnect.ErrNotSupported)
+		}
+	}
+	return db.DB.CreateTable(ctx, relation, columns, opts...)
+}
+
+// ListColumnsForSqlQuery overrides the base implementation to retrieve the query's schema using a dry-run job, without executing it
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.DB.ListColumnsForSqlQueryUsing(ctx, sql, db.dryRunQuery, opts...)
+}
+
+// EstimateQuery overrides the base implementation to estimate the bytes processed by the query using a dry-run job, without executing it.
+// The plan of the estimate contains the job's query statistics in json format.
+func (db *DB) EstimateQuery(ctx context.Context, sql string) (sqlconnect.QueryEstimate, error) {
+	stats, err := db.dryRun(ctx, sql)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, err
+	}
+	plan, err := json.Marshal(stats)
+	if err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("marshalling query statistics: %w", err)
+	}
+	return sqlconnect.QueryEstimate{
+		Bytes: &stats.TotalBytesProcessed,
+		Plan:  string(plan),
+	}, nil
+}
+
+// dryRunQuery returns the columns of the query's result set using a dry-run job, which validates the query without processing any data
+func (db *DB) dryRunQuery(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+	stats, err := db.dryRun(ctx, sql)
+	if err != nil {
+		return nil, err
+	}
+	if stats.Schema == nil {
+		return nil, fmt.Errorf("dry-run job didn't report the query's schema")
+	}
+	return lo.Map(stats.Schema, func(field *bigquery.FieldSchema, _ int) base.QueryColumn {
+		return base.NewQueryColumn(field.Name, driver.FieldSchemaType(field))
+	}), nil
+}
+
+// dryRun runs the query as a dry-run job, returning the statistics reported for it
+func (db *DB) dryRun(ctx context.Context, sql string) (*bigquery.QueryStatistics, error) {
+	var stats *bigquery.QueryStatistics
+	err := db.WithBigqueryClient(ctx, func(c *bigquery.Client) error {
+		q := c.Query(sql)
+		q.DryRun = true
+		job, err := q.Run(ctx)
+		if err != nil {
+			return fmt.Errorf("running dry-run job: %w", err)
+		}
+		status := job.LastStatus()
+		if err := status.Err(); err != nil {
+			return fmt.Errorf("dry-run job: %w", err)
+		}
+		if status.Statistics == nil {
+			return fmt.Errorf("dry-run job didn't report any statistics")
+		}
+		var ok bool
+		if stats, ok = status.Statistics.Details.(*bigquery.QueryStatistics); !ok {
+			return fmt.Errorf("dry-run job didn't report any query statistics")
+		}
+		return nil
+	})
+	return stats, err
+}
@@ -0,0 +1,18 @@
+package bigquery
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestCreateTablePartitioning(t *testing.T) {
+	db := &DB{}
+	columns := []sqlconnect.ColumnDef{{Name: "id", Type: "int"}, {Name: "ts", Type: "datetime"}}
+	err := db.CreateTable(context.Background(), sqlconnect.NewRelationRef("table"), columns, sqlconnect.WithPartitionBy("id"))
+	require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should not partition tables by columns other than datetime ones")
+	require.ErrorContains(t, err, `partitioned by column id of type "int"`)
+}
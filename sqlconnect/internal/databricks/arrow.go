@@ -0,0 +1,216 @@
+package databricks
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	arrowv12 "github.com/apache/arrow/go/v12/arrow"
+	dbsqlrows "github.com/databricks/databricks-sql-go/rows"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// queryArrow runs a query using the driver's arrow batches, converting their records to the arrow version used by [sqlconnect.QueryArrow].
+// Queries returning no records return a reader with the schema of the batches, or the schema of the rows if the driver cannot provide it.
+func queryArrow(ctx context.Context, driverConn any, query string, args []driver.NamedValue, rowsSchema func(driver.Rows) *arrow.Schema) (array.RecordReader, error) {
+	conn, ok := driverConn.(driver.QueryerContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	rows, err := conn.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	dbsqlRows, ok := rows.(dbsqlrows.Rows)
+	if !ok {
+		_ = rows.Close()
+		return nil, fmt.Errorf("unexpected driver rows: %T", rows)
+	}
+	batches, err := dbsqlRows.GetArrowBatches(ctx)
+	if err != nil {
+		_ = rows.Close()
+		return nil, fmt.Errorf("getting arrow batches: %w", err)
+	}
+	schema, err := batchesSchema(batches)
+	if err != nil {
+		schema = rowsSchema(rows)
+	}
+	return base.ArrowReaderFromRecords(arrowRecords(rows, batches), schema)
+}
+
+// batchesSchema returns the schema of the batches, converted to the arrow version used by [sqlconnect.QueryArrow]
+func batchesSchema(batches dbsqlrows.ArrowBatchIterator) (*arrow.Schema, error) {
+	schema, err := batches.Schema()
+	if err != nil {
+		return nil, err
+	}
+	return convertSchema(schema)
+}
+
+// arrowRecords converts the records of the batches, closing the batches and the rows once done
+func arrowRecords(rows driver.Rows, batches dbsqlrows.ArrowBatchIterator) iter.Seq2[arrow.Record, error] {
+	return func(yield func(arrow.Record, error) bool) {
+		defer func() { _ = rows.Close() }()
+		defer batches.Close()
+		for batches.HasNext() {
+			record, err := batches.Next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, fmt.Errorf("fetching arrow batch: %w", err))
+				return
+			}
+			converted, err := convertRecord(record)
+			record.Release()
+			if err != nil {
+				yield(nil, fmt.Errorf("converting arrow batch: %w", err))
+				return
+			}
+			if !yield(converted, nil) {
+				return
+			}
+		}
+	}
+}
+
+// convertRecord converts a record of the arrow version used by the driver in place, sharing the buffers of its columns.
+// The buffers stay valid once the record is released, since the driver allocates them using the go allocator, which leaves them to the garbage collector.
+func convertRecord(record arrowv12.Record) (arrow.Record, error) {
+	schema, err := convertSchema(record.Schema())
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]arrow.Array, record.NumCols())
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release() // retained by the new record
+			}
+		}
+	}()
+	for i, col := range record.Columns() {
+		data := convertData(col.Data(), schema.Field(i).Type)
+		cols[i] = array.MakeFromData(data)
+		data.Release()
+	}
+	return array.NewRecord(schema, cols, record.NumRows()), nil
+}
+
+// convertData converts the data of an array to the given type, which must have been converted from the type of the data
+func convertData(data arrowv12.ArrayData, dataType arrow.DataType) arrow.ArrayData {
+	buffers := make([]*memory.Buffer, len(data.Buffers()))
+	for i, buf := range data.Buffers() {
+		if buf != nil {
+			buffers[i] = memory.NewBufferBytes(buf.Bytes())
+		}
+	}
+	children := make([]arrow.ArrayData, len(data.Children()))
+	for i, child := range data.Children() {
+		children[i] = convertData(child, dataType.(arrow.NestedType).Fields()[i].Type)
+	}
+	converted := array.NewData(dataType, data.Len(), buffers, children, data.NullN(), data.Offset())
+	for _, child := range children {
+		child.Release() // retained by the converted data
+	}
+	return converted
+}
+
+func convertSchema(schema *arrowv12.Schema) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(schema.Fields()))
+	for i, field := range schema.Fields() {
+		converted, err := convertField(field)
+		if err != nil {
+			return nil, err
+		}
+		fields[i] = converted
+	}
+	metadata := convertMetadata(schema.Metadata())
+	return arrow.NewSchema(fields, &metadata), nil
+}
+
+func convertField(field arrowv12.Field) (arrow.Field, error) {
+	dataType, err := convertType(field.Type)
+	if err != nil {
+		return arrow.Field{}, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return arrow.Field{Name: field.Name, Type: dataType, Nullable: field.Nullable, Metadata: convertMetadata(field.Metadata)}, nil
+}
+
+func convertMetadata(metadata arrowv12.Metadata) arrow.Metadata {
+	return arrow.NewMetadata(metadata.Keys(), metadata.Values())
+}
+
+// convertType converts the types of the records returned by the driver, whose layout is the same for both arrow versions
+func convertType(dataType arrowv12.DataType) (arrow.DataType, error) {
+	switch t := dataType.(type) {
+	case *arrowv12.NullType:
+		return arrow.Null, nil
+	case *arrowv12.BooleanType:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case *arrowv12.Int8Type:
+		return arrow.PrimitiveTypes.Int8, nil
+	case *arrowv12.Int16Type:
+		return arrow.PrimitiveTypes.Int16, nil
+	case *arrowv12.Int32Type:
+		return arrow.PrimitiveTypes.Int32, nil
+	case *arrowv12.Int64Type:
+		return arrow.PrimitiveTypes.Int64, nil
+	case *arrowv12.Float32Type:
+		return arrow.PrimitiveTypes.Float32, nil
+	case *arrowv12.Float64Type:
+		return arrow.PrimitiveTypes.Float64, nil
+	case *arrowv12.StringType:
+		return arrow.BinaryTypes.String, nil
+	case *arrowv12.LargeStringType:
+		return arrow.BinaryTypes.LargeString, nil
+	case *arrowv12.BinaryType:
+		return arrow.BinaryTypes.Binary, nil
+	case *arrowv12.LargeBinaryType:
+		return arrow.BinaryTypes.LargeBinary, nil
+	case *arrowv12.Date32Type:
+		return arrow.FixedWidthTypes.Date32, nil
+	case *arrowv12.Date64Type:
+		return arrow.FixedWidthTypes.Date64, nil
+	case *arrowv12.TimestampType:
+		return &arrow.TimestampType{Unit: arrow.TimeUnit(t.Unit), TimeZone: t.TimeZone}, nil
+	case *arrowv12.Decimal128Type:
+		return &arrow.Decimal128Type{Precision: t.Precision, Scale: t.Scale}, nil
+	case *arrowv12.ListType:
+		elem, err := convertField(t.ElemField())
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOfField(elem), nil
+	case *arrowv12.StructType:
+		fields := make([]arrow.Field, len(t.Fields()))
+		for i, field := range t.Fields() {
+			converted, err := convertField(field)
+			if err != nil {
+				return nil, err
+			}
+			fields[i] = converted
+		}
+		return arrow.StructOf(fields...), nil
+	case *arrowv12.MapType:
+		key, err := convertField(t.KeyField())
+		if err != nil {
+			return nil, err
+		}
+		item, err := convertField(t.ItemField())
+		if err != nil {
+			return nil, err
+		}
+		mapType := arrow.MapOfFields(key, item)
+		mapType.KeysSorted = t.KeysSorted
+		return mapType, nil
+	}
+	return nil, fmt.Errorf("unsupported arrow type: %s", dataType)
+}
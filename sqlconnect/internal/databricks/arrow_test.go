@@ -0,0 +1,70 @@
+package databricks
+
+import (
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	arrowv12 "github.com/apache/arrow/go/v12/arrow"
+	arrayv12 "github.com/apache/arrow/go/v12/arrow/array"
+	"github.com/apache/arrow/go/v12/arrow/decimal128"
+	memoryv12 "github.com/apache/arrow/go/v12/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertRecord(t *testing.T) {
+	schema := arrowv12.NewSchema([]arrowv12.Field{
+		{Name: "id", Type: arrowv12.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrowv12.BinaryTypes.String, Nullable: true, Metadata: arrowv12.NewMetadata([]string{"type"}, []string{"STRING"})},
+		{Name: "created_at", Type: &arrowv12.TimestampType{Unit: arrowv12.Microsecond, TimeZone: "UTC"}},
+		{Name: "amount", Type: &arrowv12.Decimal128Type{Precision: 10, Scale: 2}},
+		{Name: "tags", Type: arrowv12.ListOf(arrowv12.BinaryTypes.String)},
+		{Name: "address", Type: arrowv12.StructOf(arrowv12.Field{Name: "city", Type: arrowv12.BinaryTypes.String})},
+		{Name: "attributes", Type: arrowv12.MapOf(arrowv12.BinaryTypes.String, arrowv12.PrimitiveTypes.Int64)},
+	}, nil)
+	builder := arrayv12.NewRecordBuilder(memoryv12.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*arrayv12.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	builder.Field(1).(*arrayv12.StringBuilder).AppendValues([]string{"a", ""}, []bool{true, false})
+	builder.Field(2).(*arrayv12.TimestampBuilder).AppendValues([]arrowv12.Timestamp{1, 2}, nil)
+	builder.Field(3).(*arrayv12.Decimal128Builder).AppendValues([]decimal128.Num{decimal128.FromI64(1234), decimal128.FromI64(-5)}, nil)
+	tags := builder.Field(4).(*arrayv12.ListBuilder)
+	tags.Append(true)
+	tags.ValueBuilder().(*arrayv12.StringBuilder).AppendValues([]string{"x", "y"}, nil)
+	tags.Append(true)
+	address := builder.Field(5).(*arrayv12.StructBuilder)
+	address.AppendValues([]bool{true, true})
+	address.FieldBuilder(0).(*arrayv12.StringBuilder).AppendValues([]string{"berlin", "paris"}, nil)
+	attributes := builder.Field(6).(*arrayv12.MapBuilder)
+	attributes.Append(true)
+	attributes.KeyBuilder().(*arrayv12.StringBuilder).Append("k")
+	attributes.ItemBuilder().(*arrayv12.Int64Builder).Append(7)
+	attributes.AppendNull()
+	record := builder.NewRecord()
+	defer record.Release()
+
+	converted, err := convertRecord(record)
+	require.NoError(t, err)
+	defer converted.Release()
+
+	require.EqualValues(t, 2, converted.NumRows())
+	require.Equal(t, arrow.PrimitiveTypes.Int32, converted.Schema().Field(0).Type)
+	require.Equal(t, "STRING", converted.Schema().Field(1).Metadata.Values()[0], "it should keep the metadata of the fields")
+	require.True(t, converted.Schema().Field(1).Nullable)
+	for i, expected := range []string{
+		`[1 2]`,
+		`["a" (null)]`,
+		`[1 2]`,
+		`[{1234 0} {18446744073709551611 -1}]`,
+		`[["x" "y"] []]`,
+		`{["berlin" "paris"]}`,
+		`[{["k"] [7]} (null)]`,
+	} {
+		require.Equal(t, expected, converted.Column(i).String(), "it should convert the values of column %s", converted.ColumnName(i))
+	}
+	require.Equal(t, "12.34", converted.Column(3).ValueStr(0), "it should keep the precision and scale of decimals")
+}
+
+func TestConvertType(t *testing.T) {
+	_, err := convertType(arrowv12.FixedWidthTypes.MonthInterval)
+	require.ErrorContains(t, err, "unsupported arrow type", "it should reject types it cannot convert")
+}
@@ -1,9 +1,12 @@
 package databricks
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	databricks "github.com/databricks/databricks-sql-go"
 	"github.com/samber/lo"
@@ -19,8 +22,13 @@ const (
 
 // NewDB creates a new databricks db client
 func NewDB(configJson json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), configJson)
+}
+
+// NewDBContext creates a new databricks db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, configJson json.RawMessage) (*DB, error) {
 	var config Config
-	err := config.Parse(configJson)
+	err := config.ParseContext(ctx, configJson)
 	if err != nil {
 		return nil, err
 	}
@@ -44,9 +52,10 @@ func NewDB(configJson json.RawMessage) (*DB, error) {
 	} else {
 		opts = append(opts, databricks.WithAccessToken(config.Token))
 	}
+	var tunnel sshtunnel.Tunnel
 	tunnelCloser := sshtunnel.NoTunnelCloser
 	if config.TunnelInfo != nil {
-		tunnel, err := sshtunnel.NewSocks5Tunnel(*config.TunnelInfo)
+		tunnel, err = sshtunnel.NewSocks5TunnelContext(ctx, *config.TunnelInfo)
 		if err != nil {
 			return nil, err
 		}
@@ -67,9 +76,16 @@ func NewDB(configJson json.RawMessage) (*DB, error) {
 		DB: base.NewDB(
 			db,
 			tunnelCloser,
+			base.WithSSHTunnel(tunnel),
 			base.WithDialect(newDialect()),
 			base.WithColumnTypeMapper(getColumnTypeMapper(config)),
 			base.WithJsonRowMapper(getJonRowMapper(config)),
+			base.WithTypeTreeMapper(typeTreeMapper),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("databricks", config.Catalog),
+			base.WithArrowQuery(queryArrow),
+			base.WithQueryTagSession(queryTagsSession),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.CurrentCatalog = func() string {
 					return "SELECT current_catalog()"
@@ -121,9 +137,56 @@ func NewDB(configJson json.RawMessage) (*DB, error) {
 					}
 					return fmt.Sprintf("DESCRIBE TABLE `%[1]s`.`%[2]s`.`%[3]s`", catalog, schema, table), "col_name", "data_type"
 				}
+				cmds.DescribeQuery = func(sql string) (string, string, string) {
+					return "DESCRIBE QUERY " + base.TrimQuery(sql), "col_name", "data_type"
+				}
+				cmds.ExplainQuery = func(sql string) (string, func(string) (sqlconnect.QueryEstimate, error)) {
+					return "EXPLAIN COST " + base.TrimQuery(sql), parseQueryPlan
+				}
 				cmds.RenameTable = func(schema, oldName, newName base.QuotedIdentifier) string {
 					return fmt.Sprintf("ALTER TABLE %[1]s.%[2]s RENAME TO %[1]s.%[3]s", schema, oldName, newName)
 				}
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					// column details are only available through unity catalog's information schema, where ordinal positions start from 0
+					informationSchema := "information_schema"
+					if catalog != "" {
+						informationSchema = fmt.Sprintf("`%[1]s`.information_schema", catalog)
+					}
+					stmt := fmt.Sprintf("SELECT c.column_name, c.full_data_type, c.ordinal_position + 1 AS ordinal_position, c.is_nullable, c.numeric_precision, c.numeric_scale, c.character_maximum_length, c.column_default, c.comment, c.partition_index IS NOT NULL AS is_partition_key, "+
+						"EXISTS (SELECT 1 FROM %[1]s.table_constraints tc JOIN %[1]s.key_column_usage kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema AND tc.table_name = c.table_name AND kcu.column_name = c.column_name) AS is_primary_key "+
+						"FROM %[1]s.columns c WHERE c.table_schema = '%[2]s' AND c.table_name = '%[3]s' ORDER BY c.ordinal_position ASC", informationSchema, base.EscapeSqlString(schema), base.EscapeSqlString(table))
+					cols := base.InformationSchemaColumnDetails
+					cols.Type = "full_data_type"
+					cols.Comment = "comment"
+					cols.PartitionKey = "is_partition_key"
+					return stmt, cols
+				}
+				cmds.CreateTable = func(table base.QuotedIdentifier, columns []string, primaryKey []base.QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []base.QuotedIdentifier) (string, error) {
+					if len(partitionBy) > 0 && len(clusterBy) > 0 {
+						return "", fmt.Errorf("partitioning and clustering the same table: %w", sqlconnect.ErrNotSupported)
+					}
+					stmt := base.CreateTableCommand(table, ifNotExists, slices.Concat(columns, base.PrimaryKeyConstraint(primaryKey)))
+					if len(partitionBy) > 0 {
+						stmt += fmt.Sprintf(" PARTITIONED BY (%s)", base.JoinQuotedIdentifiers(partitionBy, ""))
+					}
+					if len(clusterBy) > 0 { // liquid clustering
+						stmt += fmt.Sprintf(" CLUSTER BY (%s)", base.JoinQuotedIdentifiers(clusterBy, ""))
+					}
+					return stmt, nil
+				}
+				cmds.AddColumns = func(table base.QuotedIdentifier, columns []string) []string {
+					return []string{fmt.Sprintf("ALTER TABLE %[1]s ADD COLUMNS (%[2]s)", table, strings.Join(columns, ", "))}
+				}
+				cmds.DropColumns = func(table base.QuotedIdentifier, columns []base.QuotedIdentifier) []string {
+					return []string{
+						enableColumnMapping(table),
+						fmt.Sprintf("ALTER TABLE %[1]s DROP COLUMNS (%[2]s)", table, base.JoinQuotedIdentifiers(columns, "")),
+					}
+				}
+				cmds.AlterColumnType = func(_, _ base.QuotedIdentifier, _, _, _ string) ([]string, error) {
+					return nil, fmt.Errorf("delta tables can only widen BIGINT columns to DECIMAL: %w", sqlconnect.ErrNotSupported)
+				}
+				cmds.Merge = base.MergeInto
 				return cmds
 			}),
 		),
@@ -132,8 +195,8 @@ func NewDB(configJson json.RawMessage) (*DB, error) {
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -142,6 +205,11 @@ type DB struct {
 	skipColumnNormalization bool
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...), skipColumnNormalization: db.skipColumnNormalization}
+}
+
 func getColumnTypeMapper(config Config) func(base.ColumnType) string {
 	if config.UseLegacyMappings {
 		return legacyColumnTypeMapper
@@ -0,0 +1,52 @@
+package databricks
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+const dbSqlStateCatalogNotFound = "42704" // catalog (or schema) not found
+
+// errorClasses maps the error classes reported by databricks at the start of error messages, e.g. [TABLE_OR_VIEW_NOT_FOUND], to their warehouse-agnostic error classes
+var errorClasses = map[string][]error{
+	"TABLE_OR_VIEW_NOT_FOUND":              {sqlconnect.ErrRelationNotFound},
+	"SCHEMA_NOT_FOUND":                     {sqlconnect.ErrSchemaNotFound},
+	"CATALOG_NOT_FOUND":                    {sqlconnect.ErrSchemaNotFound},
+	"NO_SUCH_CATALOG_EXCEPTION":            {sqlconnect.ErrSchemaNotFound},
+	"PARSE_SYNTAX_ERROR":                   {sqlconnect.ErrSyntax},
+	"INSUFFICIENT_PERMISSIONS":             {sqlconnect.ErrPermissionDenied},
+	"PERMISSION_DENIED":                    {sqlconnect.ErrPermissionDenied},
+	"QUERY_EXECUTION_TIMEOUT_EXCEEDED":     {sqlconnect.ErrQueryTimeout},
+	"RESOURCE_EXHAUSTED":                   {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"TEMPORARILY_UNAVAILABLE":              {sqlconnect.ErrRetryable},
+	"DELTA_ALTER_TABLE_RENAME_NOT_ALLOWED": {sqlconnect.ErrNotSupported},
+}
+
+// errorClassRegex matches the error classes reported by databricks in error messages, e.g. [TABLE_OR_VIEW_NOT_FOUND] or [UNRESOLVED_COLUMN.WITH_SUGGESTION], capturing the main class
+var errorClassRegex = regexp.MustCompile(`\[([A-Z][A-Z0-9_]*)(?:\.[A-Z0-9_]+)*\]`)
+
+// classifyError classifies databricks errors using the first known error class found in their message, falling back to their SQLSTATE codes
+func classifyError(err error) []error {
+	for _, match := range errorClassRegex.FindAllStringSubmatch(err.Error(), -1) {
+		errorClass := match[1]
+		if classes, ok := errorClasses[errorClass]; ok {
+			return classes
+		}
+		if strings.HasPrefix(errorClass, "DELTA_CONCURRENT_") { // conflicting concurrent transactions, e.g. DELTA_CONCURRENT_APPEND
+			return []error{sqlconnect.ErrRetryable}
+		}
+	}
+	if execErr, ok := errors.AsType[dbsqlerr.DBExecutionError](err); ok {
+		if execErr.SqlState() == dbSqlStateCatalogNotFound {
+			return []error{sqlconnect.ErrSchemaNotFound}
+		}
+		return base.ClassifySQLState(execErr.SqlState())
+	}
+	return nil
+}
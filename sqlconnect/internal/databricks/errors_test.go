@@ -0,0 +1,35 @@
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "table not found", err: errors.New("databricks: execution error: failed to execute query: [TABLE_OR_VIEW_NOT_FOUND] The table or view `s`.`t` cannot be found."), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "schema not found", err: errors.New("databricks: execution error: failed to execute query: [SCHEMA_NOT_FOUND] The schema `s` cannot be found."), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "syntax error", err: fmt.Errorf("querying: %w", errors.New("[PARSE_SYNTAX_ERROR] Syntax error at or near 'SELEC'.")), expected: []error{sqlconnect.ErrSyntax}},
+		{name: "insufficient permissions", err: errors.New("[INSUFFICIENT_PERMISSIONS] Insufficient privileges: User does not have USE SCHEMA on Schema 's'."), expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "rename not allowed", err: errors.New("[DELTA_ALTER_TABLE_RENAME_NOT_ALLOWED] Operation not allowed: ALTER TABLE RENAME TO is not allowed for managed Delta tables on S3"), expected: []error{sqlconnect.ErrNotSupported}},
+		{name: "concurrent append", err: errors.New("[DELTA_CONCURRENT_APPEND] ConcurrentAppendException: Files were added to the root of the table by a concurrent update."), expected: []error{sqlconnect.ErrRetryable}},
+		{name: "error sub-class", err: errors.New("[INSUFFICIENT_PERMISSIONS.NO_USAGE] Insufficient privileges."), expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "error class mentioned in the message", err: errors.New("[TABLE_OR_VIEW_NOT_FOUND] The table or view `PERMISSION_DENIED` cannot be found."), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "unknown error class before a known one", err: errors.New("[UNKNOWN_ERROR] Caused by: [SCHEMA_NOT_FOUND] The schema `s` cannot be found."), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "error class without brackets", err: errors.New("the PARSE_SYNTAX_ERROR option is not set")},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
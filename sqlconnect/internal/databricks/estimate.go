@@ -0,0 +1,59 @@
+package databricks
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// planStatisticsRegex matches the statistics of a node in an optimized logical plan, e.g. Statistics(sizeInBytes=1.0 KiB, rowCount=5)
+var planStatisticsRegex = regexp.MustCompile(`Statistics\(sizeInBytes=([^,)]+)(?:, rowCount=([0-9]+))?`)
+
+// parseQueryPlan extracts an estimate out of a plan returned by EXPLAIN COST, using the statistics of its optimized logical plan.
+// Rows are estimated using the statistics of the plan's root node, whereas bytes are summed across the relations read by the query.
+// Spark reports the size of relations without statistics as 8.0 EiB, in which case bytes are left empty.
+func parseQueryPlan(plan string) (sqlconnect.QueryEstimate, error) {
+	var (
+		estimate      sqlconnect.QueryEstimate
+		bytes         int64
+		bytesKnown    = true
+		relationFound bool
+		rootEstimated bool
+		inLogicalPlan bool
+	)
+	for line := range strings.Lines(plan) {
+		if strings.HasPrefix(line, "==") {
+			inLogicalPlan = strings.Contains(line, "Optimized Logical Plan")
+			continue
+		}
+		match := planStatisticsRegex.FindStringSubmatch(line)
+		if !inLogicalPlan || match == nil {
+			continue
+		}
+		if !rootEstimated {
+			rootEstimated = true
+			if rows, err := strconv.ParseInt(match[2], 10, 64); err == nil {
+				estimate.Rows = &rows
+			}
+		}
+		if strings.Contains(line, "Relation ") {
+			relationFound = true
+			size, err := base.ParseByteSize(match[1])
+			if err != nil {
+				bytesKnown = false
+			}
+			bytes += size
+		}
+	}
+	if !rootEstimated {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("no statistics found in plan: %q", plan)
+	}
+	if relationFound && bytesKnown {
+		estimate.Bytes = &bytes
+	}
+	return estimate, nil
+}
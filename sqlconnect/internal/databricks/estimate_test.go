@@ -0,0 +1,41 @@
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Run("with statistics", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`== Optimized Logical Plan ==
+Join Inner, (id#1 = id#3), Statistics(sizeInBytes=2.0 KiB, rowCount=12)
+:- Relation spark_catalog.default.a[id#1,name#2] parquet, Statistics(sizeInBytes=1.5 KiB, rowCount=20)
++- Relation spark_catalog.default.b[id#3] parquet, Statistics(sizeInBytes=512.0 B, rowCount=10)
+
+== Physical Plan ==
+AdaptiveSparkPlan isFinalPlan=false
++- BroadcastHashJoin [id#1], [id#3], Inner, BuildRight, false
+`)
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 12, *estimate.Rows)
+		require.NotNil(t, estimate.Bytes)
+		require.EqualValues(t, 2048, *estimate.Bytes)
+	})
+
+	t.Run("without statistics", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`== Optimized Logical Plan ==
+Project [id#1], Statistics(sizeInBytes=8.0 EiB)
++- Relation spark_catalog.default.a[id#1] json, Statistics(sizeInBytes=8.0 EiB)
+`)
+		require.NoError(t, err)
+		require.Nil(t, estimate.Rows)
+		require.Nil(t, estimate.Bytes)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := parseQueryPlan(`== Physical Plan ==`)
+		require.ErrorContains(t, err, "no statistics found in plan")
+	})
+}
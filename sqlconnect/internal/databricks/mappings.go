@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
@@ -49,6 +50,9 @@ var columnTypeMappings = map[string]string{
 	"STRUCT": "unsupported",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "BIGINT", "DOUBLE", "STRING", "TIMESTAMP", "BOOLEAN", "VARIANT")
+
 var re = regexp.MustCompile(`(\(.+\)|<.+>)`) // remove type parameters [<>] and size constraints [()]
 
 // stringElementArray matches ARRAY<STRING|VARCHAR|CHAR ...> — string-element arrays
@@ -68,6 +72,12 @@ func columnTypeMapper(columnType base.ColumnType) string {
 	return databaseTypeName
 }
 
+// typeTreeMapper parses the type trees of ARRAY, MAP, STRUCT and VARIANT columns.
+// Element, key and value types are only available when listing the columns of a table, since the driver reports bare ARRAY, MAP and STRUCT types for query results.
+func typeTreeMapper(columnType base.ColumnType) *sqlconnect.TypeNode {
+	return base.NestedTypeTree(columnType.DatabaseTypeName(), "VARIANT")
+}
+
 // jsonRowMapper maps a row's scanned column to a json object's field
 func jsonRowMapper(databaseTypeName string, value any) any {
 	switch v := value.(type) {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 )
 
 // mockColumnType implements base.ColumnType for testing the column type mapper.
@@ -49,3 +51,14 @@ func TestLegacyColumnTypeMapper_Array(t *testing.T) {
 	}
 	require.Equal(t, "string", legacyColumnTypeMapper(mockColumnType{"STRING"}))
 }
+
+func TestTypeTreeMapper(t *testing.T) {
+	require.Nil(t, typeTreeMapper(mockColumnType{"decimal(10,2)"}), "scalars have no type tree")
+	require.Equal(t, sqlconnect.TypeKindVariant, typeTreeMapper(mockColumnType{"variant"}).Kind)
+	require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindArray, RawType: "ARRAY", Nullable: true}, typeTreeMapper(mockColumnType{"ARRAY"}), "query results report bare arrays")
+
+	tree := typeTreeMapper(mockColumnType{"array<struct<a:int,b:map<string,variant>>>"})
+	require.Equal(t, sqlconnect.TypeKindArray, tree.Kind)
+	require.Equal(t, []string{"a", "b"}, tree.Element.FieldPaths())
+	require.Equal(t, sqlconnect.TypeKindVariant, tree.Element.Fields[1].Value.Kind)
+}
@@ -0,0 +1,20 @@
+package databricks
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// queryTagsEscaper percent-encodes the characters separating the key:value pairs of the query_tags, as well as quotes and backslashes which databricks treats as escapes in string literals
+var queryTagsEscaper = strings.NewReplacer("%", "%25", ",", "%2C", ":", "%3A", "'", "%27", `\`, "%5C")
+
+// queryTagsSession returns the statements setting and resetting the query_tags of a session, setting them to the query tags formatted as comma-separated key:value pairs
+func queryTagsSession(tags map[string]string) (set, reset string) {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, queryTagsEscaper.Replace(k)+":"+queryTagsEscaper.Replace(tags[k]))
+	}
+	return fmt.Sprintf("SET query_tags = '%s'", strings.Join(pairs, ",")), "RESET query_tags"
+}
@@ -0,0 +1,16 @@
+package databricks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTagsSession(t *testing.T) {
+	set, reset := queryTagsSession(map[string]string{"job": "sync", "customer": "acme"})
+	require.Equal(t, "SET query_tags = 'customer:acme,job:sync'", set)
+	require.Equal(t, "RESET query_tags", reset)
+
+	set, _ = queryTagsSession(map[string]string{"job": `a,b:c`, "o'brien": `50%\`})
+	require.Equal(t, "SET query_tags = 'job:a%2Cb%3Ac,o%27brien:50%25%5C'", set, "it should escape separators, quotes and backslashes in keys and values")
+}
@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 
-	dbsqlerr "github.com/databricks/databricks-sql-go/errors"
-
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 )
 
@@ -33,18 +31,7 @@ func (db *DB) ListSchemas(ctx context.Context, opts ...sqlconnect.Option) ([]sql
 	return schemas, nil
 }
 
-const (
-	dbSqlStateInsufficientPrivileges = "42501" // invalid permission to access catalog
-	dbSqlStateCatalogNotFound        = "42704" // catalog not found
-)
-
+// isObjectInaccessibleError returns true if the error is caused by a catalog or schema that either doesn't exist or is not accessible
 func isObjectInaccessibleError(err error) bool {
-	if execErr, ok := errors.AsType[dbsqlerr.DBExecutionError](err); ok {
-		switch execErr.SqlState() {
-		case dbSqlStateInsufficientPrivileges,
-			dbSqlStateCatalogNotFound:
-			return true
-		}
-	}
-	return false
+	return errors.Is(err, sqlconnect.ErrSchemaNotFound) || errors.Is(err, sqlconnect.ErrPermissionDenied)
 }
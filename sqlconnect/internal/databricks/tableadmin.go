@@ -2,11 +2,13 @@ package databricks
 
 import (
 	"context"
-	"strings"
+	"errors"
+	"fmt"
 
 	"github.com/samber/lo"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
 // ListColumns returns a list of columns for the given table
@@ -21,8 +23,20 @@ func (db *DB) ListColumns(ctx context.Context, relation sqlconnect.RelationRef)
 	}), err
 }
 
-func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string) ([]sqlconnect.ColumnRef, error) {
-	cols, err := db.DB.ListColumnsForSqlQuery(ctx, sql)
+// ListColumnDetails returns a list of columns for the given table along with their metadata
+func (db *DB) ListColumnDetails(ctx context.Context, relation sqlconnect.RelationRef) ([]sqlconnect.ColumnDetails, error) {
+	cols, err := db.DB.ListColumnDetails(ctx, relation)
+	if db.skipColumnNormalization {
+		return cols, err
+	}
+	return lo.Map(cols, func(col sqlconnect.ColumnDetails, _ int) sqlconnect.ColumnDetails {
+		col.Name = db.NormaliseIdentifier(col.Name)
+		return col
+	}), err
+}
+
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	cols, err := db.DB.ListColumnsForSqlQuery(ctx, sql, opts...)
 	if db.skipColumnNormalization {
 		return cols, err
 	}
@@ -36,7 +50,7 @@ func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string) ([]sqlconn
 func (db *DB) RenameTable(ctx context.Context, oldRef, newRef sqlconnect.RelationRef) error {
 	if err := db.DB.RenameTable(ctx, oldRef, newRef); err != nil {
 		// move table if rename is not supported
-		if strings.Contains(err.Error(), "DELTA_ALTER_TABLE_RENAME_NOT_ALLOWED") {
+		if errors.Is(err, sqlconnect.ErrNotSupported) {
 			return db.MoveTable(ctx, oldRef, newRef)
 		}
 		return err
@@ -44,6 +58,20 @@ func (db *DB) RenameTable(ctx context.Context, oldRef, newRef sqlconnect.Relatio
 	return nil
 }
 
+// RenameColumn overrides the base implementation to enable column mapping on the table first, since delta tables can only rename columns with column mapping enabled
+func (db *DB) RenameColumn(ctx context.Context, relation sqlconnect.RelationRef, oldName, newName string) error {
+	if _, err := db.ExecContext(base.WithOperation(ctx, "RenameColumn", relation), enableColumnMapping(base.QuotedIdentifier(db.QuoteTable(relation)))); err != nil {
+		return fmt.Errorf("renaming column %s to %s in %s: %w", oldName, newName, relation, err)
+	}
+	return db.DB.RenameColumn(ctx, relation, oldName, newName)
+}
+
+// enableColumnMapping provides the statement for enabling column mapping by name on a delta table, which is required for renaming and dropping its columns.
+// Enabling it upgrades the table's protocol, thus it is only enabled for tables whose columns are renamed or dropped.
+func enableColumnMapping(table base.QuotedIdentifier) string {
+	return fmt.Sprintf("ALTER TABLE %[1]s SET TBLPROPERTIES ('delta.columnMapping.mode' = 'name')", table)
+}
+
 // ListTables overrides the base implementation to handle nonexistent catalog gracefully
 func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts ...sqlconnect.Option) ([]sqlconnect.RelationRef, error) {
 	tables, err := db.DB.ListTables(ctx, schema, opts...)
@@ -1,13 +1,16 @@
 package integrationtest
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -21,6 +24,7 @@ import (
 	"github.com/rudderlabs/rudder-go-kit/testhelper/rand"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/export"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/op"
 	sqlconnectutil "github.com/rudderlabs/sqlconnect-go/sqlconnect/util"
 )
@@ -31,6 +35,11 @@ type Options struct {
 
 	SpecialCharactersInQuotedTable string // special characters to test in quoted table identifiers (default: <space>,",',``)
 
+	AddUniqueKey func(table, column string) string // provides the statement for adding a unique key to a table, for warehouses that need one for merging tables
+
+	LongRunningQuery func(marker string) string // provides a query containing the marker which keeps running in the warehouse for at least a minute, for testing query cancellation
+	RunningQueries   func(marker string) string // provides a query counting the queries containing the marker which are running in the warehouse, excluding itself
+
 	ExtraTests func(t *testing.T, db sqlconnect.DB)
 }
 
@@ -49,6 +58,20 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 		require.Error(t, err, "it should return error for invalid configuration")
 	})
 
+	t.Run("using context", func(t *testing.T) {
+		t.Run("with context cancelled", func(t *testing.T) {
+			_, err := sqlconnect.NewDBContext(cancelledCtx, warehouse, configJSON)
+			require.ErrorIs(t, err, context.Canceled, "it should not be able to create a new DB with a cancelled context")
+		})
+
+		t.Run("normal operation", func(t *testing.T) {
+			db, err := sqlconnect.NewDBContext(ctx, warehouse, configJSON)
+			require.NoError(t, err, "it should be able to create a new DB")
+			defer func() { _ = db.Close() }()
+			require.NoError(t, db.PingContext(ctx), "it should be able to ping the database")
+		})
+	})
+
 	t.Run("ping", func(t *testing.T) {
 		t.Run("with context cancelled", func(t *testing.T) {
 			err := db.PingContext(cancelledCtx)
@@ -62,6 +85,17 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			err = db.PingContext(ctx)
 			require.NoError(t, err, "it should be able to ping the database")
 		})
+
+		t.Run("with retry policy", func(t *testing.T) {
+			db.SetRetryPolicy(sqlconnect.DefaultRetryPolicy)
+			defer db.SetRetryPolicy(sqlconnect.RetryPolicy{})
+
+			err := db.PingContext(ctx)
+			require.NoError(t, err, "it should be able to ping the database")
+
+			err = db.PingContext(cancelledCtx)
+			require.Error(t, err, "it should not retry pinging the database with a cancelled context")
+		})
 	})
 
 	var currentCatalog sqlconnect.CatalogRef
@@ -585,6 +619,117 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			require.True(t, exists, "it should return true for a table that was just created")
 		})
 
+		t.Run("create table", func(t *testing.T) {
+			table := sqlconnect.NewRelationRef(formatfn("test_table_typed"), sqlconnect.WithSchema(schema.Name))
+			columns := []sqlconnect.ColumnDef{
+				{Name: formatfn("c_int"), Type: "int", NotNull: true},
+				{Name: formatfn("c_float"), Type: "float"},
+				{Name: formatfn("c_string"), Type: "string"},
+				{Name: formatfn("c_datetime"), Type: "datetime"},
+			}
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				err := db.CreateTable(cancelledCtx, table, columns)
+				require.Error(t, err, "it should not be able to create a table with a cancelled context")
+			})
+
+			t.Run("with unsupported type", func(t *testing.T) {
+				err := db.CreateTable(ctx, table, []sqlconnect.ColumnDef{{Name: formatfn("c1"), Type: "unknown"}})
+				require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should not be able to create a table with an unsupported type")
+			})
+
+			err := db.CreateTable(ctx, table, columns)
+			require.NoError(t, err, "it should be able to create a table")
+			err = db.CreateTable(ctx, table, columns, sqlconnect.WithIfNotExists())
+			require.NoError(t, err, "it shouldn't fail if the table already exists")
+
+			created, err := db.ListColumns(ctx, table)
+			require.NoError(t, err, "it should be able to list columns of the created table")
+			require.Equal(t,
+				lo.Map(columns, func(col sqlconnect.ColumnDef, _ int) string { return col.Name + ":" + col.Type }),
+				lo.Map(created, func(col sqlconnect.ColumnRef, _ int) string { return col.Name + ":" + col.Type }),
+				"it should create the columns with the correct types",
+			)
+		})
+
+		t.Run("list column details", func(t *testing.T) {
+			table := sqlconnect.NewRelationRef(formatfn("test_table_typed"), sqlconnect.WithSchema(schema.Name))
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				_, err := db.ListColumnDetails(cancelledCtx, table)
+				require.Error(t, err, "it should not be able to list column details with a cancelled context")
+			})
+
+			t.Run("for nonexistent table", func(t *testing.T) {
+				_, err := db.ListColumnDetails(ctx, sqlconnect.NewRelationRef(formatfn("nonexistent"), sqlconnect.WithSchema(schema.Name)))
+				require.Error(t, err, "it should not be able to list column details for a nonexistent table")
+			})
+
+			details, err := db.ListColumnDetails(ctx, table)
+			require.NoError(t, err, "it should be able to list column details")
+			require.Equal(t,
+				[]string{formatfn("c_int") + ":int:1", formatfn("c_float") + ":float:2", formatfn("c_string") + ":string:3", formatfn("c_datetime") + ":datetime:4"},
+				lo.Map(details, func(col sqlconnect.ColumnDetails, _ int) string {
+					return fmt.Sprintf("%s:%s:%d", col.Name, col.Type, col.Ordinal)
+				}),
+				"it should return the columns with their types and ordinal positions",
+			)
+			require.False(t, details[0].Nullable, "it should report not null columns as not nullable")
+			require.True(t, details[1].Nullable, "it should report nullable columns as nullable")
+		})
+
+		t.Run("schema evolution", func(t *testing.T) {
+			table := sqlconnect.NewRelationRef(formatfn("test_table_typed"), sqlconnect.WithSchema(schema.Name))
+			columnTypes := func() map[string]string {
+				columns, err := db.ListColumns(ctx, table)
+				require.NoError(t, err, "it should be able to list columns")
+				return lo.SliceToMap(columns, func(col sqlconnect.ColumnRef) (string, string) { return col.Name, col.Type })
+			}
+
+			t.Run("add columns", func(t *testing.T) {
+				t.Run("with context cancelled", func(t *testing.T) {
+					err := db.AddColumns(cancelledCtx, table, []sqlconnect.ColumnDef{{Name: formatfn("c_added"), Type: "string"}})
+					require.Error(t, err, "it should not be able to add columns with a cancelled context")
+				})
+
+				err := db.AddColumns(ctx, table, []sqlconnect.ColumnDef{{Name: formatfn("c_added"), Type: "string"}, {Name: formatfn("c_dropped"), Type: "int"}})
+				require.NoError(t, err, "it should be able to add columns")
+				require.Equal(t, "string", columnTypes()[formatfn("c_added")], "it should add the column with the correct type")
+				require.Equal(t, "int", columnTypes()[formatfn("c_dropped")], "it should add the column with the correct type")
+			})
+
+			t.Run("rename column", func(t *testing.T) {
+				err := db.RenameColumn(ctx, table, formatfn("c_added"), formatfn("c_renamed"))
+				require.NoError(t, err, "it should be able to rename a column")
+				types := columnTypes()
+				require.NotContains(t, types, formatfn("c_added"), "it should not contain the old column name")
+				require.Equal(t, "string", types[formatfn("c_renamed")], "it should contain the new column name")
+			})
+
+			t.Run("drop columns", func(t *testing.T) {
+				err := db.DropColumns(ctx, table, []string{formatfn("c_renamed"), formatfn("c_dropped")})
+				require.NoError(t, err, "it should be able to drop columns")
+				types := columnTypes()
+				require.NotContains(t, types, formatfn("c_renamed"), "it should not contain the dropped column")
+				require.NotContains(t, types, formatfn("c_dropped"), "it should not contain the dropped column")
+			})
+
+			t.Run("alter column type", func(t *testing.T) {
+				err := db.AlterColumnType(ctx, table, formatfn("c_string"), "string")
+				require.NoError(t, err, "it should be a no-op when the column already has the requested type")
+
+				err = db.AlterColumnType(ctx, table, formatfn("c_float"), "int")
+				if typeChangeErr, ok := errors.AsType[*sqlconnect.ColumnTypeChangeError](err); ok {
+					require.ErrorIs(t, typeChangeErr, sqlconnect.ErrNotSupported, "it should wrap ErrNotSupported")
+					require.Equal(t, "float", typeChangeErr.FromType)
+					require.Equal(t, "int", typeChangeErr.ToType)
+					return
+				}
+				require.NoError(t, err, "it should be able to alter a column's type")
+				require.Equal(t, "int", columnTypes()[formatfn("c_float")], "it should change the column's type")
+			})
+		})
+
 		t.Run("create view", func(t *testing.T) {
 			_, err := db.ExecContext(ctx, fmt.Sprintf("CREATE VIEW %s AS SELECT * FROM %s", db.QuoteTable(view), db.QuoteTable(table)))
 			require.NoError(t, err, "it should be able to create a view")
@@ -824,6 +969,75 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			require.ElementsMatch(t, columns, []sqlconnect.ColumnRef{
 				{Name: formatfn("c1"), Type: "int"},
 			}, "it should return the correct columns")
+
+			t.Run("with trailing semicolon", func(t *testing.T) {
+				columns, err := db.ListColumnsForSqlQuery(ctx, stmt+";")
+				require.NoError(t, err, "it should be able to list columns for a sql query ending with a semicolon")
+				require.Len(t, columns, 1, "it should return the correct number of columns")
+			})
+
+			t.Run("with execute fallback", func(t *testing.T) {
+				columns, err := db.ListColumnsForSqlQuery(ctx, stmt, sqlconnect.WithExecuteFallback())
+				require.NoError(t, err, "it should be able to list columns for a sql query with execute fallback")
+				require.Len(t, columns, 1, "it should return the correct number of columns")
+			})
+
+			t.Run("with invalid query", func(t *testing.T) {
+				_, err := db.ListColumnsForSqlQuery(ctx, "SELECT * FROM "+db.QuoteTable(sqlconnect.NewRelationRef(formatfn("nonexistent"), sqlconnect.WithSchema(schema.Name))))
+				require.Error(t, err, "it should not be able to list columns for a sql query referencing a nonexistent table")
+			})
+		})
+
+		t.Run("estimate query", func(t *testing.T) {
+			q := sqlconnect.QueryDef{
+				Table:   table,
+				Columns: []string{formatfn("c1")},
+			}
+			stmt := q.ToSQL(db)
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				_, err := db.EstimateQuery(cancelledCtx, stmt)
+				require.Error(t, err, "it should not be able to estimate a query with a cancelled context")
+			})
+
+			estimate, err := db.EstimateQuery(ctx, stmt)
+			require.NoError(t, err, "it should be able to estimate a query")
+			require.NotEmpty(t, estimate.Plan, "it should return the query's plan")
+
+			t.Run("with invalid query", func(t *testing.T) {
+				_, err := db.EstimateQuery(ctx, "SELECT * FROM "+db.QuoteTable(sqlconnect.NewRelationRef(formatfn("nonexistent"), sqlconnect.WithSchema(schema.Name))))
+				require.Error(t, err, "it should not be able to estimate a query referencing a nonexistent table")
+			})
+		})
+
+		t.Run("error classification", func(t *testing.T) {
+			// some drivers report errors lazily, while iterating the result set
+			query := func(sql string) error {
+				rows, err := db.QueryContext(ctx, sql)
+				if err != nil {
+					return err
+				}
+				defer func() { _ = rows.Close() }()
+				for rows.Next() {
+				}
+				return db.ClassifyError(rows.Err())
+			}
+
+			t.Run("with nonexistent table", func(t *testing.T) {
+				err := query("SELECT * FROM " + db.QuoteTable(sqlconnect.NewRelationRef(formatfn("nonexistent"), sqlconnect.WithSchema(schema.Name))))
+				require.ErrorIs(t, err, sqlconnect.ErrRelationNotFound, "it should classify the error as relation not found")
+			})
+
+			t.Run("with syntax error", func(t *testing.T) {
+				err := query("SELEC 1")
+				require.ErrorIs(t, err, sqlconnect.ErrSyntax, "it should classify the error as a syntax error")
+			})
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				_, err := db.QueryContext(cancelledCtx, "SELECT 1")
+				require.Error(t, err, "it should not be able to query with a cancelled context")
+				require.NotErrorIs(t, err, sqlconnect.ErrSyntax, "it should not classify a cancellation as a syntax error")
+			})
 		})
 
 		t.Run("count table rows", func(t *testing.T) {
@@ -845,6 +1059,226 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			require.Equal(t, 1, count, "it should return 1 for a table with one row")
 		})
 
+		t.Run("interceptors", func(t *testing.T) {
+			var ops []sqlconnect.Operation
+			intercepted := sqlconnect.Wrap(db, func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+				ops = append(ops, op)
+				return invoke(ctx, op)
+			})
+
+			t.Run("internal operation", func(t *testing.T) {
+				ops = nil
+				_, err := intercepted.CountTableRows(ctx, table)
+				require.NoError(t, err, "it should be able to count table rows")
+				require.NotEmpty(t, ops, "it should intercept the queries performed for counting table rows")
+				require.Equal(t, sqlconnect.OperationQuery, ops[0].Kind)
+				require.Equal(t, "CountTableRows", ops[0].Method)
+				require.Equal(t, []sqlconnect.RelationRef{table}, ops[0].Relations)
+			})
+
+			t.Run("rejecting operations", func(t *testing.T) {
+				errRejected := errors.New("rejected")
+				guarded := sqlconnect.Wrap(intercepted, func(ctx context.Context, op sqlconnect.Operation, invoke sqlconnect.Invoker) (sqlconnect.OperationResult, error) {
+					if op.Kind == sqlconnect.OperationExec {
+						return sqlconnect.OperationResult{}, errRejected
+					}
+					return invoke(ctx, op)
+				})
+				_, err := guarded.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (c1, c2) VALUES (2, '2')", db.QuoteTable(table)))
+				require.ErrorIs(t, err, errRejected, "it should reject the statement")
+
+				count, err := db.CountTableRows(ctx, table)
+				require.NoError(t, err, "it should be able to count table rows")
+				require.Equal(t, 1, count, "it should not insert any rows")
+			})
+		})
+
+		t.Run("query tags", func(t *testing.T) {
+			ctx := sqlconnect.WithQueryTag(ctx, map[string]string{"customer": "it's acme", "job": "integration test"})
+
+			count, err := db.CountTableRows(ctx, table)
+			require.NoError(t, err, "it should be able to count table rows using a tagged context")
+			require.Equal(t, 1, count)
+
+			_, err = db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE c1 = 42", db.QuoteTable(table)))
+			require.NoError(t, err, "it should be able to execute a statement using a tagged context")
+		})
+
+		t.Run("async queries", func(t *testing.T) {
+			handle, err := db.SubmitQuery(ctx, fmt.Sprintf("SELECT COUNT(*) FROM %s", db.QuoteTable(table)))
+			if errors.Is(err, sqlconnect.ErrNotSupported) {
+				t.Skipf("skipping test for warehouse %s: %v", warehouse, err)
+			}
+			require.NoError(t, err, "it should be able to submit a query")
+
+			b, err := json.Marshal(handle)
+			require.NoError(t, err, "it should be able to marshal the query handle")
+			var resumed sqlconnect.QueryHandle
+			require.NoError(t, json.Unmarshal(b, &resumed), "it should be able to unmarshal the query handle")
+			require.Equal(t, handle, resumed)
+
+			require.Eventually(t, func() bool {
+				status, err := db.QueryStatus(ctx, resumed)
+				require.NoError(t, err, "it should be able to get the status of the query")
+				require.NotEqual(t, sqlconnect.QueryStateFailed, status.State, "it should not fail the query: %v", status.Err)
+				return status.State == sqlconnect.QueryStateSucceeded
+			}, 2*time.Minute, time.Second, "it should report the query as succeeded eventually")
+
+			rows, err := db.FetchResults(ctx, resumed)
+			require.NoError(t, err, "it should be able to fetch the results of the query")
+			defer func() { _ = rows.Close() }()
+			require.True(t, rows.Next(), "it should return a row")
+			var count int
+			require.NoError(t, rows.Scan(&count))
+			require.Equal(t, 1, count)
+			require.NoError(t, rows.Err())
+		})
+
+		t.Run("query arrow", func(t *testing.T) {
+			t.Run("with context cancelled", func(t *testing.T) {
+				_, err := sqlconnect.QueryArrow(cancelledCtx, db, fmt.Sprintf("SELECT c1, c2 FROM %s", db.QuoteTable(table)))
+				require.Error(t, err, "it should not be able to query arrow records with a cancelled context")
+			})
+
+			t.Run("normal operation", func(t *testing.T) {
+				reader, err := sqlconnect.QueryArrow(ctx, db, fmt.Sprintf("SELECT c1, c2 FROM %s", db.QuoteTable(table)))
+				require.NoError(t, err, "it should be able to query arrow records")
+				defer reader.Release()
+				require.Equal(t, 2, reader.Schema().NumFields(), "it should return the schema of the query")
+				var rows int64
+				for reader.Next() {
+					record := reader.Record()
+					for i := range int(record.NumRows()) {
+						require.Equal(t, "1", record.Column(0).ValueStr(i))
+						require.Equal(t, "1", record.Column(1).ValueStr(i))
+					}
+					rows += record.NumRows()
+				}
+				require.NoError(t, reader.Err(), "it should be able to read the arrow records")
+				require.EqualValues(t, 1, rows, "it should return the rows of the table")
+			})
+
+			t.Run("without results", func(t *testing.T) {
+				reader, err := sqlconnect.QueryArrow(ctx, db, fmt.Sprintf("SELECT c1, c2 FROM %s WHERE c1 = 42", db.QuoteTable(table)))
+				require.NoError(t, err, "it should be able to query arrow records")
+				defer reader.Release()
+				require.Equal(t, 2, reader.Schema().NumFields(), "it should return the schema of the query")
+				require.False(t, reader.Next(), "it should not return any records")
+				require.NoError(t, reader.Err())
+			})
+		})
+
+		t.Run("struct row mapper", func(t *testing.T) {
+			type row struct {
+				ID   int64  `sqlconnect:"id"`
+				Name string `sqlconnect:"name"`
+			}
+			query := fmt.Sprintf("SELECT c1 AS %s, c2 AS %s FROM %s", db.QuoteIdentifier("id"), db.QuoteIdentifier("name"), db.QuoteTable(table))
+			var rows []row
+			for r, err := range sqlconnect.QuerySeq(ctx, db, sqlconnect.StructRowMapper[row](db), query) {
+				require.NoError(t, err, "it should be able to map rows to structs")
+				rows = append(rows, r)
+			}
+			require.Equal(t, []row{{ID: 1, Name: "1"}}, rows)
+
+			t.Run("with missing column", func(t *testing.T) {
+				type missing struct {
+					Missing string
+				}
+				for _, err := range sqlconnect.QuerySeq(ctx, db, sqlconnect.StructRowMapper[missing](db), query) {
+					require.ErrorContains(t, err, "missing column", "it should fail for fields without columns")
+				}
+			})
+		})
+
+		t.Run("export query", func(t *testing.T) {
+			query := fmt.Sprintf("SELECT c1, c2 FROM %s", db.QuoteTable(table))
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				_, err := export.ExportQuery(cancelledCtx, db, query, nil, io.Discard, export.FormatCSV)
+				require.Error(t, err, "it should not be able to export with a cancelled context")
+			})
+
+			t.Run("csv", func(t *testing.T) {
+				var buf bytes.Buffer
+				stats, err := export.ExportQuery(ctx, db, query, nil, &buf, export.FormatCSV, export.WithCSVHeader(false))
+				require.NoError(t, err, "it should be able to export as csv")
+				require.Equal(t, "1,1\n", buf.String())
+				require.Equal(t, export.Stats{Rows: 1, Bytes: 4, Chunks: []export.ChunkStats{{Rows: 1, Bytes: 4}}}, stats)
+			})
+
+			for _, format := range []export.Format{export.FormatNDJSON, export.FormatParquet} {
+				t.Run(string(format), func(t *testing.T) {
+					var buf bytes.Buffer
+					stats, err := export.ExportQuery(ctx, db, query, nil, &buf, format)
+					require.NoError(t, err, "it should be able to export as %s", format)
+					require.EqualValues(t, 1, stats.Rows)
+					require.EqualValues(t, buf.Len(), stats.Bytes)
+				})
+			}
+		})
+
+		t.Run("query cancellation", func(t *testing.T) {
+			if opts.LongRunningQuery == nil {
+				t.Skipf("skipping test for warehouse %s: no long running query", warehouse)
+			}
+
+			t.Run("with context cancelled while running", func(t *testing.T) {
+				if opts.RunningQueries == nil {
+					t.Skipf("skipping test for warehouse %s: no query for listing running queries", warehouse)
+				}
+				marker := "tsqlcon_cancel_" + rand.String(12)
+				runningQueries := func() int {
+					var count int
+					require.NoError(t, db.QueryRowContext(ctx, opts.RunningQueries(marker)).Scan(&count), "it should be able to count the running queries")
+					return count
+				}
+
+				queryCtx, cancel := context.WithCancel(ctx)
+				defer cancel()
+				done := make(chan error, 1)
+				go func() {
+					rows, err := db.QueryContext(queryCtx, opts.LongRunningQuery(marker))
+					if err == nil {
+						for rows.Next() {
+						}
+						err = rows.Err()
+						_ = rows.Close()
+					}
+					done <- err
+				}()
+				require.Eventually(t, func() bool { return runningQueries() == 1 }, time.Minute, time.Second, "it should run the query in the warehouse")
+
+				cancel()
+				select {
+				case err := <-done:
+					require.Error(t, err, "it should not be able to complete a query with a cancelled context")
+				case <-time.After(30 * time.Second):
+					t.Fatal("it should return as soon as the context is cancelled")
+				}
+				require.Eventually(t, func() bool { return runningQueries() == 0 }, time.Minute, time.Second, "it should cancel the query in the warehouse")
+			})
+
+			t.Run("cancel submitted query", func(t *testing.T) {
+				handle, err := db.SubmitQuery(ctx, opts.LongRunningQuery("tsqlcon_cancel_"+rand.String(12)))
+				if errors.Is(err, sqlconnect.ErrNotSupported) {
+					t.Skipf("skipping test for warehouse %s: %v", warehouse, err)
+				}
+				require.NoError(t, err, "it should be able to submit a query")
+
+				require.NoError(t, db.CancelQuery(ctx, handle), "it should be able to cancel the query")
+				require.Eventually(t, func() bool {
+					status, err := db.QueryStatus(ctx, handle)
+					require.NoError(t, err, "it should be able to get the status of the query")
+					require.NotEqual(t, sqlconnect.QueryStateSucceeded, status.State, "it should not complete the query")
+					return status.State == sqlconnect.QueryStateCanceled
+				}, 2*time.Minute, time.Second, "it should report the query as canceled eventually")
+
+				_, err = db.FetchResults(ctx, handle)
+				require.Error(t, err, "it should not be able to fetch the results of a canceled query")
+			})
+		})
+
 		t.Run("truncate table", func(t *testing.T) {
 			t.Run("with context cancelled", func(t *testing.T) {
 				err := db.TruncateTable(cancelledCtx, table)
@@ -858,6 +1292,85 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			require.Equal(t, 0, count, "it should return 0 for a table with no rows")
 		})
 
+		t.Run("insert rows", func(t *testing.T) {
+			table := sqlconnect.NewRelationRef(formatfn("test_table_inserts"), sqlconnect.WithSchema(schema.Name))
+			err := db.CreateTestTable(ctx, table)
+			require.NoError(t, err, "it should be able to create a test table")
+			columns := []string{formatfn("c1"), formatfn("c2")}
+			rows := [][]any{{1, "1"}, {2, "2"}, {3, "3"}}
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				err := db.InsertRows(cancelledCtx, table, columns, slices.Values(rows))
+				require.Error(t, err, "it should not be able to insert rows with a cancelled context")
+			})
+
+			t.Run("with invalid rows", func(t *testing.T) {
+				err := db.InsertRows(ctx, table, columns, slices.Values([][]any{{1}}))
+				require.Error(t, err, "it should not be able to insert rows with a wrong number of values")
+			})
+
+			err = db.InsertRows(ctx, table, columns, slices.Values(rows), sqlconnect.WithBatchSize(2))
+			require.NoError(t, err, "it should be able to insert rows")
+			count, err := db.CountTableRows(ctx, table)
+			require.NoError(t, err, "it should be able to count table rows")
+			require.Equal(t, len(rows), count, "it should return the number of rows inserted")
+		})
+
+		t.Run("merge table", func(t *testing.T) {
+			target := sqlconnect.NewRelationRef(formatfn("test_table_merge_target"), sqlconnect.WithSchema(schema.Name))
+			source := sqlconnect.NewRelationRef(formatfn("test_table_merge_source"), sqlconnect.WithSchema(schema.Name))
+			for _, table := range []sqlconnect.RelationRef{target, source} {
+				err := db.CreateTestTable(ctx, table)
+				require.NoError(t, err, "it should be able to create a test table")
+			}
+			if opts.AddUniqueKey != nil {
+				_, err := db.ExecContext(ctx, opts.AddUniqueKey(db.QuoteTable(target), "c1"))
+				require.NoError(t, err, "it should be able to add a unique key to the target table")
+			}
+			_, err := db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (c1, c2) VALUES (1, '1'), (2, '2')", db.QuoteTable(target)))
+			require.NoError(t, err, "it should be able to insert rows into the target table")
+			_, err = db.ExecContext(ctx, fmt.Sprintf("INSERT INTO %s (c1, c2) VALUES (2, 'two'), (3, 'three')", db.QuoteTable(source)))
+			require.NoError(t, err, "it should be able to insert rows into the source table")
+			keyColumns := []string{formatfn("c1")}
+			updateColumns := []string{formatfn("c2")}
+
+			t.Run("with context cancelled", func(t *testing.T) {
+				err := db.MergeTable(cancelledCtx, target, source, keyColumns, updateColumns)
+				require.Error(t, err, "it should not be able to merge tables with a cancelled context")
+			})
+
+			t.Run("without key columns", func(t *testing.T) {
+				err := db.MergeTable(ctx, target, source, nil, updateColumns)
+				require.Error(t, err, "it should not be able to merge tables without key columns")
+			})
+
+			t.Run("with update columns", func(t *testing.T) {
+				err := db.MergeTable(ctx, target, source, keyColumns, updateColumns)
+				require.NoError(t, err, "it should be able to merge tables")
+				count, err := db.CountTableRows(ctx, target)
+				require.NoError(t, err, "it should be able to count table rows")
+				require.Equal(t, 3, count, "it should insert the rows that didn't match")
+				var c2 string
+				err = db.QueryRowContext(ctx, fmt.Sprintf("SELECT c2 FROM %s WHERE c1 = 2", db.QuoteTable(target))).Scan(&c2)
+				require.NoError(t, err, "it should be able to query the merged row")
+				require.Equal(t, "two", c2, "it should update the rows that matched")
+			})
+
+			t.Run("without update columns", func(t *testing.T) {
+				_, err := db.ExecContext(ctx, fmt.Sprintf("UPDATE %s SET c2 = 'updated' WHERE c1 > 0", db.QuoteTable(source)))
+				require.NoError(t, err, "it should be able to update the source table")
+				err = db.MergeTable(ctx, target, source, keyColumns, nil)
+				require.NoError(t, err, "it should be able to merge tables")
+				count, err := db.CountTableRows(ctx, target)
+				require.NoError(t, err, "it should be able to count table rows")
+				require.Equal(t, 3, count, "it should not insert rows that matched")
+				var c2 string
+				err = db.QueryRowContext(ctx, fmt.Sprintf("SELECT c2 FROM %s WHERE c1 = 3", db.QuoteTable(target))).Scan(&c2)
+				require.NoError(t, err, "it should be able to query the merged row")
+				require.Equal(t, "three", c2, "it should leave the rows that matched untouched")
+			})
+		})
+
 		t.Run("rename table", func(t *testing.T) {
 			table := sqlconnect.NewRelationRef(formatfn("test_table_torename"), sqlconnect.WithSchema(schema.Name))
 			err := db.CreateTestTable(ctx, table)
@@ -999,6 +1512,10 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 				require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 				col.RawType = ""
+				if col.TypeTree != nil {
+					require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+					col.TypeTree = nil
+				}
 				return col
 			})
 			require.ElementsMatch(t, actualCols, expectedCols, "it should return the correct columns")
@@ -1009,6 +1526,10 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 			actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 				require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 				col.RawType = ""
+				if col.TypeTree != nil {
+					require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+					col.TypeTree = nil
+				}
 				return col
 			})
 			require.NoError(t, err, "it should be able to list columns")
@@ -1100,6 +1621,10 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 					actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 						require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 						col.RawType = ""
+						if col.TypeTree != nil {
+							require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+							col.TypeTree = nil
+						}
 						return col
 					})
 					require.ElementsMatch(t, actualCols, expectedCols, "it should return the correct columns")
@@ -1112,6 +1637,10 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 					actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 						require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 						col.RawType = ""
+						if col.TypeTree != nil {
+							require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+							col.TypeTree = nil
+						}
 						return col
 					})
 					require.ElementsMatch(t, actualCols, expectedCols, "it should return the correct columns")
@@ -1133,6 +1662,10 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 				actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 					require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 					col.RawType = ""
+					if col.TypeTree != nil {
+						require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+						col.TypeTree = nil
+					}
 					return col
 				})
 				require.ElementsMatch(t, actualCols, expectedCols, "it should return the correct columns")
@@ -1194,6 +1727,66 @@ func TestDatabaseScenarios(t *testing.T, warehouse string, configJSON json.RawMe
 				wg.Wait()
 				require.Equal(t, 0, iterations, "it shouldn't iterate after leaving the channel")
 			})
+
+			t.Run("QueryJSONMapSeq without error", func(t *testing.T) {
+				var rows int
+				for row, err := range sqlconnect.QueryJSONMapSeq(ctx, db, selectSQL) {
+					require.NoError(t, err, "it should be able to scan a row")
+					require.NotEmpty(t, row)
+					rows++
+				}
+				require.Positive(t, rows, "it should return the rows")
+			})
+
+			t.Run("QueryJSONMapSeq with break", func(t *testing.T) {
+				for _, err := range sqlconnect.QueryJSONMapSeq(ctx, db, selectSQL) {
+					require.NoError(t, err, "it should be able to scan a row")
+					break
+				}
+				count, err := db.CountTableRows(ctx, table)
+				require.NoError(t, err, "it should be able to query after breaking out of the loop")
+				require.Positive(t, count)
+			})
+
+			t.Run("QueryJSONMapSeq with context cancelled", func(t *testing.T) {
+				var iterations int
+				for _, err := range sqlconnect.QueryJSONMapSeq(cancelledCtx, db, selectSQL) {
+					iterations++
+					require.ErrorIs(t, err, context.Canceled)
+				}
+				require.Equal(t, 1, iterations, "it should only iterate once")
+			})
+
+			t.Run("QueryBatchesAsync without error", func(t *testing.T) {
+				var expected int
+				rowsCh, leaveRows := sqlconnect.QueryJSONMapAsync(ctx, db, selectSQL)
+				defer leaveRows()
+				for row := range rowsCh {
+					require.NoError(t, row.Err)
+					expected++
+				}
+				ch, leave := sqlconnect.QueryBatchesAsync(ctx, db, db.JSONRowMapper(), 2, selectSQL, nil, sqlconnect.WithMaxLatency(time.Second), sqlconnect.WithBufferSize(2))
+				defer leave()
+				var rows int
+				for batch := range ch {
+					require.NoError(t, batch.Err, "it should be able to scan the rows")
+					require.NotEmpty(t, batch.Value, "it should not send empty batches")
+					require.LessOrEqual(t, len(batch.Value), 2, "it should not exceed the batch size")
+					rows += len(batch.Value)
+				}
+				require.Equal(t, expected, rows, "it should return all rows")
+			})
+
+			t.Run("QueryBatchesAsync with context cancelled", func(t *testing.T) {
+				ch, leave := sqlconnect.QueryBatchesAsync(cancelledCtx, db, db.JSONRowMapper(), 2, selectSQL, nil)
+				defer leave()
+				var iterations int
+				for batch := range ch {
+					iterations++
+					require.ErrorIs(t, batch.Err, context.Canceled)
+				}
+				require.Equal(t, 1, iterations, "it should only iterate once")
+			})
 		})
 	})
 
@@ -2,7 +2,10 @@ package integrationtest
 
 import (
 	"context"
+	"crypto/ed25519"
+	cryptorand "crypto/rand"
 	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net"
@@ -14,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/tidwall/sjson"
 	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 
 	kithelper "github.com/rudderlabs/rudder-go-kit/testhelper"
 	tunnelhelper "github.com/rudderlabs/sql-tunnels/tunnel/testhelper"
@@ -56,14 +60,163 @@ func TestSshTunnelScenarios(t *testing.T, warehouse string, configJSON json.RawM
 		require.NoError(t, err, "it should be able to list schemas")
 		require.GreaterOrEqual(t, server.connections, 1, "ssh server should have received at least 1 connection")
 	})
+
+	t.Run("ssh tunnel through jump hosts", func(t *testing.T) {
+		// the tunnel connects to the first jump host, through it to the second one and through that to the ssh server
+		jumpServers := make([]*testsshserver, 2)
+		jumpHosts := make([]sshtunnel.JumpHost, 2)
+		for i := range jumpServers {
+			port, err := kithelper.GetFreePort()
+			require.NoError(t, err, "it should be able to get a free port")
+			var privateKey []byte
+			jumpServers[i], privateKey = newSshServer(t, port)
+			t.Cleanup(func() { _ = jumpServers[i].Close() })
+			jumpHosts[i] = sshtunnel.JumpHost{
+				User:       "jump",
+				Host:       "127.0.0.1",
+				Port:       strconv.Itoa(port),
+				PrivateKey: string(privateKey),
+			}
+		}
+		tunnelConfig := tunnelConfig
+		tunnelConfig.JumpHosts = jumpHosts
+		configJSON, err := sjson.SetBytes(configJSON, "tunnel_info", tunnelConfig)
+		require.NoError(t, err, "it should be able to set the tunnel info in the config")
+
+		connections := server.connections
+		db, err := sqlconnect.NewDB(warehouse, configJSON)
+		require.NoError(t, err, "it should be able to create a new DB")
+		defer func() { _ = db.Close() }()
+		err = db.Ping()
+		require.NoError(t, err, "it should be able to ping the db")
+		_, err = db.ListSchemas(context.Background())
+		require.NoError(t, err, "it should be able to list schemas")
+		for i, jumpServer := range jumpServers {
+			require.GreaterOrEqual(t, jumpServer.connections, 1, "jump host %d should have forwarded at least 1 connection", i)
+		}
+		require.Greater(t, server.connections, connections, "ssh server should have received connections through the jump hosts")
+	})
+
+	t.Run("ssh tunnel with host key verification", func(t *testing.T) {
+		hostname := knownhosts.Normalize(net.JoinHostPort(tunnelConfig.Host, tunnelConfig.Port))
+		for _, tc := range []struct {
+			name               string
+			knownHosts         string
+			hostKeyFingerprint string
+			err                bool
+		}{
+			{name: "known hosts", knownHosts: knownhosts.Line([]string{hostname}, server.hostKey)},
+			{name: "host key fingerprint", hostKeyFingerprint: ssh.FingerprintSHA256(server.hostKey)},
+			{name: "other host key", knownHosts: knownhosts.Line([]string{hostname}, otherHostKey(t)), err: true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				tunnelConfig := tunnelConfig
+				tunnelConfig.HostKeyMode = sshtunnel.HostKeyModeStrict
+				tunnelConfig.KnownHosts = tc.knownHosts
+				tunnelConfig.HostKeyFingerprint = tc.hostKeyFingerprint
+				configJSON, err := sjson.SetBytes(configJSON, "tunnel_info", tunnelConfig)
+				require.NoError(t, err, "it should be able to set the tunnel info in the config")
+
+				db, err := sqlconnect.NewDB(warehouse, configJSON)
+				if tc.err {
+					require.ErrorContains(t, err, sshtunnel.ErrHostKeyVerification.Error(), "it should not connect to an ssh server with another host key")
+					return
+				}
+				require.NoError(t, err, "it should be able to create a new DB")
+				defer func() { _ = db.Close() }()
+				err = db.Ping()
+				require.NoError(t, err, "it should be able to ping the db")
+			})
+		}
+
+		t.Run("trust on first use", func(t *testing.T) {
+			tunnelConfig := tunnelConfig
+			tunnelConfig.HostKeyMode = sshtunnel.HostKeyModeTrustOnFirstUse
+			tunnel, err := sshtunnel.NewSocks5Tunnel(tunnelConfig)
+			require.NoError(t, err, "it should trust the host key on first use")
+			require.NoError(t, tunnel.Close())
+			require.Equal(t, ssh.FingerprintSHA256(server.hostKey), tunnel.HostKeyFingerprint(), "it should return the fingerprint of the host key")
+
+			tunnelConfig.HostKeyFingerprint = tunnel.HostKeyFingerprint()
+			tunnel, err = sshtunnel.NewSocks5Tunnel(tunnelConfig)
+			require.NoError(t, err, "it should accept the pinned host key")
+			require.NoError(t, tunnel.Close())
+
+			tunnelConfig.HostKeyFingerprint = ssh.FingerprintSHA256(otherHostKey(t))
+			_, err = sshtunnel.NewSocks5Tunnel(tunnelConfig)
+			require.ErrorIs(t, err, sshtunnel.ErrHostKeyVerification, "it should reject other host keys once pinned")
+		})
+	})
+
+	t.Run("ssh tunnel with other authentication methods", func(t *testing.T) {
+		rawPrivateKey, err := ssh.ParseRawPrivateKey(privateKey)
+		require.NoError(t, err)
+		encryptedPrivateKey, err := ssh.MarshalPrivateKeyWithPassphrase(rawPrivateKey, "", []byte("passphrase"))
+		require.NoError(t, err)
+
+		// a key unknown to the server, which is only accepted with a certificate signed by the server's user CA
+		_, certPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+		require.NoError(t, err)
+		certSigner, err := ssh.NewSignerFromKey(certPrivateKey)
+		require.NoError(t, err)
+		cert := &ssh.Certificate{Key: certSigner.PublicKey(), CertType: ssh.UserCert, ValidPrincipals: []string{tunnelConfig.User}, ValidBefore: ssh.CertTimeInfinity}
+		require.NoError(t, cert.SignCert(cryptorand.Reader, server.userCA))
+		certPEM, err := ssh.MarshalPrivateKey(certPrivateKey, "")
+		require.NoError(t, err)
+
+		for _, tc := range []struct {
+			name   string
+			config func(c *sshtunnel.Config)
+		}{
+			{name: "password", config: func(c *sshtunnel.Config) {
+				c.PrivateKey = ""
+				c.Password = testSshPassword
+			}},
+			{name: "encrypted private key", config: func(c *sshtunnel.Config) {
+				c.PrivateKey = string(pem.EncodeToMemory(encryptedPrivateKey))
+				c.PrivateKeyPassphrase = "passphrase"
+			}},
+			{name: "certificate", config: func(c *sshtunnel.Config) {
+				c.PrivateKey = string(pem.EncodeToMemory(certPEM))
+				c.Certificate = string(ssh.MarshalAuthorizedKey(cert))
+			}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				tunnelConfig := tunnelConfig
+				tc.config(&tunnelConfig)
+				configJSON, err := sjson.SetBytes(configJSON, "tunnel_info", tunnelConfig)
+				require.NoError(t, err, "it should be able to set the tunnel info in the config")
+
+				db, err := sqlconnect.NewDB(warehouse, configJSON)
+				require.NoError(t, err, "it should be able to create a new DB")
+				defer func() { _ = db.Close() }()
+				err = db.Ping()
+				require.NoError(t, err, "it should be able to ping the db")
+			})
+		}
+	})
+}
+
+func otherHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	publicKey, _, err := ed25519.GenerateKey(cryptorand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	return key
 }
 
 type testsshserver struct {
 	*sshx.Server
 
+	hostKey     ssh.PublicKey
+	userCA      ssh.Signer // signs the user certificates accepted by the server
 	connections int
 }
 
+// testSshPassword is the password accepted by the test ssh servers
+const testSshPassword = "password"
+
 func (s *testsshserver) DirectTCPIPHandler(srv *sshx.Server, conn *ssh.ServerConn, newChan ssh.NewChannel, ctx sshx.Context) {
 	s.connections++
 	sshx.DirectTCPIPHandler(srv, conn, newChan, ctx)
@@ -77,7 +230,18 @@ func newSshServer(t *testing.T, port int) (server *testsshserver, privateKey []b
 	pkey, _, _, _, err := sshx.ParseAuthorizedKey(publicKey)
 	require.NoError(t, err)
 
+	_, hostPrivateKey, err := ed25519.GenerateKey(cryptorand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	require.NoError(t, err)
+	server.hostKey = hostSigner.PublicKey()
+	_, userCAKey, err := ed25519.GenerateKey(cryptorand.Reader)
+	require.NoError(t, err)
+	server.userCA, err = ssh.NewSignerFromKey(userCAKey)
+	require.NoError(t, err)
+
 	server.Server = &sshx.Server{
+		HostSigners: []sshx.Signer{hostSigner},
 		LocalPortForwardingCallback: sshx.LocalPortForwardingCallback(func(ctx sshx.Context, dhost string, dport uint32) bool {
 			return true
 		}),
@@ -90,8 +254,14 @@ func newSshServer(t *testing.T, port int) (server *testsshserver, privateKey []b
 			return true
 		}),
 		PublicKeyHandler: func(ctx sshx.Context, key sshx.PublicKey) bool {
+			if cert, ok := key.(*ssh.Certificate); ok {
+				return sshx.KeysEqual(cert.SignatureKey, server.userCA.PublicKey()) && new(ssh.CertChecker).CheckCert(ctx.User(), cert) == nil
+			}
 			return sshx.KeysEqual(key, pkey)
 		},
+		PasswordHandler: func(ctx sshx.Context, password string) bool {
+			return password == testSshPassword
+		},
 		ChannelHandlers: map[string]sshx.ChannelHandler{
 			"direct-tcpip": server.DirectTCPIPHandler,
 			"session":      sshx.DefaultSessionHandler,
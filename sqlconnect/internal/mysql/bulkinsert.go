@@ -0,0 +1,101 @@
+package mysql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+const (
+	mysqlErrNotAllowedCommand      = 1148 // the used command is not allowed with this MySQL version
+	mysqlErrClientLocalFilesDenied = 3948 // loading local data is disabled on the client or the server
+)
+
+// InsertRows inserts rows into the given table using [LOAD DATA LOCAL INFILE] with a registered reader.
+// If loading local data is disabled by the server, batches are inserted using multi-row VALUES statements instead.
+func (db *DB) InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], opts ...sqlconnect.Option) error {
+	return base.InsertRows(ctx, relation, columns, rows, db.loadData, opts...)
+}
+
+// loadData loads a batch of rows through a reader handler registered for the duration of the statement
+func (db *DB) loadData(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error {
+	data, err := encodeLoadDataRows(batch)
+	if err != nil {
+		return err
+	}
+	readerName := "sqlconnect-" + uuid.New().String()
+	mysqldriver.RegisterReaderHandler(readerName, func() io.Reader { return bytes.NewReader(data) })
+	defer mysqldriver.DeregisterReaderHandler(readerName)
+
+	stmt := fmt.Sprintf(`LOAD DATA LOCAL INFILE 'Reader::%[1]s' INTO TABLE %[2]s CHARACTER SET utf8mb4 FIELDS TERMINATED BY '\t' ESCAPED BY '\\' LINES TERMINATED BY '\n' (%[3]s)`,
+		readerName,
+		db.QuoteTable(relation),
+		strings.Join(lo.Map(columns, func(col string, _ int) string { return db.QuoteIdentifier(col) }), ", "),
+	)
+	if _, err := db.ExecContext(ctx, stmt); err != nil {
+		if isLocalInfileDisabledError(err) {
+			return db.InsertValues(ctx, relation, columns, batch)
+		}
+		return err
+	}
+	return nil
+}
+
+func isLocalInfileDisabledError(err error) bool {
+	if mysqlErr, ok := errors.AsType[*mysqldriver.MySQLError](err); ok {
+		switch mysqlErr.Number {
+		case mysqlErrNotAllowedCommand,
+			mysqlErrClientLocalFilesDenied:
+			return true
+		}
+	}
+	return false
+}
+
+// loadDataEscaper escapes the characters that have a special meaning in [LOAD DATA] text using the default escape character
+var loadDataEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`, "\x00", `\0`)
+
+// encodeLoadDataRows encodes rows as tab-separated lines, using [\N] for NULL values
+func encodeLoadDataRows(batch [][]any) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, row := range batch {
+		for i, value := range row {
+			if i > 0 {
+				buf.WriteByte('\t')
+			}
+			switch v := value.(type) {
+			case nil:
+				buf.WriteString(`\N`)
+			case string:
+				buf.WriteString(loadDataEscaper.Replace(v))
+			case []byte:
+				buf.WriteString(loadDataEscaper.Replace(string(v)))
+			case json.RawMessage:
+				buf.WriteString(loadDataEscaper.Replace(string(v)))
+			case bool:
+				buf.WriteString(lo.Ternary(v, "1", "0"))
+			case time.Time:
+				buf.WriteString(v.UTC().Format("2006-01-02 15:04:05.999999"))
+			case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+				fmt.Fprintf(&buf, "%v", v)
+			default:
+				return nil, fmt.Errorf("unsupported value type %T", value)
+			}
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,25 @@
+package mysql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeLoadDataRows(t *testing.T) {
+	t.Run("escapes special characters and nulls", func(t *testing.T) {
+		data, err := encodeLoadDataRows([][]any{
+			{1, "tab\there", nil},
+			{2.5, "new\nline\\", true},
+			{int64(3), []byte("bytes"), time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)},
+		})
+		require.NoError(t, err)
+		require.Equal(t, "1\ttab\\there\t\\N\n2.5\tnew\\nline\\\\\t1\n3\tbytes\t2021-01-01 10:00:00\n", string(data))
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := encodeLoadDataRows([][]any{{struct{}{}}})
+		require.ErrorContains(t, err, "unsupported value type")
+	})
+}
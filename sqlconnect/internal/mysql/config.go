@@ -1,6 +1,7 @@
 package mysql
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -63,6 +64,11 @@ func (c Config) TLS() (string, error) {
 }
 
 func (c *Config) Parse(input json.RawMessage) error {
+	return c.ParseContext(context.Background(), input)
+}
+
+// ParseContext parses the given JSON into the config, aborting the validation of its hosts if the context is done before it completes
+func (c *Config) ParseContext(ctx context.Context, input json.RawMessage) error {
 	err := json.Unmarshal(input, c)
 	if err != nil {
 		return err
@@ -76,8 +82,8 @@ func (c *Config) Parse(input json.RawMessage) error {
 	// permits loopback, which is all a container-backed test needs —
 	// link-local, private and unspecified addresses stay rejected whether or
 	// not it is set.
-	if err := util.ValidateHost(c.Host, util.AllowLoopback(c.SkipHostValidation)); err != nil {
+	if err := util.ValidateHostContext(ctx, c.Host, util.AllowLoopback(c.SkipHostValidation)); err != nil {
 		return err
 	}
-	return sshtunnel.ValidateHost(c.TunnelInfo, util.AllowLoopback(c.SkipHostValidation))
+	return sshtunnel.ValidateHostContext(ctx, c.TunnelInfo, util.AllowLoopback(c.SkipHostValidation))
 }
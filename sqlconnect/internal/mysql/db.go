@@ -1,11 +1,15 @@
 package mysql
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
-	_ "github.com/go-sql-driver/mysql" // mysql driver
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/samber/lo"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
@@ -18,15 +22,21 @@ const (
 
 // NewDB creates a new mysql db client
 func NewDB(configJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), configJSON)
+}
+
+// NewDBContext creates a new mysql db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, configJSON json.RawMessage) (*DB, error) {
 	var config Config
-	err := config.Parse(configJSON)
+	err := config.ParseContext(ctx, configJSON)
 	if err != nil {
 		return nil, err
 	}
 
+	var tunnel sshtunnel.Tunnel
 	tunnelCloser := sshtunnel.NoTunnelCloser
 	if config.TunnelInfo != nil {
-		tunnel, err := sshtunnel.NewTcpTunnel(*config.TunnelInfo, config.Host, config.Port)
+		tunnel, err = sshtunnel.NewTcpTunnelContext(ctx, *config.TunnelInfo, config.Host, config.Port)
 		if err != nil {
 			return nil, err
 		}
@@ -40,17 +50,26 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	db, err := sql.Open(DatabaseType, connectionString)
+	driverConfig, err := mysqldriver.ParseDSN(connectionString)
+	if err != nil {
+		return nil, err
+	}
+	connector, err := mysqldriver.NewConnector(driverConfig)
 	if err != nil {
 		return nil, err
 	}
+	db := sql.OpenDB(killQueryConnector{Connector: connector})
 
 	return &DB{
 		DB: base.NewDB(
 			db,
 			tunnelCloser,
+			base.WithSSHTunnel(tunnel),
 			base.WithColumnTypeMapper(getColumnTypeMapper(config)),
 			base.WithJsonRowMapper(getJonRowMapper(config)),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("mysql", config.DBName),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.CurrentCatalog = func() string {
 					return "SELECT DATABASE()"
@@ -61,6 +80,29 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 				cmds.RenameTable = func(schema, oldName, newName base.QuotedIdentifier) string {
 					return fmt.Sprintf("RENAME TABLE %[1]s.%[2]s TO %[1]s.%[3]s", schema, oldName, newName)
 				}
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					stmt := fmt.Sprintf("SELECT column_name, data_type, ordinal_position, is_nullable, numeric_precision, numeric_scale, character_maximum_length, column_default, column_comment, column_key = 'PRI' AS is_primary_key FROM information_schema.columns WHERE table_schema = '%[1]s' AND table_name = '%[2]s'", base.EscapeSqlString(schema), base.EscapeSqlString(table))
+					if catalog != "" {
+						stmt += fmt.Sprintf(" AND table_catalog = '%[1]s'", base.EscapeSqlString(catalog))
+					}
+					return stmt + " ORDER BY ordinal_position ASC", base.InformationSchemaColumnDetails
+				}
+				cmds.ExplainQuery = func(sql string) (string, func(string) (sqlconnect.QueryEstimate, error)) {
+					return "EXPLAIN FORMAT=JSON " + base.TrimQuery(sql), parseQueryPlan
+				}
+				cmds.Merge = func(target, source base.QuotedIdentifier, keyColumns, updateColumns []base.QuotedIdentifier) []string {
+					columns := slices.Concat(keyColumns, updateColumns)
+					updates := []base.QuotedIdentifier{keyColumns[0]} // a no-op update for ignoring duplicate keys
+					if len(updateColumns) > 0 {
+						updates = updateColumns
+					}
+					set := strings.Join(lo.Map(updates, func(col base.QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("%[1]s = s.%[1]s", col)
+					}), ", ")
+					return []string{
+						fmt.Sprintf("INSERT INTO %[1]s (%[3]s) SELECT %[4]s FROM %[2]s s ON DUPLICATE KEY UPDATE %[5]s", target, source, base.JoinQuotedIdentifiers(columns, ""), base.JoinQuotedIdentifiers(columns, "s"), set),
+					}
+				}
 				return cmds
 			}),
 			base.WithDialect(newDialect()),
@@ -69,8 +111,8 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -78,6 +120,11 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 func getColumnTypeMapper(config Config) func(base.ColumnType) string {
 	if config.UseLegacyMappings {
 		return columnTypeMapper(nil)
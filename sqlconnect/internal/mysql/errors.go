@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"errors"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+const (
+	mysqlErrTooManyConnections  = 1040 // too many connections
+	mysqlErrDBAccessDenied      = 1044 // access denied to a database
+	mysqlErrAccessDenied        = 1045 // invalid user or password
+	mysqlErrBadDB               = 1049 // unknown database
+	mysqlErrServerShutdown      = 1053 // server shutdown in progress
+	mysqlErrDupFieldName        = 1060 // duplicate column name
+	mysqlErrParse               = 1064 // syntax error
+	mysqlErrTableAccessDenied   = 1142 // command denied to a table
+	mysqlErrColumnAccessDenied  = 1143 // command denied to a column
+	mysqlErrNoSuchTable         = 1146 // table doesn't exist
+	mysqlErrTooManyUserConnects = 1203 // user has too many active connections
+	mysqlErrLockWaitTimeout     = 1205 // lock wait timeout exceeded
+	mysqlErrLockDeadlock        = 1213 // deadlock found when trying to get a lock
+	mysqlErrUserLimitReached    = 1226 // user has exceeded a resource limit
+	mysqlErrSpecificAccess      = 1227 // privilege required for the operation
+	mysqlErrQueryTimeout        = 3024 // maximum statement execution time exceeded
+)
+
+// classifyError classifies mysql errors using their error numbers
+func classifyError(err error) []error {
+	if errors.Is(err, mysqldriver.ErrInvalidConn) {
+		return []error{sqlconnect.ErrRetryable}
+	}
+	mysqlErr, ok := errors.AsType[*mysqldriver.MySQLError](err)
+	if !ok {
+		return nil
+	}
+	switch mysqlErr.Number {
+	case mysqlErrAccessDenied:
+		return []error{sqlconnect.ErrAuthentication}
+	case mysqlErrDBAccessDenied, mysqlErrTableAccessDenied, mysqlErrColumnAccessDenied, mysqlErrSpecificAccess:
+		return []error{sqlconnect.ErrPermissionDenied}
+	case mysqlErrNoSuchTable:
+		return []error{sqlconnect.ErrRelationNotFound}
+	case mysqlErrBadDB:
+		return []error{sqlconnect.ErrSchemaNotFound}
+	case mysqlErrParse:
+		return []error{sqlconnect.ErrSyntax}
+	case mysqlErrQueryTimeout:
+		return []error{sqlconnect.ErrQueryTimeout}
+	case mysqlErrLockWaitTimeout, mysqlErrLockDeadlock, mysqlErrServerShutdown:
+		return []error{sqlconnect.ErrRetryable}
+	case mysqlErrTooManyConnections, mysqlErrTooManyUserConnects, mysqlErrUserLimitReached:
+		return []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "no such table", err: &mysqldriver.MySQLError{Number: 1146, Message: "Table 'db.t' doesn't exist"}, expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "unknown database", err: &mysqldriver.MySQLError{Number: 1049, Message: "Unknown database 'db'"}, expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "parse error", err: &mysqldriver.MySQLError{Number: 1064}, expected: []error{sqlconnect.ErrSyntax}},
+		{name: "access denied", err: &mysqldriver.MySQLError{Number: 1045}, expected: []error{sqlconnect.ErrAuthentication}},
+		{name: "table access denied", err: &mysqldriver.MySQLError{Number: 1142}, expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "query timeout", err: &mysqldriver.MySQLError{Number: 3024}, expected: []error{sqlconnect.ErrQueryTimeout}},
+		{name: "deadlock", err: &mysqldriver.MySQLError{Number: 1213}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "too many connections", err: &mysqldriver.MySQLError{Number: 1040}, expected: []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}},
+		{name: "invalid connection", err: fmt.Errorf("querying: %w", mysqldriver.ErrInvalidConn), expected: []error{sqlconnect.ErrRetryable}},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package mysql
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// parseQueryPlan extracts an estimate out of a plan returned by EXPLAIN FORMAT=JSON.
+// Rows are estimated using the rows produced by the last table of the outermost query block, whereas bytes and partitions are summed across all tables accessed by the query.
+func parseQueryPlan(plan string) (sqlconnect.QueryEstimate, error) {
+	var root map[string]any
+	if err := json.Unmarshal([]byte(plan), &root); err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("unmarshalling plan: %w", err)
+	}
+	queryBlock, ok := root["query_block"].(map[string]any)
+	if !ok {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("no query_block found in plan")
+	}
+	var estimate sqlconnect.QueryEstimate
+	if costInfo, ok := queryBlock["cost_info"].(map[string]any); ok {
+		if cost, err := strconv.ParseFloat(fmt.Sprint(costInfo["query_cost"]), 64); err == nil {
+			estimate.Cost = &cost
+		}
+	}
+	if rows, ok := producedRows(queryBlock); ok {
+		estimate.Rows = &rows
+	}
+	var bytes, partitions int64
+	var hasBytes, hasPartitions bool
+	walkPlanTables(root, func(table map[string]any) {
+		if costInfo, ok := table["cost_info"].(map[string]any); ok {
+			if size, err := base.ParseByteSize(fmt.Sprint(costInfo["data_read_per_join"])); err == nil {
+				bytes += size
+				hasBytes = true
+			}
+		}
+		if tablePartitions, ok := table["partitions"].([]any); ok {
+			partitions += int64(len(tablePartitions))
+			hasPartitions = true
+		}
+	})
+	if hasBytes {
+		estimate.Bytes = &bytes
+	}
+	if hasPartitions {
+		estimate.Partitions = &partitions
+	}
+	return estimate, nil
+}
+
+// producedRows returns the rows produced by the last table of a query block, looking into the operations wrapping its tables, e.g. ordering or grouping
+func producedRows(node map[string]any) (int64, bool) {
+	if table, ok := node["table"].(map[string]any); ok {
+		for _, key := range []string{"rows_produced_per_join", "rows"} { // mariadb only reports rows
+			if rows, ok := table[key].(float64); ok {
+				return int64(rows), true
+			}
+		}
+		return 0, false
+	}
+	if nestedLoop, ok := node["nested_loop"].([]any); ok && len(nestedLoop) > 0 {
+		if last, ok := nestedLoop[len(nestedLoop)-1].(map[string]any); ok {
+			return producedRows(last)
+		}
+		return 0, false
+	}
+	for _, key := range []string{"ordering_operation", "grouping_operation", "duplicates_removal", "windowing", "filesort", "temporary_table"} {
+		if operation, ok := node[key].(map[string]any); ok {
+			return producedRows(operation)
+		}
+	}
+	return 0, false
+}
+
+// walkPlanTables calls fn for every table accessed by the plan, including tables of subqueries
+func walkPlanTables(node any, fn func(table map[string]any)) {
+	switch v := node.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if table, ok := value.(map[string]any); ok && key == "table" {
+				fn(table)
+			}
+			walkPlanTables(value, fn)
+		}
+	case []any:
+		for _, value := range v {
+			walkPlanTables(value, fn)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package mysql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Run("single table", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`{
+			"query_block": {
+				"select_id": 1,
+				"cost_info": {"query_cost": "0.75"},
+				"table": {
+					"table_name": "t",
+					"partitions": ["p0", "p1"],
+					"rows_examined_per_scan": 5,
+					"rows_produced_per_join": 5,
+					"cost_info": {"read_cost": "0.25", "eval_cost": "0.50", "prefix_cost": "0.75", "data_read_per_join": "1K"}
+				}
+			}
+		}`)
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 5, *estimate.Rows)
+		require.NotNil(t, estimate.Bytes)
+		require.EqualValues(t, 1024, *estimate.Bytes)
+		require.NotNil(t, estimate.Partitions)
+		require.EqualValues(t, 2, *estimate.Partitions)
+		require.NotNil(t, estimate.Cost)
+		require.InDelta(t, 0.75, *estimate.Cost, 0.001)
+	})
+
+	t.Run("ordered join", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`{
+			"query_block": {
+				"cost_info": {"query_cost": "12.50"},
+				"ordering_operation": {
+					"nested_loop": [
+						{"table": {"table_name": "a", "rows_produced_per_join": 10, "cost_info": {"data_read_per_join": "160"}}},
+						{"table": {"table_name": "b", "rows_produced_per_join": 3, "cost_info": {"data_read_per_join": "48"}}}
+					]
+				}
+			}
+		}`)
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 3, *estimate.Rows)
+		require.NotNil(t, estimate.Bytes)
+		require.EqualValues(t, 208, *estimate.Bytes)
+		require.Nil(t, estimate.Partitions)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := parseQueryPlan(`-> Table scan on t`)
+		require.Error(t, err)
+
+		_, err = parseQueryPlan(`{}`)
+		require.ErrorContains(t, err, "no query_block found in plan")
+	})
+}
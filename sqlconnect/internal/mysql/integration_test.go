@@ -2,6 +2,7 @@ package mysql_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -42,6 +43,15 @@ func TestMysqlDB(t *testing.T) {
 		strings.ToLower,
 		integrationtest.Options{
 			LegacySupport: true,
+			AddUniqueKey: func(table, column string) string {
+				return fmt.Sprintf("ALTER TABLE %s ADD UNIQUE (%s)", table, column)
+			},
+			LongRunningQuery: func(marker string) string {
+				return fmt.Sprintf("SELECT '%s', SLEEP(120)", marker)
+			},
+			RunningQueries: func(marker string) string {
+				return fmt.Sprintf("SELECT COUNT(*) FROM information_schema.processlist WHERE info LIKE '%%%s%%' AND id <> CONNECTION_ID()", marker)
+			},
 		},
 	)
 
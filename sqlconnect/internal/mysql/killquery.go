@@ -0,0 +1,214 @@
+package mysql
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// killQueryConnector creates connections which kill their running queries in the server as soon as the context of a query is done.
+// The driver only closes its connection in that case, leaving the query running in the server until it tries to send its results.
+type killQueryConnector struct {
+	driver.Connector
+}
+
+// Connect creates a connection, getting its id for being able to kill its queries
+func (c killQueryConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mc, ok := conn.(mysqlConn)
+	if !ok {
+		return conn, nil
+	}
+	id, err := connectionID(ctx, mc)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("getting connection id: %w", err)
+	}
+	return &killQueryConn{mysqlConn: mc, id: id, connector: c.Connector}, nil
+}
+
+// mysqlConn is the set of interfaces implemented by the driver's connections
+type mysqlConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+	driver.NamedValueChecker
+}
+
+// mysqlStmt is the set of interfaces implemented by the driver's prepared statements
+type mysqlStmt interface {
+	driver.Stmt
+	driver.StmtExecContext
+	driver.StmtQueryContext
+	driver.NamedValueChecker
+}
+
+// mysqlRows is the set of interfaces implemented by the driver's rows
+type mysqlRows interface {
+	driver.Rows
+	driver.RowsNextResultSet
+	driver.RowsColumnTypeDatabaseTypeName
+	driver.RowsColumnTypeNullable
+	driver.RowsColumnTypePrecisionScale
+	driver.RowsColumnTypeScanType
+}
+
+type killQueryConn struct {
+	mysqlConn
+	id        uint64
+	connector driver.Connector
+	killed    atomic.Bool // whether a query of the connection has been killed, thus the connection must not be reused
+}
+
+func (c *killQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	stop := c.watchQuery(ctx)
+	defer stop()
+	return c.mysqlConn.ExecContext(ctx, query, args)
+}
+
+func (c *killQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	stop := c.watchQuery(ctx)
+	rows, err := c.mysqlConn.QueryContext(ctx, query, args)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	return newKillQueryRows(rows, stop), nil
+}
+
+func (c *killQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.mysqlConn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if s, ok := stmt.(mysqlStmt); ok {
+		return &killQueryStmt{mysqlStmt: s, conn: c}, nil
+	}
+	return stmt, nil
+}
+
+// ResetSession discards the connection if one of its queries has been killed
+func (c *killQueryConn) ResetSession(ctx context.Context) error {
+	if c.killed.Load() {
+		return driver.ErrBadConn
+	}
+	return c.mysqlConn.ResetSession(ctx)
+}
+
+// IsValid reports the connection as invalid if one of its queries has been killed
+func (c *killQueryConn) IsValid() bool {
+	return !c.killed.Load() && c.mysqlConn.IsValid()
+}
+
+// watchQuery kills the running query of the connection as soon as the context is done, returning a function for stopping watching the context.
+// If the query is being killed, the returned function waits for the kill to complete and marks the connection as bad,
+// so that a late KILL QUERY cannot kill the next query of the connection.
+func (c *killQueryConn) watchQuery(ctx context.Context) func() {
+	done := make(chan struct{})
+	stop := context.AfterFunc(ctx, func() {
+		defer close(done)
+		c.killQuery()
+	})
+	return sync.OnceFunc(func() {
+		if !stop() {
+			<-done
+			c.killed.Store(true)
+		}
+	})
+}
+
+// killQuery kills the running query of the connection using a new connection, since the connection itself is busy running it
+func (c *killQueryConn) killQuery() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, _ = execer.ExecContext(ctx, "KILL QUERY "+strconv.FormatUint(c.id, 10), nil)
+	}
+}
+
+type killQueryStmt struct {
+	mysqlStmt
+	conn *killQueryConn
+}
+
+func (s *killQueryStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	stop := s.conn.watchQuery(ctx)
+	defer stop()
+	return s.mysqlStmt.ExecContext(ctx, args)
+}
+
+func (s *killQueryStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	stop := s.conn.watchQuery(ctx)
+	rows, err := s.mysqlStmt.QueryContext(ctx, args)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+	return newKillQueryRows(rows, stop), nil
+}
+
+// newKillQueryRows returns rows which keep killing the query if the context is done until they are closed, since the server runs the query while sending its rows
+func newKillQueryRows(rows driver.Rows, stop func()) driver.Rows {
+	r, ok := rows.(mysqlRows)
+	if !ok {
+		stop()
+		return rows
+	}
+	return &killQueryRows{mysqlRows: r, stop: stop}
+}
+
+type killQueryRows struct {
+	mysqlRows
+	stop func()
+}
+
+func (r *killQueryRows) Close() error {
+	err := r.mysqlRows.Close()
+	r.stop()
+	return err
+}
+
+// connectionID returns the id of the connection, as expected by KILL QUERY
+func connectionID(ctx context.Context, conn driver.QueryerContext) (uint64, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT CONNECTION_ID()", nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+	dest := make([]driver.Value, 1)
+	if err := rows.Next(dest); err != nil {
+		if errors.Is(err, io.EOF) {
+			return 0, fmt.Errorf("no connection id returned")
+		}
+		return 0, err
+	}
+	switch id := dest[0].(type) {
+	case int64:
+		return uint64(id), nil
+	case uint64:
+		return id, nil
+	case []byte:
+		return strconv.ParseUint(string(id), 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected connection id: %v", dest[0])
+	}
+}
@@ -0,0 +1,128 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKillQueryConnector(t *testing.T) {
+	connector := &fakeConnector{}
+	db := sql.OpenDB(killQueryConnector{Connector: connector})
+	defer func() { _ = db.Close() }()
+	db.SetMaxOpenConns(1)
+
+	t.Run("normal operation", func(t *testing.T) {
+		var v int64
+		require.NoError(t, db.QueryRowContext(context.Background(), "SELECT 1").Scan(&v))
+		require.EqualValues(t, 1, v)
+		_, err := db.ExecContext(context.Background(), "SELECT 1")
+		require.NoError(t, err)
+		require.Empty(t, connector.killed(), "it should not kill queries which complete")
+	})
+
+	t.Run("with context cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := db.QueryContext(ctx, "SELECT SLEEP(60)")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Equal(t, []string{"KILL QUERY 1"}, connector.killed(), "it should kill the query using another connection before returning")
+
+		var id int64
+		require.NoError(t, db.QueryRowContext(context.Background(), "SELECT CONNECTION_ID()").Scan(&id))
+		require.EqualValues(t, 3, id, "it should discard the connection whose query has been killed")
+		require.Equal(t, []string{"KILL QUERY 1"}, connector.killed(), "it should not kill the next query")
+	})
+}
+
+// fakeConnector creates connections with increasing ids, whose "SELECT SLEEP(60)" queries run until their context is done
+type fakeConnector struct {
+	mu     sync.Mutex
+	lastID int64
+	kills  []string
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastID++
+	return &fakeConn{connector: c, id: c.lastID}, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver { return nil }
+
+func (c *fakeConnector) killed() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.kills...)
+}
+
+type fakeConn struct {
+	connector *fakeConnector
+	id        int64
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                        { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (c *fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c *fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+func (c *fakeConn) Ping(context.Context) error               { return nil }
+func (c *fakeConn) ResetSession(context.Context) error       { return nil }
+func (c *fakeConn) IsValid() bool                            { return true }
+func (c *fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	if strings.HasPrefix(query, "KILL QUERY") {
+		c.connector.mu.Lock()
+		defer c.connector.mu.Unlock()
+		c.connector.kills = append(c.connector.kills, query)
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	switch query {
+	case "SELECT CONNECTION_ID()":
+		return &fakeRows{value: c.id}, nil
+	case "SELECT SLEEP(60)":
+		<-ctx.Done()
+		return nil, ctx.Err()
+	default:
+		return &fakeRows{value: 1}, nil
+	}
+}
+
+type fakeRows struct {
+	value any
+	done  bool
+}
+
+func (r *fakeRows) Columns() []string { return []string{"value"} }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = r.value
+	return nil
+}
+func (r *fakeRows) HasNextResultSet() bool                            { return false }
+func (r *fakeRows) NextResultSet() error                              { return io.EOF }
+func (r *fakeRows) ColumnTypeDatabaseTypeName(int) string             { return "BIGINT" }
+func (r *fakeRows) ColumnTypeNullable(int) (nullable, ok bool)        { return false, true }
+func (r *fakeRows) ColumnTypePrecisionScale(int) (int64, int64, bool) { return 0, 0, false }
+func (r *fakeRows) ColumnTypeScanType(int) reflect.Type               { return reflect.TypeFor[int64]() }
@@ -47,6 +47,10 @@ var columnTypeMappings = map[string]string{
 	"JSON":            "json",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings].
+// boolean columns cannot be created, since BOOLEAN is an alias of TINYINT(1) and such columns are reported as int.
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "BIGINT", "DOUBLE", "VARCHAR(255)", "DATETIME(6)", "JSON")
+
 func columnTypeMapper(mappings map[string]string) func(base.ColumnType) string {
 	return func(c base.ColumnType) string {
 		databaseTypeName := strings.Replace(strings.ToUpper(c.DatabaseTypeName()), "UNSIGNED ", "", 1)
@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// ListColumnsForSqlQuery overrides the base implementation to report queries that can't be wrapped in a SELECT with LIMIT 0
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.DB.ListColumnsForSqlQueryUsing(ctx, sql, db.describeQuery, opts...)
+}
+
+// describeQuery describes the query using a SELECT with LIMIT 0. Unlike a query's result set, a derived table can't have duplicate column names,
+// thus such queries can only be described by executing them, if [sqlconnect.WithExecuteFallback] is provided.
+func (db *DB) describeQuery(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+	columns, err := db.DescribeQuery(ctx, sql)
+	if isDuplicateColumnError(err) {
+		return nil, fmt.Errorf("describing sql query with duplicate column names without executing it: %w", errors.Join(sqlconnect.ErrNotSupported, err))
+	}
+	return columns, err
+}
+
+// isDuplicateColumnError returns true if the error is caused by a derived table having duplicate column names
+func isDuplicateColumnError(err error) bool {
+	mysqlErr, ok := errors.AsType[*mysqldriver.MySQLError](err)
+	return ok && mysqlErr.Number == mysqlErrDupFieldName
+}
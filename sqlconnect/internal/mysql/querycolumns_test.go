@@ -0,0 +1,17 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsDuplicateColumnError(t *testing.T) {
+	require.True(t, isDuplicateColumnError(fmt.Errorf("querying list columns for sql query: %w", &mysqldriver.MySQLError{Number: 1060, Message: "Duplicate column name 'c1'"})))
+	require.False(t, isDuplicateColumnError(&mysqldriver.MySQLError{Number: 1146}))
+	require.False(t, isDuplicateColumnError(errors.New("other")))
+	require.False(t, isDuplicateColumnError(nil))
+}
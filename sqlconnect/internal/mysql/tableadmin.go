@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// AlterColumnType overrides the base implementation, since MODIFY COLUMN replaces the whole definition of a column:
+// the column's nullability, default, extra attributes and comment are carried over to its new definition
+func (db *DB) AlterColumnType(ctx context.Context, relation sqlconnect.RelationRef, column, newType string) error {
+	return db.DB.AlterColumnTypeUsing(ctx, relation, column, newType, func(ctx context.Context, table, quotedColumn base.QuotedIdentifier, _, _, ddlType string) ([]string, error) {
+		var attrs columnAttributes
+		if err := db.QueryRowContext(ctx, `SELECT is_nullable, column_default, extra, column_comment FROM information_schema.columns WHERE table_schema = ? AND table_name = ? AND column_name = ?`,
+			relation.Schema, relation.Name, column,
+		).Scan(&attrs.nullable, &attrs.defaultValue, &attrs.extra, &attrs.comment); err != nil {
+			return nil, fmt.Errorf("querying column definition: %w", err)
+		}
+		definition, err := attrs.definition(ddlType)
+		if err != nil {
+			return nil, err
+		}
+		return []string{fmt.Sprintf("ALTER TABLE %[1]s MODIFY COLUMN %[2]s %[3]s", table, quotedColumn, definition)}, nil
+	})
+}
+
+// columnAttributes are the attributes of a column's definition, as reported by information_schema.columns
+type columnAttributes struct {
+	nullable     string
+	defaultValue sql.NullString
+	extra        string
+	comment      string
+}
+
+// currentTimestampRegex matches the CURRENT_TIMESTAMP defaults of temporal columns, which are expressions that can be used without parentheses
+var currentTimestampRegex = regexp.MustCompile(`(?i)^CURRENT_TIMESTAMP(\(\d*\))?$`)
+
+// definition returns the column's definition using the given type, followed by its attributes
+func (a columnAttributes) definition(ddlType string) (string, error) {
+	extra := strings.ToLower(a.extra)
+	if strings.Contains(strings.ReplaceAll(extra, "default_generated", ""), "generated") {
+		return "", fmt.Errorf("changing the type of generated columns: %w", sqlconnect.ErrNotSupported)
+	}
+	definition := ddlType
+	if a.nullable == "NO" {
+		definition += " NOT NULL"
+	}
+	if a.defaultValue.Valid {
+		switch {
+		case currentTimestampRegex.MatchString(a.defaultValue.String):
+			definition += " DEFAULT " + a.defaultValue.String
+		case strings.Contains(extra, "default_generated"): // expression defaults are reported without their enclosing parentheses
+			definition += " DEFAULT (" + a.defaultValue.String + ")"
+		default:
+			definition += " DEFAULT '" + escapeString(a.defaultValue.String) + "'"
+		}
+	}
+	if strings.Contains(extra, "auto_increment") {
+		definition += " AUTO_INCREMENT"
+	}
+	if i := strings.Index(extra, "on update "); i >= 0 {
+		definition += " " + strings.ToUpper(a.extra[i:])
+	}
+	if a.comment != "" {
+		definition += " COMMENT '" + escapeString(a.comment) + "'"
+	}
+	return definition, nil
+}
+
+// escapeString escapes a value for embedding it in a single-quoted string literal, where backslashes are escape characters too
+func escapeString(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `''`).Replace(value)
+}
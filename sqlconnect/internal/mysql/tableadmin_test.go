@@ -0,0 +1,35 @@
+package mysql
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestColumnAttributesDefinition(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		attrs    columnAttributes
+		expected string
+	}{
+		{name: "nullable", attrs: columnAttributes{nullable: "YES"}, expected: "BIGINT"},
+		{name: "not null with literal default", attrs: columnAttributes{nullable: "NO", defaultValue: sql.NullString{String: `it's a \ default`, Valid: true}}, expected: `BIGINT NOT NULL DEFAULT 'it''s a \\ default'`},
+		{name: "expression default", attrs: columnAttributes{nullable: "YES", defaultValue: sql.NullString{String: "uuid()", Valid: true}, extra: "DEFAULT_GENERATED"}, expected: "BIGINT DEFAULT (uuid())"},
+		{name: "current timestamp", attrs: columnAttributes{nullable: "NO", defaultValue: sql.NullString{String: "CURRENT_TIMESTAMP(3)", Valid: true}, extra: "DEFAULT_GENERATED on update CURRENT_TIMESTAMP(3)"}, expected: "BIGINT NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"},
+		{name: "auto increment with comment", attrs: columnAttributes{nullable: "NO", extra: "auto_increment", comment: "the 'id'"}, expected: "BIGINT NOT NULL AUTO_INCREMENT COMMENT 'the ''id'''"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			definition, err := tc.attrs.definition("BIGINT")
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, definition, "it should carry over the column's attributes")
+		})
+	}
+
+	t.Run("generated column", func(t *testing.T) {
+		_, err := columnAttributes{nullable: "YES", extra: "VIRTUAL GENERATED"}.definition("BIGINT")
+		require.ErrorIs(t, err, sqlconnect.ErrNotSupported, "it should not change the type of generated columns")
+	})
+}
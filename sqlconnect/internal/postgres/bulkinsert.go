@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"iter"
+
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// InsertRows inserts rows into the given table using [COPY FROM STDIN]
+func (db *DB) InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], opts ...sqlconnect.Option) error {
+	return base.InsertRows(ctx, relation, columns, rows, db.copyIn, opts...)
+}
+
+// copyIn copies a batch of rows into the given table within a single transaction
+func (db *DB) copyIn(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginning transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stmt := pq.CopyIn(relation.Name, columns...)
+	if relation.Schema != "" {
+		stmt = pq.CopyInSchema(relation.Schema, relation.Name, columns...)
+	}
+	copyStmt, err := tx.PrepareContext(ctx, stmt)
+	if err != nil {
+		return fmt.Errorf("preparing copy statement: %w", db.ClassifyError(err))
+	}
+	defer func() { _ = copyStmt.Close() }()
+	for _, row := range batch {
+		if _, err := copyStmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf("copying row: %w", db.ClassifyError(err))
+		}
+	}
+	if _, err := copyStmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("flushing copy statement: %w", db.ClassifyError(err))
+	}
+	if err := copyStmt.Close(); err != nil {
+		return fmt.Errorf("closing copy statement: %w", db.ClassifyError(err))
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("committing transaction: %w", db.ClassifyError(err))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 
@@ -17,15 +18,21 @@ const (
 
 // NewDB creates a new postgres db client
 func NewDB(credentialsJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), credentialsJSON)
+}
+
+// NewDBContext creates a new postgres db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, credentialsJSON json.RawMessage) (*DB, error) {
 	var config Config
-	err := config.Parse(credentialsJSON)
+	err := config.ParseContext(ctx, credentialsJSON)
 	if err != nil {
 		return nil, err
 	}
 
+	var tunnel sshtunnel.Tunnel
 	tunnelCloser := sshtunnel.NoTunnelCloser
 	if config.TunnelInfo != nil {
-		tunnel, err := sshtunnel.NewTcpTunnel(*config.TunnelInfo, config.Host, config.Port)
+		tunnel, err = sshtunnel.NewTcpTunnelContext(ctx, *config.TunnelInfo, config.Host, config.Port)
 		if err != nil {
 			return nil, err
 		}
@@ -44,16 +51,20 @@ func NewDB(credentialsJSON json.RawMessage) (*DB, error) {
 		DB: base.NewDB(
 			db,
 			tunnelCloser,
+			base.WithSSHTunnel(tunnel),
 			base.WithGoquDialect(base.NewGoquDialect(DatabaseType, GoquDialectOptions(), GoquExpressions())),
 			base.WithColumnTypeMappings(getColumnTypeMappings(config)),
 			base.WithJsonRowMapper(getJonRowMapper(config)),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("postgresql", config.DBName),
 		),
 	}, nil
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -61,6 +72,11 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 func getColumnTypeMappings(config Config) map[string]string {
 	if config.UseLegacyMappings {
 		return legacyColumnTypeMappings
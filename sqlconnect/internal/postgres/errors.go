@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// classifyError classifies postgres errors using their SQLSTATE codes
+func classifyError(err error) []error {
+	pqErr, ok := errors.AsType[*pq.Error](err)
+	if !ok {
+		return nil
+	}
+	if pqErr.Code == "57014" && strings.Contains(pqErr.Message, "timeout") { // query_canceled, which is also reported when cancelling a query
+		return []error{sqlconnect.ErrQueryTimeout}
+	}
+	return base.ClassifySQLState(string(pqErr.Code))
+}
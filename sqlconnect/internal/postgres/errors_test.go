@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "undefined table", err: &pq.Error{Code: "42P01", Message: `relation "t" does not exist`}, expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "invalid schema", err: &pq.Error{Code: "3F000", Message: `schema "s" does not exist`}, expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "syntax error", err: &pq.Error{Code: "42601", Message: `syntax error at or near "SELEC"`}, expected: []error{sqlconnect.ErrSyntax}},
+		{name: "insufficient privilege", err: &pq.Error{Code: "42501", Message: "permission denied for table t"}, expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "invalid password", err: &pq.Error{Code: "28P01", Message: `password authentication failed for user "u"`}, expected: []error{sqlconnect.ErrAuthentication}},
+		{name: "statement timeout", err: &pq.Error{Code: "57014", Message: "canceling statement due to statement timeout"}, expected: []error{sqlconnect.ErrQueryTimeout}},
+		{name: "user cancellation", err: &pq.Error{Code: "57014", Message: "canceling statement due to user request"}},
+		{name: "serialization failure", err: &pq.Error{Code: "40001"}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "too many connections", err: &pq.Error{Code: "53300"}, expected: []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}},
+		{name: "wrapped", err: fmt.Errorf("querying: %w", &pq.Error{Code: "42P01"}), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
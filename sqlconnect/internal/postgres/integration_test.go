@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"strings"
 	"testing"
@@ -42,6 +43,15 @@ func TestPostgresDB(t *testing.T) {
 		strings.ToLower,
 		integrationtest.Options{
 			LegacySupport: true,
+			AddUniqueKey: func(table, column string) string {
+				return fmt.Sprintf("ALTER TABLE %s ADD UNIQUE (%s)", table, column)
+			},
+			LongRunningQuery: func(marker string) string {
+				return fmt.Sprintf("SELECT '%s', pg_sleep(120)", marker)
+			},
+			RunningQueries: func(marker string) string {
+				return fmt.Sprintf("SELECT COUNT(*) FROM pg_stat_activity WHERE state = 'active' AND query LIKE '%%%s%%' AND pid <> pg_backend_pid()", marker)
+			},
 		},
 	)
 
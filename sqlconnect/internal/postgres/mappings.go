@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/lib/pq"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
 // pgStringArrayTypes are the Postgres string-element array type names (element type prefixed
@@ -87,6 +89,9 @@ var columnTypeMappings = map[string]string{
 	"_name":              "array",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "bigint", "double precision", "text", "timestamptz", "boolean", "jsonb")
+
 // jsonRowMapper maps a row's scanned column to a json object's field
 func jsonRowMapper(databaseTypeName string, value any) any {
 	switch databaseTypeName {
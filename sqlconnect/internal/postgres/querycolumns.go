@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// ListColumnsForSqlQuery overrides the base implementation to describe the query using the catalog, without executing it
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.DB.ListColumnsForSqlQueryUsing(ctx, sql, db.describeQuery, opts...)
+}
+
+// describeQuery describes the query by creating a temporary view for it in a transaction which is rolled back, postgres analysing the query without executing it.
+// A temporary view is used instead of a prepared statement, since the driver doesn't expose the columns of prepared statements.
+// If the view can't be created, e.g. because the query has duplicate column names or the server is a read-only replica, the query is described using the base implementation instead,
+// which doesn't describe statements other than queries.
+func (db *DB) describeQuery(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+	if !base.IsSelectQuery(sql) {
+		return db.DescribeQuery(ctx, sql)
+	}
+	columns, err := db.describeView(ctx, sql)
+	if err != nil && ctx.Err() == nil {
+		return db.DescribeQuery(ctx, sql)
+	}
+	return columns, err
+}
+
+// describeView creates a temporary view for the query and returns its columns, using the same type names as the driver
+func (db *DB) describeView(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction for describing query: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("CREATE TEMPORARY VIEW %[1]s AS %[2]s", describeViewName, base.TrimQuery(sql))); err != nil {
+		return nil, fmt.Errorf("creating view for describing query: %w", err)
+	}
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`SELECT a.attname AS column_name, upper(t.typname) AS data_type FROM pg_attribute a JOIN pg_type t ON t.oid = a.atttypid
+		WHERE a.attrelid = 'pg_temp.%[1]s'::regclass AND a.attnum > 0 AND NOT a.attisdropped ORDER BY a.attnum`, describeViewName))
+	if err != nil {
+		return nil, fmt.Errorf("querying columns of view for describing query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return base.ScanQueryColumns(rows, "column_name", "data_type")
+}
+
+const describeViewName = "sqlconnect_describe"
@@ -0,0 +1,63 @@
+package redshift
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+	redshiftdriver "github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/redshift/driver"
+)
+
+// dataAsyncQueries runs queries asynchronously using the data api, which identifies them by their statement ids
+var dataAsyncQueries = base.AsyncQueries{
+	DatabaseType: DatabaseType,
+	SubmitContext: func(ctx context.Context) (context.Context, func() (string, string)) {
+		var statementID string
+		return redshiftdriver.WithAsyncMode(ctx, func(id string) { statementID = id }), func() (string, string) {
+			return statementID, ""
+		}
+	},
+	Status: statementStatus,
+	FetchContext: func(ctx context.Context, handle sqlconnect.QueryHandle) context.Context {
+		return redshiftdriver.WithFetchResultByID(ctx, handle.ID)
+	},
+	Cancel: func(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) error {
+		conn, ok := driverConn.(redshiftdriver.RedshiftConnection)
+		if !ok {
+			return fmt.Errorf("unexpected driver connection: %T", driverConn)
+		}
+		return conn.CancelStatement(ctx, handle.ID)
+	},
+}
+
+// statementStatus returns the status of a statement submitted using the data api
+func statementStatus(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error) {
+	conn, ok := driverConn.(redshiftdriver.RedshiftConnection)
+	if !ok {
+		return sqlconnect.QueryStatus{}, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	output, err := conn.DescribeStatement(ctx, handle.ID)
+	if err != nil {
+		return sqlconnect.QueryStatus{}, err
+	}
+	switch output.Status {
+	case types.StatusStringFinished:
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateSucceeded}, nil
+	case types.StatusStringFailed, types.StatusStringAborted:
+		state := sqlconnect.QueryStateFailed
+		if output.Status == types.StatusStringAborted {
+			state = sqlconnect.QueryStateCanceled
+		}
+		return sqlconnect.QueryStatus{State: state, Err: &redshiftdriver.StatementError{
+			ID:      handle.ID,
+			Status:  output.Status,
+			Message: aws.ToString(output.Error),
+		}}, nil
+	default: // submitted, picked or started
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateRunning}, nil
+	}
+}
@@ -60,7 +60,10 @@ func (c *Config) Parse(input json.RawMessage) error {
 
 // DialectConfig is the configuration for a redshift dialect
 type DialectConfig struct {
-	EnableCaseSensitiveIdentifier bool `json:"enableCaseSensitiveIdentifier"`
+	EnableCaseSensitiveIdentifier bool `json:"enableCaseSensitiveIdentifier"` // for dialects created without a db client, whose dialect probes the database instead
+	// LazyDialectProbe defers probing the database's case sensitivity, when creating a db client, until its first connection is opened.
+	// Until then identifiers are considered case insensitive, whereas a failed probe is retried on the next connection.
+	LazyDialectProbe bool `json:"lazyDialectProbe"`
 }
 
 // Parse parses the given JSON into the config
@@ -3,17 +3,19 @@ package redshift
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"time"
+	"slices"
+	"strings"
 
-	_ "github.com/lib/pq" // postgres driver
+	"github.com/lib/pq"
 	"github.com/samber/lo"
 	"github.com/tidwall/gjson"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
-	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/postgres"
 	redshiftdriver "github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/redshift/driver"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/sshtunnel"
 )
@@ -24,36 +26,73 @@ const (
 
 // NewDB creates a new redshift db client
 func NewDB(credentialsJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), credentialsJSON)
+}
+
+// NewDBContext creates a new redshift db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, credentialsJSON json.RawMessage) (*DB, error) {
+	var dialectConfig DialectConfig
+	if err := dialectConfig.Parse(credentialsJSON); err != nil {
+		return nil, err
+	}
 	var (
-		db  *sql.DB
-		err error
+		connector driver.Connector
+		err       error
 	)
 	useLegacyMappings := gjson.GetBytes(credentialsJSON, "useLegacyMappings").Bool()
+	var tunnel sshtunnel.Tunnel
 	tunnelCloser := sshtunnel.NoTunnelCloser
+	namespace := gjson.GetBytes(credentialsJSON, "dbname").Str
+	queryTagSession := queryGroup
+	var asyncQueries base.AsyncQueries // only the data api can run queries asynchronously
 	// Use the SDK if the credentials are for the SDK
 	if configType := gjson.GetBytes(credentialsJSON, "type").Str; configType == RedshiftDataConfigType {
-		db, err = newRedshiftDataDB(credentialsJSON)
+		connector, err = newRedshiftDataConnector(credentialsJSON)
+		namespace = gjson.GetBytes(credentialsJSON, "database").Str
+		queryTagSession = nil // the data api doesn't support sessions, thus statements are tagged using sql comments
+		asyncQueries = dataAsyncQueries
 	} else {
-		db, tunnelCloser, err = newPostgresDB(credentialsJSON)
+		connector, tunnel, err = newPostgresConnector(ctx, credentialsJSON)
+		if tunnel != nil {
+			tunnelCloser = tunnel.Close
+		}
 	}
 	if err != nil {
 		return nil, err
 	}
-	var caseSensitive string
 
-	func() {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		_ = db.QueryRowContext(ctx, "show enable_case_sensitive_identifier").Scan(&caseSensitive)
-	}()
+	var (
+		db            *sql.DB
+		caseSensitive func() bool
+	)
+	if dialectConfig.LazyDialectProbe {
+		// the database's case sensitivity is probed using the first connection opened, so that creating a db client doesn't require a roundtrip to the database
+		probe := &lazyCaseSensitivityProbe{}
+		db = sql.OpenDB(probingConnector{Connector: connector, probe: probe})
+		caseSensitive = probe.CaseSensitive
+	} else {
+		db = sql.OpenDB(connector)
+		enabled := probeCaseSensitiveIdentifier(ctx, db)
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Join(err, db.Close(), tunnelCloser())
+		}
+		caseSensitive = func() bool { return enabled }
+	}
 
 	return &DB{
 		DB: base.NewDB(
 			db,
 			tunnelCloser,
-			base.WithDialect(newDialect(DialectConfig{EnableCaseSensitiveIdentifier: caseSensitive == "on"})),
+			base.WithSSHTunnel(tunnel),
+			base.WithDialect(newProbedDialect(caseSensitive)),
 			base.WithColumnTypeMappings(getColumnTypeMappings(useLegacyMappings)),
 			base.WithJsonRowMapper(getJonRowMapper(useLegacyMappings)),
+			base.WithTypeTreeMapper(typeTreeMapper),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("redshift", namespace),
+			base.WithQueryTagSession(queryTagSession),
+			base.WithAsyncQueries(asyncQueries),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.CurrentCatalog = func() string {
 					return "SELECT current_database()"
@@ -105,21 +144,77 @@ func NewDB(credentialsJSON json.RawMessage) (*DB, error) {
 					}
 					return stmt + " ORDER BY ordinal_position ASC", "column_name", "data_type"
 				}
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					// sort keys are only available for local tables through svv_redshift_columns
+					stmt := fmt.Sprintf("SELECT c.column_name, c.data_type, c.ordinal_position, c.is_nullable, c.numeric_precision, c.numeric_scale, c.character_maximum_length, c.column_default, c.remarks AS column_comment, COALESCE(r.sortkey, 0) <> 0 AS is_sort_key FROM SVV_ALL_COLUMNS c "+
+						"LEFT JOIN SVV_REDSHIFT_COLUMNS r ON r.database_name = c.database_name AND r.schema_name = c.schema_name AND r.table_name = c.table_name AND r.column_name = c.column_name "+
+						"WHERE c.schema_name = '%[1]s' AND c.table_name = '%[2]s'", base.EscapeSqlString(schema), base.EscapeSqlString(table))
+					if catalog != "" {
+						stmt += fmt.Sprintf(" AND c.database_name = '%[1]s'", base.EscapeSqlString(catalog))
+					}
+					cols := base.InformationSchemaColumnDetails
+					cols.PrimaryKey = ""
+					cols.ClusteringKey = "is_sort_key"
+					return stmt + " ORDER BY c.ordinal_position ASC", cols
+				}
+				cmds.CreateTable = func(table base.QuotedIdentifier, columns []string, primaryKey []base.QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []base.QuotedIdentifier) (string, error) {
+					if len(partitionBy) > 0 {
+						return "", fmt.Errorf("partitioning tables: %w", sqlconnect.ErrNotSupported)
+					}
+					stmt := base.CreateTableCommand(table, ifNotExists, slices.Concat(columns, base.PrimaryKeyConstraint(primaryKey)))
+					if len(clusterBy) > 0 { // clustering columns are used as a compound sort key
+						stmt += fmt.Sprintf(" SORTKEY (%s)", base.JoinQuotedIdentifiers(clusterBy, ""))
+					}
+					return stmt, nil
+				}
+				cmds.AddColumns = func(table base.QuotedIdentifier, columns []string) []string { // redshift can only add one column per statement
+					return lo.Map(columns, func(col string, _ int) string { return fmt.Sprintf("ALTER TABLE %[1]s ADD COLUMN %[2]s", table, col) })
+				}
+				cmds.DropColumns = func(table base.QuotedIdentifier, columns []base.QuotedIdentifier) []string { // redshift can only drop one column per statement
+					return lo.Map(columns, func(col base.QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("ALTER TABLE %[1]s DROP COLUMN %[2]s", table, col)
+					})
+				}
+				cmds.AlterColumnType = func(_, _ base.QuotedIdentifier, _, _, _ string) ([]string, error) {
+					return nil, fmt.Errorf("redshift can only increase the size of varchar columns: %w", sqlconnect.ErrNotSupported)
+				}
+				cmds.Merge = func(target, source base.QuotedIdentifier, keyColumns, updateColumns []base.QuotedIdentifier) []string {
+					columns := base.JoinQuotedIdentifiers(slices.Concat(keyColumns, updateColumns), "")
+					// the target table is referenced by its name, instead of an alias, in both statements
+					on := strings.Join(lo.Map(keyColumns, func(col base.QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("%[1]s.%[2]s = s.%[2]s", target, col)
+					}), " AND ")
+					// rows that don't match are inserted, after matched rows have been updated
+					insert := fmt.Sprintf("INSERT INTO %[1]s (%[3]s) SELECT %[3]s FROM %[2]s s WHERE NOT EXISTS (SELECT 1 FROM %[1]s WHERE %[4]s)", target, source, columns, on)
+					if len(updateColumns) == 0 {
+						return []string{insert}
+					}
+					// only the update columns of matched rows are overwritten, keeping the rest of their columns
+					set := strings.Join(lo.Map(updateColumns, func(col base.QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("%[1]s = s.%[1]s", col)
+					}), ", ")
+					return []string{
+						fmt.Sprintf("UPDATE %[1]s SET %[3]s FROM %[2]s s WHERE %[4]s", target, source, set, on),
+						insert,
+					}
+				}
 				return cmds
 			}),
 		),
 	}, nil
 }
 
-func newPostgresDB(credentialsJSON json.RawMessage) (*sql.DB, func() error, error) {
+// newPostgresConnector returns a connector using the postgres protocol, along with the ssh tunnel it connects through, if any
+func newPostgresConnector(ctx context.Context, credentialsJSON json.RawMessage) (driver.Connector, sshtunnel.Tunnel, error) {
 	var config PostgresConfig
-	err := config.Parse(credentialsJSON)
+	err := config.ParseContext(ctx, credentialsJSON)
 	if err != nil {
 		return nil, nil, err
 	}
+	var tunnel sshtunnel.Tunnel
 	tunnelCloser := sshtunnel.NoTunnelCloser
 	if config.TunnelInfo != nil {
-		tunnel, err := sshtunnel.NewTcpTunnel(*config.TunnelInfo, config.Host, config.Port)
+		tunnel, err = sshtunnel.NewTcpTunnelContext(ctx, *config.TunnelInfo, config.Host, config.Port)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -129,14 +224,14 @@ func newPostgresDB(credentialsJSON json.RawMessage) (*sql.DB, func() error, erro
 		config.Port = tunnel.Port()
 	}
 
-	db, err := sql.Open(postgres.DatabaseType, config.ConnectionString())
+	connector, err := pq.NewConnector(config.ConnectionString())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, errors.Join(err, tunnelCloser())
 	}
-	return db, tunnelCloser, nil
+	return connector, tunnel, nil
 }
 
-func newRedshiftDataDB(credentialsJSON json.RawMessage) (*sql.DB, error) {
+func newRedshiftDataConnector(credentialsJSON json.RawMessage) (driver.Connector, error) {
 	var config Config
 	err := config.Parse(credentialsJSON)
 	if err != nil {
@@ -161,14 +256,12 @@ func newRedshiftDataDB(credentialsJSON json.RawMessage) (*sql.DB, error) {
 		MaxPolling:          config.MaxPolling,
 		RetryMaxAttempts:    config.RetryMaxAttempts,
 	}
-	connector := redshiftdriver.NewRedshiftConnector(cfg)
-
-	return sql.OpenDB(connector), nil
+	return redshiftdriver.NewRedshiftConnector(cfg), nil
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -176,6 +269,11 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 func getColumnTypeMappings(useLegacyMappings bool) map[string]string {
 	if useLegacyMappings {
 		return legacyColumnTypeMappings
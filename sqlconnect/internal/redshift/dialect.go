@@ -11,15 +11,20 @@ import (
 
 // newDialect returns a Redshift dialect
 func newDialect(config DialectConfig) sqlconnect.Dialect {
+	return newProbedDialect(func() bool { return config.EnableCaseSensitiveIdentifier })
+}
+
+// newProbedDialect returns a Redshift dialect whose case sensitivity is reported by the provided function, e.g. by probing the database's configuration
+func newProbedDialect(caseSensitive func() bool) sqlconnect.Dialect {
 	return dialect{
 		GoquDialect:   base.NewGoquDialect(DatabaseType, GoquDialectOptions(), GoquExpressions()),
-		caseSensitive: config.EnableCaseSensitiveIdentifier,
+		caseSensitive: caseSensitive,
 	}
 }
 
 type dialect struct {
 	*base.GoquDialect
-	caseSensitive bool
+	caseSensitive func() bool // nil if identifiers are case insensitive
 }
 
 // QuoteTable quotes a table name
@@ -45,7 +50,7 @@ func (d dialect) FormatTableName(name string) string {
 
 // NormaliseIdentifier normalises all identifier parts by lower casing them.
 func (d dialect) NormaliseIdentifier(identifier string) string {
-	if d.caseSensitive {
+	if d.caseSensitive != nil && d.caseSensitive() {
 		return base.NormaliseIdentifier(identifier, '"', strings.ToLower)
 	}
 	// ASCII letters in standard and delimited identifiers are case-insensitive and are folded to lowercase in the database
@@ -45,7 +45,7 @@ func TestDialect(t *testing.T) {
 		require.Equal(t, `"sh""ema".table."column"`, normalised, "all parts should be normalised")
 
 		t.Run("case sensitive", func(t *testing.T) {
-			d := dialect{caseSensitive: true}
+			d := dialect{caseSensitive: func() bool { return true }}
 
 			normalised := d.NormaliseIdentifier("column")
 			require.Equal(t, "column", normalised, "column name should be normalised to lowercase")
@@ -86,7 +86,7 @@ func TestDialect(t *testing.T) {
 		require.Equal(t, sqlconnect.RelationRef{Catalog: "cata\"log", Schema: "schema", Name: "table"}, parsed)
 
 		t.Run("case sensitive", func(t *testing.T) {
-			d := dialect{caseSensitive: true}
+			d := dialect{caseSensitive: func() bool { return true }}
 
 			parsed, err := d.ParseRelationRef("table")
 			require.NoError(t, err)
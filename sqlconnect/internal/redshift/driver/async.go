@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+type (
+	asyncModeKey       struct{}
+	fetchResultByIDKey struct{}
+)
+
+// RedshiftConnection exposes the Redshift Data API operations of the driver's connections, which can be reached using [sql.Conn.Raw]
+type RedshiftConnection interface {
+	// DescribeStatement describes a statement, e.g. for getting its status
+	DescribeStatement(ctx context.Context, statementID string) (*redshiftdata.DescribeStatementOutput, error)
+	// CancelStatement cancels a running statement
+	CancelStatement(ctx context.Context, statementID string) error
+}
+
+// WithAsyncMode returns a context for submitting queries without waiting for their statements to complete, calling onSubmit with the id of each statement submitted.
+// Queries performed with the context return no rows.
+func WithAsyncMode(ctx context.Context, onSubmit func(statementID string)) context.Context {
+	return context.WithValue(ctx, asyncModeKey{}, onSubmit)
+}
+
+// WithFetchResultByID returns a context for fetching the result of a submitted statement, by performing an empty query with it.
+// The statement is waited for if it hasn't completed yet, without getting canceled if the context is done before it completes.
+func WithFetchResultByID(ctx context.Context, statementID string) context.Context {
+	return context.WithValue(ctx, fetchResultByIDKey{}, statementID)
+}
+
+// DescribeStatement describes a statement, e.g. for getting its status
+func (c *redshiftConnection) DescribeStatement(ctx context.Context, statementID string) (*redshiftdata.DescribeStatementOutput, error) {
+	return c.client.DescribeStatement(ctx, &redshiftdata.DescribeStatementInput{Id: aws.String(statementID)})
+}
+
+// CancelStatement cancels a running statement
+func (c *redshiftConnection) CancelStatement(ctx context.Context, statementID string) error {
+	_, err := c.client.CancelStatement(ctx, &redshiftdata.CancelStatementInput{Id: aws.String(statementID)})
+	return err
+}
+
+// submitStatement executes a statement without waiting for it to complete, returning its id
+func (c *redshiftConnection) submitStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput) (_ string, err error) {
+	ctx, span := c.startSpan(ctx, "redshiftdata.ExecuteStatement", aws.ToString(params.Sql))
+	defer func() { telemetry.EndSpan(span, err) }()
+	id, err := c.startStatement(ctx, params)
+	if err != nil {
+		return "", err
+	}
+	span.SetAttributes(statementIDKey.String(aws.ToString(id)))
+	return aws.ToString(id), nil
+}
+
+// fetchStatementResult waits for a submitted statement to complete and returns its result
+func (c *redshiftConnection) fetchStatementResult(ctx context.Context, id string) (_ driver.Rows, err error) {
+	ctx, span := telemetry.StartSpan(ctx, "redshiftdata.GetStatementResult", append(c.telemetryAttributes(), statementIDKey.String(id))...)
+	defer func() { telemetry.EndSpan(span, err) }()
+	p, _, err := c.statementResult(ctx, span, aws.String(id), false)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return noRows{}, nil
+	}
+	return newRows(ctx, id, p)
+}
+
+// noRows are the rows of a query without a result set
+type noRows struct{}
+
+func (noRows) Columns() []string         { return nil }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
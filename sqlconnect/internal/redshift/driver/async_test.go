@@ -0,0 +1,64 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncMode(t *testing.T) {
+	ctx := context.Background()
+	db := sql.OpenDB(&fakeConnector{conn: newConnection(&fakeRedshiftClient{}, &RedshiftConfig{})})
+	defer func() { _ = db.Close() }()
+
+	t.Run("submit", func(t *testing.T) {
+		var statementID string
+		rows, err := db.QueryContext(WithAsyncMode(ctx, func(id string) { statementID = id }), "SELECT * FROM t")
+		require.NoError(t, err)
+		require.False(t, rows.Next(), "it should return no rows")
+		require.NoError(t, rows.Close())
+		require.Equal(t, "statement-id", statementID)
+	})
+
+	t.Run("describe", func(t *testing.T) {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		var status types.StatusString
+		err = conn.Raw(func(driverConn any) error {
+			output, err := driverConn.(RedshiftConnection).DescribeStatement(ctx, "statement-id")
+			if err == nil {
+				status = output.Status
+			}
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, types.StatusStringFinished, status)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		err = conn.Raw(func(driverConn any) error {
+			return driverConn.(RedshiftConnection).CancelStatement(ctx, "statement-id")
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		rows, err := db.QueryContext(WithFetchResultByID(ctx, "statement-id"), "")
+		require.NoError(t, err)
+		var values []int64
+		for rows.Next() {
+			var v int64
+			require.NoError(t, rows.Scan(&v))
+			values = append(values, v)
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []int64{1, 2}, values)
+	})
+}
@@ -5,11 +5,15 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
 	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
 )
 
 type redshiftConnection struct {
@@ -131,10 +135,21 @@ func (c *redshiftConnection) QueryContext(ctx context.Context, query string, arg
 		return nil, fmt.Errorf("query in transaction: %w", ErrNotSupported)
 	}
 
+	if id, ok := ctx.Value(fetchResultByIDKey{}).(string); ok {
+		return c.fetchStatementResult(ctx, id)
+	}
 	params := &redshiftdata.ExecuteStatementInput{
 		Sql:        nullStringIfEmpty(rewriteQuery(query, len(args) > 0)),
 		Parameters: convertArgsToParameters(args),
 	}
+	if onSubmit, ok := ctx.Value(asyncModeKey{}).(func(string)); ok {
+		id, err := c.submitStatement(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		onSubmit(id)
+		return noRows{}, nil
+	}
 	p, output, err := c.executeStatement(ctx, params)
 	if err != nil {
 		return nil, err
@@ -215,7 +230,19 @@ func convertArgsToParameters(args []driver.NamedValue) []types.SqlParameter {
 	return scanParams
 }
 
-func (c *redshiftConnection) executeStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput) (*redshiftdata.GetStatementResultPaginator, *redshiftdata.DescribeStatementOutput, error) {
+func (c *redshiftConnection) executeStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput) (_ *redshiftdata.GetStatementResultPaginator, _ *redshiftdata.DescribeStatementOutput, err error) {
+	ctx, span := c.startSpan(ctx, "redshiftdata.ExecuteStatement", aws.ToString(params.Sql))
+	defer func() { telemetry.EndSpan(span, err) }()
+	id, err := c.startStatement(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	span.SetAttributes(statementIDKey.String(aws.ToString(id)))
+	return c.statementResult(ctx, span, id, true)
+}
+
+// startStatement executes a statement using the connection's configuration, returning its id without waiting for it to complete
+func (c *redshiftConnection) startStatement(ctx context.Context, params *redshiftdata.ExecuteStatementInput) (*string, error) {
 	params.ClusterIdentifier = nullStringIfEmpty(c.cfg.ClusterIdentifier)
 	params.Database = nullStringIfEmpty(c.cfg.Database)
 	params.DbUser = nullStringIfEmpty(c.cfg.DbUser)
@@ -224,17 +251,20 @@ func (c *redshiftConnection) executeStatement(ctx context.Context, params *redsh
 
 	executeOutput, err := c.client.ExecuteStatement(ctx, params)
 	if err != nil {
-		return nil, nil, fmt.Errorf("execute statement:%w", err)
+		return nil, fmt.Errorf("execute statement:%w", err)
 	}
-	describeOutput, err := c.wait(ctx, executeOutput.Id)
+	return executeOutput.Id, nil
+}
+
+// statementResult waits for a statement to complete, returning a paginator for its result set, if any.
+// The statement gets canceled if the context is done or the connection gets closed while waiting, unless cancelOnDone is false.
+func (c *redshiftConnection) statementResult(ctx context.Context, span trace.Span, id *string, cancelOnDone bool) (*redshiftdata.GetStatementResultPaginator, *redshiftdata.DescribeStatementOutput, error) {
+	describeOutput, err := c.wait(ctx, id, cancelOnDone)
 	if err != nil {
 		return nil, nil, err
 	}
-	if describeOutput.Status == types.StatusStringAborted {
-		return nil, nil, fmt.Errorf("query aborted: %s", *describeOutput.Error)
-	}
-	if describeOutput.Status == types.StatusStringFailed {
-		return nil, nil, fmt.Errorf("query failed: %s", *describeOutput.Error)
+	if describeOutput.Status == types.StatusStringAborted || describeOutput.Status == types.StatusStringFailed {
+		return nil, nil, newStatementError(describeOutput)
 	}
 	if describeOutput.Status != types.StatusStringFinished {
 		return nil, nil, fmt.Errorf("query status is not finished: %s", describeOutput.Status)
@@ -242,13 +272,16 @@ func (c *redshiftConnection) executeStatement(ctx context.Context, params *redsh
 	if !*describeOutput.HasResultSet {
 		return nil, describeOutput, nil
 	}
+	span.SetAttributes(telemetry.ReturnedRowsKey.Int64(describeOutput.ResultRows))
 	p := redshiftdata.NewGetStatementResultPaginator(c.client, &redshiftdata.GetStatementResultInput{
-		Id: executeOutput.Id,
+		Id: id,
 	})
 	return p, describeOutput, nil
 }
 
-func (c *redshiftConnection) batchExecuteStatement(ctx context.Context, params *redshiftdata.BatchExecuteStatementInput) ([]*redshiftdata.GetStatementResultPaginator, *redshiftdata.DescribeStatementOutput, error) {
+func (c *redshiftConnection) batchExecuteStatement(ctx context.Context, params *redshiftdata.BatchExecuteStatementInput) (_ []*redshiftdata.GetStatementResultPaginator, _ *redshiftdata.DescribeStatementOutput, err error) {
+	ctx, span := c.startSpan(ctx, "redshiftdata.BatchExecuteStatement", strings.Join(params.Sqls, ";\n"))
+	defer func() { telemetry.EndSpan(span, err) }()
 	params.ClusterIdentifier = nullStringIfEmpty(c.cfg.ClusterIdentifier)
 	params.Database = nullStringIfEmpty(c.cfg.Database)
 	params.DbUser = nullStringIfEmpty(c.cfg.DbUser)
@@ -259,15 +292,13 @@ func (c *redshiftConnection) batchExecuteStatement(ctx context.Context, params *
 	if err != nil {
 		return nil, nil, fmt.Errorf("execute statement:%w", err)
 	}
-	describeOutput, err := c.wait(ctx, batchExecuteOutput.Id)
+	span.SetAttributes(statementIDKey.String(aws.ToString(batchExecuteOutput.Id)))
+	describeOutput, err := c.wait(ctx, batchExecuteOutput.Id, true)
 	if err != nil {
 		return nil, nil, err
 	}
-	if describeOutput.Status == types.StatusStringAborted {
-		return nil, nil, fmt.Errorf("query aborted: %s", *describeOutput.Error)
-	}
-	if describeOutput.Status == types.StatusStringFailed {
-		return nil, nil, fmt.Errorf("query failed: %s", *describeOutput.Error)
+	if describeOutput.Status == types.StatusStringAborted || describeOutput.Status == types.StatusStringFailed {
+		return nil, nil, newStatementError(describeOutput)
 	}
 	if describeOutput.Status != types.StatusStringFinished {
 		return nil, nil, fmt.Errorf("query status is not finished: %s", describeOutput.Status)
@@ -288,8 +319,8 @@ func isFinishedStatus(status types.StatusString) bool {
 	return status == types.StatusStringFinished || status == types.StatusStringFailed || status == types.StatusStringAborted
 }
 
-// wait for the query to finish using an exponential backoff
-func (c *redshiftConnection) wait(ctx context.Context, id *string) (output *redshiftdata.DescribeStatementOutput, err error) {
+// wait for the query to finish using an exponential backoff, canceling it if the context is done or the connection gets closed, unless cancelOnDone is false
+func (c *redshiftConnection) wait(ctx context.Context, id *string, cancelOnDone bool) (output *redshiftdata.DescribeStatementOutput, err error) {
 	polling := c.cfg.GetMinPolling()
 	if c.cfg.Timeout > 0 {
 		var cancel context.CancelFunc
@@ -299,6 +330,9 @@ func (c *redshiftConnection) wait(ctx context.Context, id *string) (output *reds
 
 	// cancelStatement cancels the query in case of a timeout
 	cancelStatement := func() {
+		if !cancelOnDone {
+			return
+		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		_, _ = c.client.CancelStatement(ctx, &redshiftdata.CancelStatementInput{Id: id})
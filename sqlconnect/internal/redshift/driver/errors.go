@@ -1,6 +1,13 @@
 package driver
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+)
 
 var (
 	ErrNotSupported = errors.New("not supported")
@@ -10,3 +17,25 @@ var (
 	ErrNotInTx      = errors.New("not in transaction")
 	ErrInTx         = errors.New("already in transaction")
 )
+
+// StatementError is returned when a statement fails or gets aborted, carrying the error message reported by redshift
+type StatementError struct {
+	ID      string             // the id of the statement
+	Status  types.StatusString // either FAILED or ABORTED
+	Message string             // the error message reported by redshift, e.g. ERROR: relation "t" does not exist
+}
+
+func (e *StatementError) Error() string {
+	if e.Status == types.StatusStringAborted {
+		return fmt.Sprintf("query aborted: %s", e.Message)
+	}
+	return fmt.Sprintf("query failed: %s", e.Message)
+}
+
+func newStatementError(describeOutput *redshiftdata.DescribeStatementOutput) *StatementError {
+	return &StatementError{
+		ID:      aws.ToString(describeOutput.Id),
+		Status:  describeOutput.Status,
+		Message: aws.ToString(describeOutput.Error),
+	}
+}
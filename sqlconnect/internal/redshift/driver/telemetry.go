@@ -0,0 +1,27 @@
+package driver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+// statementIDKey is the span attribute reporting the id of a Redshift Data API statement, for looking it up in the console or in sys_query_history
+const statementIDKey = attribute.Key("aws.redshift.statement_id")
+
+// telemetryAttributes returns the attributes identifying the database in spans and metrics
+func (c *redshiftConnection) telemetryAttributes() []attribute.KeyValue {
+	return []attribute.KeyValue{semconv.DBSystemRedshift, semconv.DBNamespaceKey.String(c.cfg.Database)}
+}
+
+// startSpan starts a span for a call to the Redshift Data API executing the sql query
+func (c *redshiftConnection) startSpan(ctx context.Context, name, query string) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, name, append(c.telemetryAttributes(),
+		semconv.DBOperationNameKey.String(telemetry.OperationName(query)),
+		semconv.DBQueryTextKey.String(telemetry.SanitizeQuery(query)),
+	)...)
+}
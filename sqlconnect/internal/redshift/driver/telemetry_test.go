@@ -0,0 +1,87 @@
+package driver
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTelemetry(t *testing.T) {
+	tracerProvider := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(tracerProvider) })
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	cfg := &RedshiftConfig{Database: "dev"}
+	db := sql.OpenDB(&fakeConnector{conn: newConnection(&fakeRedshiftClient{}, cfg)})
+	defer func() { _ = db.Close() }()
+
+	rows, err := db.QueryContext(context.Background(), "SELECT * FROM t WHERE c = 'secret'")
+	require.NoError(t, err)
+	var count int
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, 2, count)
+
+	t.Run("spans", func(t *testing.T) {
+		ended := spans.Ended()
+		require.Len(t, ended, 1)
+		require.Equal(t, "redshiftdata.ExecuteStatement", ended[0].Name())
+		require.Subset(t, ended[0].Attributes(), []attribute.KeyValue{
+			attribute.String("db.system", "redshift"),
+			attribute.String("db.namespace", "dev"),
+			attribute.String("db.operation.name", "SELECT"),
+			attribute.String("db.query.text", "SELECT * FROM t WHERE c = ?"),
+			attribute.String("aws.redshift.statement_id", "statement-id"),
+			attribute.Int64("db.response.returned_rows", 2),
+		})
+	})
+}
+
+type fakeConnector struct {
+	conn *redshiftConnection
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return &redshiftDataDriver{} }
+
+// fakeRedshiftClient is a [RedshiftClient] returning two rows for any statement
+type fakeRedshiftClient struct{}
+
+func (fakeRedshiftClient) ExecuteStatement(context.Context, *redshiftdata.ExecuteStatementInput, ...func(*redshiftdata.Options)) (*redshiftdata.ExecuteStatementOutput, error) {
+	return &redshiftdata.ExecuteStatementOutput{Id: aws.String("statement-id")}, nil
+}
+
+func (fakeRedshiftClient) DescribeStatement(context.Context, *redshiftdata.DescribeStatementInput, ...func(*redshiftdata.Options)) (*redshiftdata.DescribeStatementOutput, error) {
+	return &redshiftdata.DescribeStatementOutput{Status: types.StatusStringFinished, HasResultSet: aws.Bool(true), ResultRows: 2}, nil
+}
+
+func (fakeRedshiftClient) CancelStatement(context.Context, *redshiftdata.CancelStatementInput, ...func(*redshiftdata.Options)) (*redshiftdata.CancelStatementOutput, error) {
+	return &redshiftdata.CancelStatementOutput{}, nil
+}
+
+func (fakeRedshiftClient) BatchExecuteStatement(context.Context, *redshiftdata.BatchExecuteStatementInput, ...func(*redshiftdata.Options)) (*redshiftdata.BatchExecuteStatementOutput, error) {
+	return &redshiftdata.BatchExecuteStatementOutput{Id: aws.String("statement-id")}, nil
+}
+
+func (fakeRedshiftClient) GetStatementResult(context.Context, *redshiftdata.GetStatementResultInput, ...func(*redshiftdata.Options)) (*redshiftdata.GetStatementResultOutput, error) {
+	return &redshiftdata.GetStatementResultOutput{
+		ColumnMetadata: []types.ColumnMetadata{{Name: aws.String("c"), TypeName: aws.String("int4")}},
+		Records: [][]types.Field{
+			{&types.FieldMemberLongValue{Value: 1}},
+			{&types.FieldMemberLongValue{Value: 2}},
+		},
+	}, nil
+}
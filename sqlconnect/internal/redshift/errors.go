@@ -0,0 +1,70 @@
+package redshift
+
+import (
+	"errors"
+	"regexp"
+
+	"github.com/aws/smithy-go"
+	"github.com/lib/pq"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+	redshiftdriver "github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/redshift/driver"
+)
+
+// dataAPIErrorClasses maps the error codes of the redshift data api to their error classes
+var dataAPIErrorClasses = map[string][]error{
+	"UnrecognizedClientException":       {sqlconnect.ErrAuthentication},
+	"InvalidSignatureException":         {sqlconnect.ErrAuthentication},
+	"ExpiredTokenException":             {sqlconnect.ErrAuthentication},
+	"AccessDeniedException":             {sqlconnect.ErrPermissionDenied},
+	"QueryTimeoutException":             {sqlconnect.ErrQueryTimeout},
+	"ThrottlingException":               {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"ActiveStatementsExceededException": {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"ActiveSessionsExceededException":   {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"DatabaseConnectionException":       {sqlconnect.ErrRetryable},
+	"InternalServerException":           {sqlconnect.ErrRetryable},
+}
+
+// errorMessageClasses classifies errors using the messages reported by redshift, since the data api doesn't report any SQLSTATE codes
+var errorMessageClasses = []struct {
+	regex   *regexp.Regexp
+	classes []error
+}{
+	{regex: regexp.MustCompile(`(?i)relation "[^"]*" does not exist`), classes: []error{sqlconnect.ErrRelationNotFound}},
+	{regex: regexp.MustCompile(`(?i)(schema|database) "[^"]*" does not exist`), classes: []error{sqlconnect.ErrSchemaNotFound}},
+	{regex: regexp.MustCompile(`(?i)permission denied`), classes: []error{sqlconnect.ErrPermissionDenied}},
+	{regex: regexp.MustCompile(`(?i)syntax error at or near`), classes: []error{sqlconnect.ErrSyntax}},
+	{regex: regexp.MustCompile(`(?i)statement timeout|cancelled by WLM abort action`), classes: []error{sqlconnect.ErrQueryTimeout}},
+	{regex: regexp.MustCompile(`(?i)serializable isolation violation`), classes: []error{sqlconnect.ErrRetryable}},
+	{regex: regexp.MustCompile(`(?i)\b(cluster|workgroup) is (resuming|being resumed)\b`), classes: []error{sqlconnect.ErrRetryable}},
+}
+
+// classifyError classifies redshift errors, using their SQLSTATE codes when connected through the postgres driver, or their error codes and messages when using the data api
+func classifyError(err error) []error {
+	if pqErr, ok := errors.AsType[*pq.Error](err); ok {
+		if classes := base.ClassifySQLState(string(pqErr.Code)); len(classes) > 0 {
+			return classes
+		}
+		return classifyErrorMessage(pqErr.Message)
+	}
+	if stmtErr, ok := errors.AsType[*redshiftdriver.StatementError](err); ok {
+		return classifyErrorMessage(stmtErr.Message)
+	}
+	if apiErr, ok := errors.AsType[smithy.APIError](err); ok {
+		if classes, ok := dataAPIErrorClasses[apiErr.ErrorCode()]; ok {
+			return classes
+		}
+		return classifyErrorMessage(apiErr.ErrorMessage())
+	}
+	return nil
+}
+
+func classifyErrorMessage(message string) []error {
+	for _, c := range errorMessageClasses {
+		if c.regex.MatchString(message) {
+			return c.classes
+		}
+	}
+	return nil
+}
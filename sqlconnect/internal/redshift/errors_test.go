@@ -0,0 +1,42 @@
+package redshift
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/redshiftdata/types"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	redshiftdriver "github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/redshift/driver"
+)
+
+func TestClassifyError(t *testing.T) {
+	statementError := func(message string) error {
+		return &redshiftdriver.StatementError{ID: "id", Status: types.StatusStringFailed, Message: message}
+	}
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "undefined table", err: &pq.Error{Code: "42P01", Message: `relation "t" does not exist`}, expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "internal error with message", err: &pq.Error{Code: "XX000", Message: "1023 Serializable isolation violation on table"}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "data api relation not found", err: fmt.Errorf("querying: %w", statementError(`ERROR: relation "public.t" does not exist`)), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "data api schema not found", err: statementError(`ERROR: schema "s" does not exist`), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "data api syntax error", err: statementError(`ERROR: syntax error at or near "SELEC"`), expected: []error{sqlconnect.ErrSyntax}},
+		{name: "data api permission denied", err: statementError(`ERROR: permission denied for relation t`), expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "data api throttling", err: &types.ActiveStatementsExceededException{Message: aws.String("Active statements exceeded the allowed quota")}, expected: []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}},
+		{name: "data api execute statement error", err: &types.ExecuteStatementException{Message: aws.String(`ERROR: syntax error at or near "SELEC"`)}, expected: []error{sqlconnect.ErrSyntax}},
+		{name: "data api resuming cluster", err: &types.DatabaseConnectionException{Message: aws.String("Connection to the cluster failed")}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "data api validation error for resuming cluster", err: &types.ValidationException{Message: aws.String("Redshift cluster is resuming, please retry later")}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package redshift_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -31,8 +32,10 @@ func TestRedshiftDB(t *testing.T) {
 			[]byte(configJSON),
 			strings.ToLower,
 			integrationtest.Options{
-				LegacySupport: true,
-				ExtraTests:    ExtraTests,
+				LegacySupport:    true,
+				ExtraTests:       ExtraTests,
+				LongRunningQuery: longRunningQuery,
+				RunningQueries:   runningQueries,
 			},
 		)
 
@@ -70,8 +73,10 @@ func TestRedshiftDB(t *testing.T) {
 					[]byte(configJSON),
 					strings.ToLower,
 					integrationtest.Options{
-						LegacySupport: true,
-						ExtraTests:    ExtraTests,
+						LegacySupport:    true,
+						ExtraTests:       ExtraTests,
+						LongRunningQuery: longRunningQuery,
+						RunningQueries:   runningQueries,
 					},
 				)
 			})
@@ -110,6 +115,10 @@ func ExtraTests(t *testing.T, db sqlconnect.DB) {
 			actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 				require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 				col.RawType = ""
+				if col.TypeTree != nil {
+					require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+					col.TypeTree = nil
+				}
 				return col
 			})
 			require.ElementsMatch(t, actualCols, expectedCols, "it should return the correct columns")
@@ -120,6 +129,10 @@ func ExtraTests(t *testing.T, db sqlconnect.DB) {
 			actualCols = lo.Map(actualCols, func(col sqlconnect.ColumnRef, _ int) sqlconnect.ColumnRef {
 				require.NotEmptyf(t, col.RawType, "it should return the raw type for column %q", col.Name)
 				col.RawType = ""
+				if col.TypeTree != nil {
+					require.NotEqualf(t, sqlconnect.TypeKindScalar, col.TypeTree.Kind, "it should only return type trees for nested column %q", col.Name)
+					col.TypeTree = nil
+				}
 				return col
 			})
 			require.NoError(t, err, "it should be able to list columns")
@@ -127,3 +140,13 @@ func ExtraTests(t *testing.T, db sqlconnect.DB) {
 		})
 	})
 }
+
+// longRunningQuery provides a query which keeps running for several minutes, since redshift doesn't support sleeping
+func longRunningQuery(marker string) string {
+	return fmt.Sprintf("SELECT '%s', COUNT(*) FROM pg_catalog.pg_attribute a CROSS JOIN pg_catalog.pg_attribute b CROSS JOIN pg_catalog.pg_attribute c", marker)
+}
+
+// runningQueries provides a query counting the running queries containing the marker
+func runningQueries(marker string) string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM stv_recents WHERE status = 'Running' AND query LIKE '%%%s%%' AND pid <> pg_backend_pid()", marker)
+}
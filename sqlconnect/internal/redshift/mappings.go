@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"strconv"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
 // superJSONValue emits a SUPER value as raw JSON (so an array trait is a JSON array, not a
@@ -76,6 +79,16 @@ var columnTypeMappings = map[string]string{
 	"super": "array",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+// json is not supported, since SUPER columns are mapped to arrays
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "bigint", "double precision", "varchar(65535)", "timestamptz", "boolean")
+
+// typeTreeMapper reports SUPER columns as variants.
+// The postgres driver reports an empty type name for SUPER columns in query results, so they are only recognised when listing the columns of a table.
+func typeTreeMapper(columnType base.ColumnType) *sqlconnect.TypeNode {
+	return base.NestedTypeTree(columnType.DatabaseTypeName(), "SUPER")
+}
+
 // jsonRowMapper maps a row's scanned column to a json object's field
 func jsonRowMapper(databaseTypeName string, value any) any {
 	switch databaseTypeName {
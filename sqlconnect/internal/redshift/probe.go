@@ -0,0 +1,94 @@
+package redshift
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// caseSensitivityQuery is the query reporting whether case sensitive identifiers are enabled in the database
+const caseSensitivityQuery = "show enable_case_sensitive_identifier"
+
+// probeCaseSensitiveIdentifier returns true if case sensitive identifiers are enabled in the database, giving up after 10 seconds
+func probeCaseSensitiveIdentifier(ctx context.Context, db *sql.DB) bool {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	var caseSensitive string
+	_ = db.QueryRowContext(ctx, caseSensitivityQuery).Scan(&caseSensitive)
+	return caseSensitive == "on"
+}
+
+// lazyCaseSensitivityProbe probes whether case sensitive identifiers are enabled in the database using the connections opened by a [probingConnector], until a probe succeeds
+type lazyCaseSensitivityProbe struct {
+	mu            sync.Mutex
+	probed        atomic.Bool
+	caseSensitive atomic.Bool
+}
+
+// CaseSensitive returns true if case sensitive identifiers are enabled in the database, or false if it hasn't been probed successfully yet
+func (p *lazyCaseSensitivityProbe) CaseSensitive() bool {
+	return p.caseSensitive.Load()
+}
+
+// probe probes the database using the connection, unless a previous probe has succeeded. Failures are not cached, thus probing is retried on the next connection.
+func (p *lazyCaseSensitivityProbe) probe(ctx context.Context, conn driver.Conn) error {
+	if p.probed.Load() {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.probed.Load() {
+		return nil
+	}
+	queryer, ok := conn.(driver.QueryerContext)
+	if !ok {
+		return errors.New("connection doesn't support queries")
+	}
+	rows, err := queryer.QueryContext(ctx, caseSensitivityQuery, nil)
+	if err != nil {
+		return fmt.Errorf("probing case sensitive identifiers: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	values := make([]driver.Value, len(rows.Columns()))
+	if err := rows.Next(values); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("no rows returned")
+		}
+		return fmt.Errorf("probing case sensitive identifiers: %w", err)
+	}
+	var value string
+	switch v := values[0].(type) {
+	case string:
+		value = v
+	case []byte:
+		value = string(v)
+	}
+	p.caseSensitive.Store(value == "on")
+	p.probed.Store(true)
+	return nil
+}
+
+// probingConnector is a connector probing the database's case sensitivity on the connections it opens, using the context of the caller opening them, until a probe succeeds
+type probingConnector struct {
+	driver.Connector
+	probe *lazyCaseSensitivityProbe
+}
+
+// Connect opens a connection, probing the database's case sensitivity with it if needed.
+// Probing failures are ignored, unless the context is done, since probing can be retried on the next connection.
+func (c probingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.probe.probe(ctx, conn); err != nil && ctx.Err() != nil {
+		return nil, errors.Join(err, conn.Close())
+	}
+	return conn, nil
+}
@@ -0,0 +1,94 @@
+package redshift
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbingConnector(t *testing.T) {
+	t.Run("probes once it succeeds", func(t *testing.T) {
+		conn := &probeConn{results: []probeResult{{err: errors.New("temporarily unavailable")}, {value: []byte("on")}}}
+		probe := &lazyCaseSensitivityProbe{}
+		connector := probingConnector{Connector: probeConnector{conn: conn}, probe: probe}
+		require.False(t, probe.CaseSensitive(), "it should consider identifiers case insensitive until probed")
+
+		_, err := connector.Connect(context.Background())
+		require.NoError(t, err, "it should ignore probing failures")
+		require.False(t, probe.CaseSensitive())
+
+		_, err = connector.Connect(context.Background())
+		require.NoError(t, err)
+		require.True(t, probe.CaseSensitive(), "it should retry probing after a failure")
+
+		_, err = connector.Connect(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, conn.queries, "it should stop probing once a probe succeeds")
+	})
+
+	t.Run("fails if the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		conn := &probeConn{results: []probeResult{{err: context.Canceled}}}
+		connector := probingConnector{Connector: probeConnector{conn: conn}, probe: &lazyCaseSensitivityProbe{}}
+		_, err := connector.Connect(ctx)
+		require.ErrorIs(t, err, context.Canceled)
+		require.True(t, conn.closed, "it should close the connection")
+	})
+}
+
+type probeConnector struct {
+	driver.Connector
+	conn *probeConn
+}
+
+func (c probeConnector) Connect(context.Context) (driver.Conn, error) { return c.conn, nil }
+
+type probeResult struct {
+	value driver.Value
+	err   error
+}
+
+// probeConn is a connection returning the next of its results to each query
+type probeConn struct {
+	driver.Conn
+	results []probeResult
+	queries int
+	closed  bool
+}
+
+func (c *probeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query != caseSensitivityQuery {
+		return nil, errors.New("unexpected query")
+	}
+	result := c.results[c.queries]
+	c.queries++
+	if result.err != nil {
+		return nil, result.err
+	}
+	return &probeRows{value: result.value}, nil
+}
+
+func (c *probeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+type probeRows struct {
+	value driver.Value
+	read  bool
+}
+
+func (r *probeRows) Columns() []string { return []string{"enable_case_sensitive_identifier"} }
+func (r *probeRows) Close() error      { return nil }
+func (r *probeRows) Next(dest []driver.Value) error {
+	if r.read {
+		return io.EOF
+	}
+	r.read, dest[0] = true, r.value
+	return nil
+}
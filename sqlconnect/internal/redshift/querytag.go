@@ -0,0 +1,22 @@
+package redshift
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// queryGroupEscaper percent-encodes the characters separating the key=value pairs of a query_group, so that keys and values cannot forge or alter other pairs
+var queryGroupEscaper = strings.NewReplacer("%", "%25", ",", "%2C", "=", "%3D")
+
+// queryGroup returns the statements setting and resetting the query_group of a session, setting it to the query tags formatted as comma-separated key=value pairs
+func queryGroup(tags map[string]string) (set, reset string) {
+	pairs := make([]string, 0, len(tags))
+	for _, k := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, queryGroupEscaper.Replace(k)+"="+queryGroupEscaper.Replace(tags[k]))
+	}
+	return fmt.Sprintf("SET query_group TO '%s'", base.EscapeSqlString(base.UnquotedIdentifier(strings.Join(pairs, ",")))), "RESET query_group"
+}
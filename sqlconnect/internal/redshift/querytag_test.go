@@ -0,0 +1,16 @@
+package redshift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryGroup(t *testing.T) {
+	set, reset := queryGroup(map[string]string{"job": "sync", "customer": "o'brien"})
+	require.Equal(t, "SET query_group TO 'customer=o''brien,job=sync'", set)
+	require.Equal(t, "RESET query_group", reset)
+
+	set, _ = queryGroup(map[string]string{"job": "a,b=c", "k=1": "50%"})
+	require.Equal(t, "SET query_group TO 'job=a%2Cb%3Dc,k%3D1=50%25'", set, "it should escape separators in keys and values")
+}
@@ -0,0 +1,114 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/compute"
+	"github.com/snowflakedb/gosnowflake"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// queryArrow runs a query using the driver's arrow batches, which are only available for queries whose results are returned by snowflake in arrow format.
+// Queries returning json results, e.g. SHOW commands, are reported as not supported, so that their rows get converted instead,
+// while queries returning no results at all return a reader with the schema of their rows, since snowflake provides no arrow schema for them.
+func queryArrow(ctx context.Context, driverConn any, query string, args []driver.NamedValue, rowsSchema func(driver.Rows) *arrow.Schema) (array.RecordReader, error) {
+	conn, ok := driverConn.(driver.QueryerContext)
+	if !ok {
+		return nil, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	ctx = gosnowflake.WithArrowBatchesTimestampOption(gosnowflake.WithArrowBatches(ctx), gosnowflake.UseMicrosecondTimestamp)
+	rows, err := conn.QueryContext(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	sfRows, ok := rows.(gosnowflake.SnowflakeRows)
+	if !ok {
+		_ = rows.Close()
+		return nil, fmt.Errorf("unexpected driver rows: %T", rows)
+	}
+	batches, err := sfRows.GetArrowBatches()
+	if err != nil {
+		_ = rows.Close()
+		if sfErr, ok := errors.AsType[*gosnowflake.SnowflakeError](err); ok && sfErr.Number == gosnowflake.ErrNonArrowResponseInArrowBatches {
+			return nil, fmt.Errorf("json results: %w", sqlconnect.ErrNotSupported)
+		}
+		return nil, err
+	}
+	return base.ArrowReaderFromRecords(arrowRecords(ctx, rows, batches), rowsSchema(rows))
+}
+
+// arrowRecords fetches the records of the batches, closing the rows once done
+func arrowRecords(ctx context.Context, rows driver.Rows, batches []*gosnowflake.ArrowBatch) iter.Seq2[arrow.Record, error] {
+	return func(yield func(arrow.Record, error) bool) {
+		defer func() { _ = rows.Close() }()
+		for i, batch := range batches {
+			records, err := batch.WithContext(ctx).Fetch()
+			if err != nil {
+				yield(nil, fmt.Errorf("fetching arrow batch %d: %w", i, err))
+				return
+			}
+			for j, record := range *records {
+				widened, err := widenIntegers(ctx, record)
+				if err != nil {
+					releaseRecords((*records)[j:])
+					yield(nil, fmt.Errorf("widening integers of arrow batch %d: %w", i, err))
+					return
+				}
+				if !yield(widened, nil) {
+					releaseRecords((*records)[j+1:])
+					return
+				}
+			}
+		}
+	}
+}
+
+// widenIntegers converts the integer columns of a record to int64, since snowflake uses the narrowest integer type fitting the values of each batch.
+// The record is released, in favour of the returned one.
+func widenIntegers(ctx context.Context, record arrow.Record) (arrow.Record, error) {
+	schema := record.Schema()
+	fields := schema.Fields()
+	cols := slices.Clone(record.Columns()) // the columns of the record itself must not be replaced
+	var widened []arrow.Array
+	for i, field := range fields {
+		if !arrow.IsInteger(field.Type.ID()) || field.Type.ID() == arrow.INT64 {
+			continue
+		}
+		col, err := compute.CastArray(ctx, cols[i], compute.SafeCastOptions(arrow.PrimitiveTypes.Int64))
+		if err != nil {
+			for _, w := range widened {
+				w.Release()
+			}
+			return nil, fmt.Errorf("column %s: %w", field.Name, err)
+		}
+		widened = append(widened, col)
+		fields[i].Type = arrow.PrimitiveTypes.Int64
+		cols[i] = col
+	}
+	if len(widened) == 0 {
+		return record, nil
+	}
+	defer record.Release()
+	defer func() {
+		for _, w := range widened {
+			w.Release() // retained by the new record
+		}
+	}()
+	metadata := schema.Metadata()
+	return array.NewRecord(arrow.NewSchema(fields, &metadata), cols, record.NumRows()), nil
+}
+
+func releaseRecords(records []arrow.Record) {
+	for _, record := range records {
+		record.Release()
+	}
+}
@@ -0,0 +1,40 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWidenIntegers(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "small", Type: arrow.PrimitiveTypes.Int8, Nullable: true, Metadata: arrow.NewMetadata([]string{"logicalType"}, []string{"FIXED"})},
+		{Name: "big", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int8Builder).AppendValues([]int8{1, 0}, []bool{true, false})
+	builder.Field(1).(*array.Int64Builder).AppendValues([]int64{2, 3}, nil)
+	builder.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	record := builder.NewRecord()
+	record.Retain() // released by widenIntegers
+	defer record.Release()
+
+	widened, err := widenIntegers(context.Background(), record)
+	require.NoError(t, err)
+	defer widened.Release()
+	require.Equal(t, arrow.PrimitiveTypes.Int64, widened.Schema().Field(0).Type, "it should widen narrower integers")
+	require.Equal(t, schema.Field(0).Metadata, widened.Schema().Field(0).Metadata, "it should keep the metadata of the fields")
+	require.Equal(t, `[1 (null)]`, widened.Column(0).String())
+	require.Same(t, record.Column(1), widened.Column(1), "it should keep the other columns")
+	require.Equal(t, arrow.PrimitiveTypes.Int8, record.Schema().Field(0).Type, "it should not modify the original record")
+
+	unchanged, err := widenIntegers(context.Background(), widened)
+	require.NoError(t, err)
+	require.Same(t, widened, unchanged, "it should return records without narrower integers as they are")
+}
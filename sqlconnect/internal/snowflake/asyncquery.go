@@ -0,0 +1,89 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/snowflakedb/gosnowflake"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+const sfErrQueryCanceled = 604 // sql execution canceled
+
+// asyncQueries runs queries asynchronously using the driver's async mode, fetching their results by query id
+var asyncQueries = base.AsyncQueries{
+	DatabaseType:  DatabaseType,
+	SubmitContext: submitContext,
+	Status:        queryStatus,
+	FetchContext: func(ctx context.Context, handle sqlconnect.QueryHandle) context.Context {
+		return gosnowflake.WithFetchResultByID(ctx, handle.ID)
+	},
+	Cancel: cancelQuery,
+}
+
+// submitContext returns a context for submitting a query in async mode, receiving its query id from the driver
+func submitContext(ctx context.Context) (context.Context, func() (string, string)) {
+	queryID := make(chan string, 1) // the driver sends the query id once and closes the channel
+	return gosnowflake.WithAsyncMode(gosnowflake.WithQueryIDChan(ctx, queryID)), awaitQueryID(ctx, queryID)
+}
+
+// awaitQueryID returns a function waiting for the query id reported by the driver until the context is done, since the driver may report it after returning the rows of the query
+func awaitQueryID(ctx context.Context, queryID <-chan string) func() (string, string) {
+	return func() (string, string) {
+		select {
+		case id := <-queryID:
+			return id, ""
+		case <-ctx.Done():
+			return "", ""
+		}
+	}
+}
+
+// queryStatus returns the status of a query using snowflake's monitoring api, which reports the status of queries that are not complete yet as errors
+func queryStatus(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) (sqlconnect.QueryStatus, error) {
+	conn, ok := driverConn.(gosnowflake.SnowflakeConnection)
+	if !ok {
+		return sqlconnect.QueryStatus{}, fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	_, err := conn.GetQueryStatus(ctx, handle.ID)
+	if err == nil {
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateSucceeded}, nil
+	}
+	sfErr, ok := errors.AsType[*gosnowflake.SnowflakeError](err)
+	if !ok {
+		return sqlconnect.QueryStatus{}, err
+	}
+	switch {
+	case sfErr.Number == gosnowflake.ErrQueryIsRunning:
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateRunning}, nil
+	case sfErr.Number == gosnowflake.ErrQueryStatus && len(sfErr.MessageArgs) == 2: // the query has failed with an error code and message
+		code, _ := strconv.Atoi(fmt.Sprint(sfErr.MessageArgs[0]))
+		queryErr := &gosnowflake.SnowflakeError{Number: code, Message: fmt.Sprint(sfErr.MessageArgs[1]), QueryID: handle.ID}
+		if code == sfErrQueryCanceled {
+			return sqlconnect.QueryStatus{State: sqlconnect.QueryStateCanceled, Err: queryErr}, nil
+		}
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateFailed, Err: queryErr}, nil
+	case sfErr.Number == gosnowflake.ErrQueryReportedError: // the query has failed or has been aborted, as reported by its status, e.g. [ABORTED]
+		if strings.Contains(sfErr.Message, "[ABORT") {
+			return sqlconnect.QueryStatus{State: sqlconnect.QueryStateCanceled, Err: sfErr}, nil
+		}
+		return sqlconnect.QueryStatus{State: sqlconnect.QueryStateFailed, Err: sfErr}, nil
+	}
+	return sqlconnect.QueryStatus{}, err
+}
+
+// cancelQuery cancels a query using SYSTEM$CANCEL_QUERY, which is allowed for the queries of the same user
+func cancelQuery(ctx context.Context, driverConn any, handle sqlconnect.QueryHandle) error {
+	conn, ok := driverConn.(driver.ExecerContext)
+	if !ok {
+		return fmt.Errorf("unexpected driver connection: %T", driverConn)
+	}
+	_, err := conn.ExecContext(ctx, "SELECT SYSTEM$CANCEL_QUERY(?)", []driver.NamedValue{{Ordinal: 1, Value: handle.ID}})
+	return err
+}
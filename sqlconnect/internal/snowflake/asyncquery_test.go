@@ -0,0 +1,28 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAwaitQueryID(t *testing.T) {
+	t.Run("reported late", func(t *testing.T) {
+		queryID := make(chan string, 1)
+		time.AfterFunc(10*time.Millisecond, func() { queryID <- "01b2c3d4" })
+
+		id, location := awaitQueryID(context.Background(), queryID)()
+		require.Equal(t, "01b2c3d4", id, "it should wait for the query id")
+		require.Empty(t, location)
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		id, _ := awaitQueryID(ctx, make(chan string))()
+		require.Empty(t, id, "it should stop waiting once the context is done")
+	})
+}
@@ -0,0 +1,62 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/snowflakedb/gosnowflake"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// InsertRows inserts rows into the given table using array binding
+func (db *DB) InsertRows(ctx context.Context, relation sqlconnect.RelationRef, columns []string, rows iter.Seq[[]any], opts ...sqlconnect.Option) error {
+	return base.InsertRows(ctx, relation, columns, rows, db.insertArrays, opts...)
+}
+
+// insertArrays inserts a batch of rows by binding one array per column. The driver takes care of uploading the arrays to a stage if the batch is large enough.
+func (db *DB) insertArrays(ctx context.Context, relation sqlconnect.RelationRef, columns []string, batch [][]any) error {
+	arrays := make([][]any, len(columns))
+	for i := range arrays {
+		arrays[i] = make([]any, len(batch))
+	}
+	for r, row := range batch {
+		for c, value := range row {
+			arrays[c][r] = arrayBindValue(value)
+		}
+	}
+	args := make([]any, len(columns))
+	for i := range arrays {
+		args[i] = gosnowflake.Array(&arrays[i])
+	}
+	stmt := fmt.Sprintf("INSERT INTO %[1]s (%[2]s) VALUES (%[3]s)",
+		db.QuoteTable(relation),
+		strings.Join(lo.Map(columns, func(col string, _ int) string { return db.QuoteIdentifier(col) }), ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	)
+	if _, err := db.ExecContext(ctx, stmt, args...); err != nil {
+		return err
+	}
+	return nil
+}
+
+// arrayBindValue converts a value to one of the types that the driver supports for binding []any arrays.
+// Unsupported types are converted to their string representation and left for snowflake to cast.
+func arrayBindValue(value any) any {
+	switch v := value.(type) {
+	case nil, int, int32, int64, float32, float64, bool, string, []byte:
+		return v
+	case json.RawMessage:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -1,6 +1,7 @@
 package snowflake
 
 import (
+	"context"
 	"crypto/rsa"
 	"encoding/json"
 	"encoding/pem"
@@ -102,6 +103,11 @@ func (c Config) ConnectionString() (dsn string, err error) {
 }
 
 func (c *Config) Parse(configJSON json.RawMessage) error {
+	return c.ParseContext(context.Background(), configJSON)
+}
+
+// ParseContext parses the given JSON into the config, aborting the validation of its hosts if the context is done before it completes
+func (c *Config) ParseContext(ctx context.Context, configJSON json.RawMessage) error {
 	if err := json.Unmarshal(configJSON, c); err != nil {
 		return err
 	}
@@ -114,7 +120,7 @@ func (c *Config) Parse(configJSON json.RawMessage) error {
 	// Host is optional for Snowflake — the driver derives it from Account when
 	// it is empty — so only validate what was actually supplied.
 	if c.Host != "" {
-		return util.ValidateHost(c.Host)
+		return util.ValidateHostContext(ctx, c.Host)
 	}
 	return nil
 }
@@ -1,9 +1,12 @@
 package snowflake
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/samber/lo"
 	_ "github.com/snowflakedb/gosnowflake" // snowflake driver
@@ -19,8 +22,13 @@ const (
 
 // NewDB creates a new snowflake db client
 func NewDB(configJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), configJSON)
+}
+
+// NewDBContext creates a new snowflake db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, configJSON json.RawMessage) (*DB, error) {
 	var config Config
-	err := config.Parse(configJSON)
+	err := config.ParseContext(ctx, configJSON)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +49,13 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 			base.WithDialect(newDialect()),
 			base.WithColumnTypeMapper(getColumnTypeMapper(config)),
 			base.WithJsonRowMapper(getJonRowMapper(config)),
+			base.WithTypeTreeMapper(typeTreeMapper),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("snowflake", config.DBName),
+			base.WithQueryTagContext(queryTagContext),
+			base.WithAsyncQueries(asyncQueries),
+			base.WithArrowQuery(queryArrow),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.CurrentCatalog = func() string {
 					return "SELECT current_database()"
@@ -98,6 +113,40 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 				cmds.RenameTable = func(schema, oldName, newName base.QuotedIdentifier) string {
 					return fmt.Sprintf(`ALTER TABLE %[1]s.%[2]s RENAME TO %[1]s.%[3]s`, schema, oldName, newName)
 				}
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					stmt, _, _ := cmds.ListColumns(catalog, schema, table)
+					return stmt, base.ColumnDetailsColumns{
+						Name:       "name",
+						Type:       "type",
+						Nullable:   "null?",
+						Default:    "default",
+						Comment:    "comment",
+						PrimaryKey: "primary key",
+					}
+				}
+				cmds.CreateTable = func(table base.QuotedIdentifier, columns []string, primaryKey []base.QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []base.QuotedIdentifier) (string, error) {
+					if len(partitionBy) > 0 {
+						return "", fmt.Errorf("partitioning tables: %w", sqlconnect.ErrNotSupported)
+					}
+					stmt := base.CreateTableCommand(table, ifNotExists, slices.Concat(columns, base.PrimaryKeyConstraint(primaryKey)))
+					if len(clusterBy) > 0 {
+						stmt += fmt.Sprintf(" CLUSTER BY (%s)", base.JoinQuotedIdentifiers(clusterBy, ""))
+					}
+					return stmt, nil
+				}
+				cmds.AddColumns = func(table base.QuotedIdentifier, columns []string) []string {
+					return []string{fmt.Sprintf("ALTER TABLE %[1]s ADD COLUMN %[2]s", table, strings.Join(columns, ", "))}
+				}
+				cmds.DropColumns = func(table base.QuotedIdentifier, columns []base.QuotedIdentifier) []string {
+					return []string{fmt.Sprintf("ALTER TABLE %[1]s DROP COLUMN %[2]s", table, base.JoinQuotedIdentifiers(columns, ""))}
+				}
+				cmds.AlterColumnType = func(_, _ base.QuotedIdentifier, _, _, _ string) ([]string, error) {
+					return nil, fmt.Errorf("snowflake can only increase the length or precision of a column without changing its type: %w", sqlconnect.ErrNotSupported)
+				}
+				cmds.ExplainQuery = func(sql string) (string, func(string) (sqlconnect.QueryEstimate, error)) {
+					return "EXPLAIN USING JSON " + base.TrimQuery(sql), parseQueryPlan
+				}
+				cmds.Merge = base.MergeInto
 				return cmds
 			}),
 		),
@@ -105,8 +154,8 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -114,6 +163,11 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 func getColumnTypeMapper(config Config) func(base.ColumnType) string {
 	if config.UseLegacyMappings {
 		return legacyColumnTypeMapper
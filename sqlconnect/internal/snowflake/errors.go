@@ -0,0 +1,56 @@
+package snowflake
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/snowflakedb/gosnowflake"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+const (
+	sfErrStatementTimeout       = 630    // statement reached its statement or warehouse timeout
+	sfErrSyntax                 = 1003   // syntax error
+	sfErrObjectNotFound         = 2003   // object does not exist or not authorized
+	sfErrObjectNotAccessible    = 2043   // object does not exist, or operation cannot be performed
+	sfErrInsufficientPrivileges = 3001   // insufficient privileges to operate on an object
+	sfErrIncorrectCredentials   = 390100 // incorrect username or password
+	sfErrInvalidJWT             = 390144 // jwt token is invalid
+	sfErrInvalidOAuthToken      = 390303 // invalid oauth access token
+	sfErrExpiredOAuthToken      = 390318 // oauth access token expired
+)
+
+// schemaNotFoundRegex matches the messages of [sfErrObjectNotFound] errors for missing databases and schemas, e.g. Schema 'DB.S' does not exist or not authorized
+var schemaNotFoundRegex = regexp.MustCompile(`(?i)\b(database|schema) '[^']*' does not exist`)
+
+// classifyError classifies snowflake errors using their error numbers.
+// Snowflake doesn't distinguish between objects that don't exist and objects that the user is not authorized to access, thus both are reported as not found.
+func classifyError(err error) []error {
+	sfErr, ok := errors.AsType[*gosnowflake.SnowflakeError](err)
+	if !ok {
+		return nil
+	}
+	switch sfErr.Number {
+	case sfErrIncorrectCredentials, sfErrInvalidJWT, sfErrInvalidOAuthToken, sfErrExpiredOAuthToken:
+		return []error{sqlconnect.ErrAuthentication}
+	case sfErrInsufficientPrivileges:
+		return []error{sqlconnect.ErrPermissionDenied}
+	case sfErrObjectNotFound:
+		if schemaNotFoundRegex.MatchString(sfErr.Error()) {
+			return []error{sqlconnect.ErrSchemaNotFound}
+		}
+		return []error{sqlconnect.ErrRelationNotFound}
+	case sfErrObjectNotAccessible:
+		return []error{sqlconnect.ErrSchemaNotFound}
+	case sfErrSyntax:
+		return []error{sqlconnect.ErrSyntax}
+	case sfErrStatementTimeout:
+		return []error{sqlconnect.ErrQueryTimeout}
+	}
+	if strings.HasPrefix(sfErr.SQLState, "08") { // connection exception
+		return []error{sqlconnect.ErrRetryable}
+	}
+	return nil
+}
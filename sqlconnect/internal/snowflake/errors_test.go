@@ -0,0 +1,34 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "table not found", err: &gosnowflake.SnowflakeError{Number: 2003, SQLState: "42S02", Message: "SQL compilation error:\nObject 'DB.S.T' does not exist or not authorized."}, expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "schema not found", err: &gosnowflake.SnowflakeError{Number: 2003, SQLState: "02000", Message: "SQL compilation error:\nSchema 'DB.S' does not exist or not authorized."}, expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "database not found", err: &gosnowflake.SnowflakeError{Number: 2043, SQLState: "02000", Message: "SQL compilation error:\nObject does not exist, or operation cannot be performed."}, expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "syntax error", err: &gosnowflake.SnowflakeError{Number: 1003, SQLState: "42000"}, expected: []error{sqlconnect.ErrSyntax}},
+		{name: "insufficient privileges", err: &gosnowflake.SnowflakeError{Number: 3001, SQLState: "42501"}, expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "incorrect credentials", err: &gosnowflake.SnowflakeError{Number: 390100, SQLState: "08004"}, expected: []error{sqlconnect.ErrAuthentication}},
+		{name: "statement timeout", err: fmt.Errorf("querying: %w", &gosnowflake.SnowflakeError{Number: 630, SQLState: "57014"}), expected: []error{sqlconnect.ErrQueryTimeout}},
+		{name: "connection exception", err: &gosnowflake.SnowflakeError{Number: 260008, SQLState: "08001"}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// queryPlan is the plan returned by EXPLAIN USING JSON
+type queryPlan struct {
+	GlobalStats *struct {
+		PartitionsTotal    int64 `json:"partitionsTotal"`
+		PartitionsAssigned int64 `json:"partitionsAssigned"`
+		BytesAssigned      int64 `json:"bytesAssigned"`
+	} `json:"GlobalStats"`
+}
+
+// parseQueryPlan extracts an estimate out of a plan returned by EXPLAIN USING JSON, using the bytes and micro-partitions assigned to the query after pruning
+func parseQueryPlan(plan string) (sqlconnect.QueryEstimate, error) {
+	var p queryPlan
+	if err := json.Unmarshal([]byte(plan), &p); err != nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("unmarshalling plan: %w", err)
+	}
+	if p.GlobalStats == nil {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("no GlobalStats found in plan")
+	}
+	return sqlconnect.QueryEstimate{
+		Bytes:      &p.GlobalStats.BytesAssigned,
+		Partitions: &p.GlobalStats.PartitionsAssigned,
+	}, nil
+}
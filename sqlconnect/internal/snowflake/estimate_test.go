@@ -0,0 +1,27 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Run("global stats", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`{"GlobalStats":{"partitionsTotal":12,"partitionsAssigned":3,"bytesAssigned":4096},"Operations":[[{"id":0,"operation":"Result","expressions":["T.A"]},{"id":1,"parentOperators":[0],"operation":"TableScan","objects":["DB.PUBLIC.T"],"partitionsAssigned":3,"partitionsTotal":12,"bytesAssigned":4096}]]}`)
+		require.NoError(t, err)
+		require.Nil(t, estimate.Rows)
+		require.NotNil(t, estimate.Bytes)
+		require.EqualValues(t, 4096, *estimate.Bytes)
+		require.NotNil(t, estimate.Partitions)
+		require.EqualValues(t, 3, *estimate.Partitions)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := parseQueryPlan(`GlobalStats:`)
+		require.Error(t, err)
+
+		_, err = parseQueryPlan(`{"Operations":[]}`)
+		require.ErrorContains(t, err, "no GlobalStats found in plan")
+	})
+}
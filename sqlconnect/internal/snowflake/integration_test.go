@@ -1,6 +1,7 @@
 package snowflake_test
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -25,6 +26,12 @@ func TestSnowflakeDB(t *testing.T) {
 		strings.ToUpper,
 		integrationtest.Options{
 			LegacySupport: true,
+			LongRunningQuery: func(marker string) string {
+				return fmt.Sprintf("SELECT '%s', SYSTEM$WAIT(120)", marker)
+			},
+			RunningQueries: func(marker string) string {
+				return fmt.Sprintf("SELECT COUNT(*) FROM TABLE(INFORMATION_SCHEMA.QUERY_HISTORY()) WHERE EXECUTION_STATUS IN ('RUNNING', 'QUEUED', 'RESUMING_WAREHOUSE') AND QUERY_TEXT LIKE '%%%s%%' AND QUERY_TEXT NOT LIKE '%%QUERY_HISTORY%%'", marker)
+			},
 		},
 	)
 }
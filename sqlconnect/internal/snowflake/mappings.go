@@ -10,6 +10,7 @@ import (
 
 	"github.com/dlclark/regexp2"
 
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
@@ -50,6 +51,9 @@ var columnTypeMappings = map[string]string{
 	"ARRAY":            "array",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "INT", "FLOAT", "VARCHAR", "TIMESTAMP_TZ", "BOOLEAN", "VARIANT")
+
 var (
 	re              = regexp.MustCompile(`(\(.+\)|<.+>)`) // remove type parameters [<>] and size constraints [()]
 	numberPrecision = regexp.MustCompile(`NUMBER\((?P<precision>\d+),(?P<scale>\d+)\)`)
@@ -79,6 +83,26 @@ func columnTypeMapper(columnType base.ColumnType) string {
 	return databaseTypeName
 }
 
+// typeTreeMapper parses the type trees of ARRAY, OBJECT, MAP and VARIANT columns.
+// Semi-structured ARRAY and OBJECT columns hold VARIANT values, unlike structured ones which declare their nested types, e.g. ARRAY(NUMBER).
+func typeTreeMapper(columnType base.ColumnType) *sqlconnect.TypeNode {
+	tree := base.NestedTypeTree(columnType.DatabaseTypeName(), "VARIANT")
+	variant := func() *sqlconnect.TypeNode {
+		return &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindVariant, RawType: "VARIANT", Nullable: true}
+	}
+	base.WalkTypeTree(tree, func(node *sqlconnect.TypeNode) {
+		switch {
+		case node.Kind == sqlconnect.TypeKindArray && node.Element == nil:
+			node.Element = variant()
+		case node.Kind == sqlconnect.TypeKindStruct && len(node.Fields) == 0 && strings.EqualFold(node.RawType, "OBJECT"):
+			node.Kind = sqlconnect.TypeKindMap
+			node.Key = &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "VARCHAR"}
+			node.Value = variant()
+		}
+	})
+	return tree
+}
+
 // check https://godoc.org/github.com/snowflakedb/gosnowflake#hdr-Supported_Data_Types for handling snowflake data types
 func jsonRowMapper(databaseTypeName string, value any) any {
 	if value == nil {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 )
 
 // mockColumnType implements base.ColumnType for testing the column type mapper.
@@ -33,6 +35,41 @@ func TestColumnTypeMapper(t *testing.T) {
 	})
 }
 
+func TestTypeTreeMapper(t *testing.T) {
+	variant := &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindVariant, RawType: "VARIANT", Nullable: true}
+
+	t.Run("scalars have no type tree", func(t *testing.T) {
+		require.Nil(t, typeTreeMapper(mockColumnType{"NUMBER(38,0)"}))
+	})
+
+	t.Run("semi-structured types hold variants", func(t *testing.T) {
+		require.Equal(t, variant, typeTreeMapper(mockColumnType{"VARIANT"}))
+		require.Equal(t, &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindArray, RawType: "ARRAY", Nullable: true, Element: variant}, typeTreeMapper(mockColumnType{"ARRAY"}))
+		require.Equal(t, &sqlconnect.TypeNode{
+			Kind:     sqlconnect.TypeKindMap,
+			RawType:  "OBJECT",
+			Nullable: true,
+			Key:      &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "VARCHAR"},
+			Value:    variant,
+		}, typeTreeMapper(mockColumnType{"OBJECT"}))
+	})
+
+	t.Run("structured types declare their nested types", func(t *testing.T) {
+		tree := typeTreeMapper(mockColumnType{"OBJECT(a NUMBER(38,0) NOT NULL, b ARRAY(VARCHAR(16777216)))"})
+		require.Equal(t, sqlconnect.TypeKindStruct, tree.Kind)
+		require.Equal(t, []sqlconnect.TypeNode{
+			{Kind: sqlconnect.TypeKindScalar, RawType: "NUMBER(38,0)", Name: "a"},
+			{
+				Kind:     sqlconnect.TypeKindArray,
+				RawType:  "ARRAY(VARCHAR(16777216))",
+				Name:     "b",
+				Nullable: true,
+				Element:  &sqlconnect.TypeNode{Kind: sqlconnect.TypeKindScalar, RawType: "VARCHAR(16777216)", Nullable: true},
+			},
+		}, tree.Fields)
+	})
+}
+
 func TestUndefinedInArray(t *testing.T) {
 	r, err := undefinedInArray.Replace("[\n  1,\n  2,\n  3,\n  undefined\n]", "${1}null", 0, -1)
 	require.NoError(t, err)
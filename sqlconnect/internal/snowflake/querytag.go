@@ -0,0 +1,17 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/snowflakedb/gosnowflake"
+)
+
+// queryTagContext sets the query tags as the QUERY_TAG of the queries performed with the context, formatted as a json object, overriding the QUERY_TAG of the configuration
+func queryTagContext(ctx context.Context, tags map[string]string) context.Context {
+	tag, err := json.Marshal(tags)
+	if err != nil {
+		return ctx
+	}
+	return gosnowflake.WithQueryTag(ctx, string(tag))
+}
@@ -33,16 +33,11 @@ func (db *DB) ListSchemas(ctx context.Context, opts ...sqlconnect.Option) ([]sql
 	return schemas, nil
 }
 
-const (
-	sfErrObjectNotFound         = 2043
-	sfErrInsufficientPrivileges = 2003
-)
-
+// isObjectInaccessibleError returns true if the error is caused by an object that either doesn't exist or is not accessible
 func isObjectInaccessibleError(err error) bool {
 	if sfErr, ok := errors.AsType[*gosnowflake.SnowflakeError](err); ok {
 		switch sfErr.Number {
-		case sfErrObjectNotFound,
-			sfErrInsufficientPrivileges:
+		case sfErrObjectNotFound, sfErrObjectNotAccessible:
 			return true
 		}
 	}
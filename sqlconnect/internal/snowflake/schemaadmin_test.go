@@ -0,0 +1,28 @@
+package snowflake
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsObjectInaccessibleError(t *testing.T) {
+	t.Run("object not found without a schema in its message", func(t *testing.T) {
+		err := &gosnowflake.SnowflakeError{Number: 2003, SQLState: "02000", Message: "SQL compilation error:\nObject 'DB.INFORMATION_SCHEMA.TABLES' does not exist or not authorized."}
+		require.False(t, schemaNotFoundRegex.MatchString(err.Error()))
+		require.True(t, isObjectInaccessibleError(fmt.Errorf("listing tables: %w", err)), "it should match any object not found error")
+	})
+
+	t.Run("object not accessible", func(t *testing.T) {
+		err := &gosnowflake.SnowflakeError{Number: 2043, SQLState: "02000", Message: "SQL compilation error:\nObject does not exist, or operation cannot be performed."}
+		require.True(t, isObjectInaccessibleError(err))
+	})
+
+	t.Run("other errors", func(t *testing.T) {
+		require.False(t, isObjectInaccessibleError(&gosnowflake.SnowflakeError{Number: 1003, SQLState: "42000"}))
+		require.False(t, isObjectInaccessibleError(errors.New("other")))
+	})
+}
@@ -3,7 +3,10 @@ package snowflake
 import (
 	"context"
 
+	"github.com/snowflakedb/gosnowflake"
+
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
 // ListTables overrides the base implementation to handle nonexistent catalog gracefully
@@ -17,3 +20,10 @@ func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts
 	}
 	return tables, nil
 }
+
+// ListColumnsForSqlQuery overrides the base implementation to describe the query without executing it, using a describe-only query
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.DB.ListColumnsForSqlQueryUsing(ctx, sql, func(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+		return db.QueryResultColumns(gosnowflake.WithDescribeOnly(ctx), sql)
+	}, opts...)
+}
@@ -0,0 +1,137 @@
+package sshtunnel
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// validateAuthSettings checks if the authentication settings of an ssh server are valid, i.e. that at least one authentication method is configured
+func validateAuthSettings(hop JumpHost) error {
+	if hop.PrivateKey == "" && hop.Password == "" && hop.AgentSocket == "" {
+		return fmt.Errorf("ssh private key, password or agent socket is required")
+	}
+	if hop.PrivateKey == "" && hop.PrivateKeyPassphrase != "" {
+		return fmt.Errorf("ssh private key is required for its passphrase")
+	}
+	if hop.PrivateKey == "" && hop.Certificate != "" {
+		return fmt.Errorf("ssh private key is required for its certificate")
+	}
+	return nil
+}
+
+// hopAuth authenticates to an ssh server using the authentication methods configured for it:
+//   - public keys, i.e. the private key, signed by its certificate if any, followed by the keys of the ssh agent.
+//   - password, which is used for keyboard-interactive authentication too, answering password prompts.
+type hopAuth struct {
+	signer      ssh.Signer // the signer of the private key, if any
+	password    string
+	agentSocket string
+
+	agentConn    net.Conn // the connection to the ssh agent, established on authentication
+	agentSigners []ssh.Signer
+}
+
+// newHopAuth parses the private key and certificate of an ssh server, if any
+func newHopAuth(hop JumpHost) (*hopAuth, error) {
+	a := &hopAuth{password: hop.Password, agentSocket: hop.AgentSocket}
+	if hop.PrivateKey == "" {
+		return a, nil
+	}
+	var err error
+	if hop.PrivateKeyPassphrase != "" {
+		a.signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(hop.PrivateKey), []byte(hop.PrivateKeyPassphrase))
+	} else {
+		a.signer, err = ssh.ParsePrivateKey([]byte(hop.PrivateKey))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing private key: %w", err)
+	}
+	if hop.Certificate == "" {
+		return a, nil
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hop.Certificate))
+	if err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+	cert, ok := key.(*ssh.Certificate)
+	if !ok || cert.CertType != ssh.UserCert {
+		return nil, fmt.Errorf("parsing certificate: not a user certificate")
+	}
+	if a.signer, err = ssh.NewCertSigner(cert, a.signer); err != nil {
+		return nil, fmt.Errorf("parsing certificate: %w", err)
+	}
+	return a, nil
+}
+
+// methods returns the authentication methods, in the order they are tried
+func (a *hopAuth) methods() []ssh.AuthMethod {
+	var methods []ssh.AuthMethod
+	if a.signer != nil || a.agentSocket != "" {
+		// a single method for all public keys, since methods of the same type are only tried once
+		methods = append(methods, ssh.PublicKeysCallback(a.publicKeys))
+	}
+	if a.password != "" {
+		methods = append(methods,
+			ssh.Password(a.password),
+			ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+				return a.keyboardInteractiveAnswers(questions), nil
+			}),
+		)
+	}
+	return methods
+}
+
+// keyboardInteractiveAnswers answers the password prompts among the questions with the password,
+// leaving the answers of any other questions, e.g. one-time codes, empty for not disclosing the password to them
+func (a *hopAuth) keyboardInteractiveAnswers(questions []string) []string {
+	answers := make([]string, len(questions))
+	for i, question := range questions {
+		if strings.Contains(strings.ToLower(question), "password") {
+			answers[i] = a.password
+		}
+	}
+	return answers
+}
+
+// publicKeys returns the signer of the private key followed by the signers of the ssh agent, connecting to it on first use.
+// Failing to use the agent is only reported if there is no private key or password to authenticate with instead.
+func (a *hopAuth) publicKeys() ([]ssh.Signer, error) {
+	var signers []ssh.Signer
+	if a.signer != nil {
+		signers = append(signers, a.signer)
+	}
+	if a.agentSocket == "" {
+		return signers, nil
+	}
+	if a.agentConn == nil {
+		conn, err := net.Dial("unix", a.agentSocket)
+		if err != nil {
+			return signers, a.agentError(fmt.Errorf("connecting to ssh agent: %w", err))
+		}
+		a.agentConn = conn
+		if a.agentSigners, err = agent.NewClient(conn).Signers(); err != nil {
+			return signers, a.agentError(fmt.Errorf("listing ssh agent keys: %w", err))
+		}
+	}
+	return append(signers, a.agentSigners...), nil
+}
+
+// agentError returns the error of using the ssh agent, unless there is a private key or password to authenticate with instead,
+// since returning an error aborts the authentication without trying any other method
+func (a *hopAuth) agentError(err error) error {
+	if a.signer != nil || a.password != "" {
+		return nil
+	}
+	return err
+}
+
+// close closes the connection to the ssh agent, which is only needed while authenticating
+func (a *hopAuth) close() {
+	if a.agentConn != nil {
+		_ = a.agentConn.Close()
+	}
+}
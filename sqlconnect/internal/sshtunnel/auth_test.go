@@ -0,0 +1,201 @@
+package sshtunnel
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestHopAuth(t *testing.T) {
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(mustPEM(t)(ssh.MarshalPrivateKey(clientKey, "")))
+	encryptedPrivateKey := pem.EncodeToMemory(mustPEM(t)(ssh.MarshalPrivateKeyWithPassphrase(clientKey, "", []byte("passphrase"))))
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+	cert := &ssh.Certificate{Key: clientSigner.PublicKey(), CertType: ssh.UserCert, ValidPrincipals: []string{"user"}, ValidBefore: ssh.CertTimeInfinity}
+	require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+	certificate := string(ssh.MarshalAuthorizedKey(cert))
+
+	publicKeyCallback := func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+		if bytes.Equal(key.Marshal(), clientSigner.PublicKey().Marshal()) {
+			return nil, nil
+		}
+		return nil, errors.New("unknown public key")
+	}
+	certChecker := &ssh.CertChecker{
+		IsUserAuthority: func(auth ssh.PublicKey) bool { return bytes.Equal(auth.Marshal(), caSigner.PublicKey().Marshal()) },
+	}
+	passwordCallback := func(_ ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+		if string(password) == "password" {
+			return nil, nil
+		}
+		return nil, errors.New("wrong password")
+	}
+	keyboardInteractiveCallback := func(_ ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+		answers, err := challenge("", "", []string{"Password: "}, []bool{false})
+		if err != nil || len(answers) != 1 || answers[0] != "password" {
+			return nil, errors.New("wrong password")
+		}
+		return nil, nil
+	}
+
+	agentSocket := filepath.Join(t.TempDir(), "agent.sock")
+	keyring := agent.NewKeyring()
+	require.NoError(t, keyring.Add(agent.AddedKey{PrivateKey: clientKey}))
+	l, err := net.Listen("unix", agentSocket)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() { _ = agent.ServeAgent(keyring, conn) }()
+		}
+	}()
+
+	for _, tc := range []struct {
+		name   string
+		hop    JumpHost
+		server ssh.ServerConfig
+		err    string
+	}{
+		{name: "private key", hop: JumpHost{PrivateKey: string(privateKey)}, server: ssh.ServerConfig{PublicKeyCallback: publicKeyCallback}},
+		{name: "encrypted private key", hop: JumpHost{PrivateKey: string(encryptedPrivateKey), PrivateKeyPassphrase: "passphrase"}, server: ssh.ServerConfig{PublicKeyCallback: publicKeyCallback}},
+		{name: "certificate", hop: JumpHost{PrivateKey: string(privateKey), Certificate: certificate}, server: ssh.ServerConfig{PublicKeyCallback: certChecker.Authenticate}},
+		{name: "private key without certificate", hop: JumpHost{PrivateKey: string(privateKey)}, server: ssh.ServerConfig{PublicKeyCallback: certChecker.Authenticate}, err: "unable to authenticate"},
+		{name: "password", hop: JumpHost{Password: "password"}, server: ssh.ServerConfig{PasswordCallback: passwordCallback}},
+		{name: "wrong password", hop: JumpHost{Password: "wrong"}, server: ssh.ServerConfig{PasswordCallback: passwordCallback}, err: "unable to authenticate"},
+		{name: "keyboard interactive", hop: JumpHost{Password: "password"}, server: ssh.ServerConfig{KeyboardInteractiveCallback: keyboardInteractiveCallback}},
+		{name: "password after private key", hop: JumpHost{PrivateKey: string(privateKey), Password: "password"}, server: ssh.ServerConfig{PublicKeyCallback: certChecker.Authenticate, PasswordCallback: passwordCallback}},
+		{name: "agent", hop: JumpHost{AgentSocket: agentSocket}, server: ssh.ServerConfig{PublicKeyCallback: publicKeyCallback}},
+		{name: "unavailable agent", hop: JumpHost{AgentSocket: agentSocket + ".missing"}, server: ssh.ServerConfig{PublicKeyCallback: publicKeyCallback}, err: "connecting to ssh agent"},
+		{name: "unavailable agent with password", hop: JumpHost{Password: "password", AgentSocket: agentSocket + ".missing"}, server: ssh.ServerConfig{PasswordCallback: passwordCallback}},
+		{name: "unavailable agent with private key", hop: JumpHost{PrivateKey: string(privateKey), AgentSocket: agentSocket + ".missing"}, server: ssh.ServerConfig{PublicKeyCallback: publicKeyCallback}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.hop.User, tc.hop.Host, tc.hop.Port = "user", "host", "22"
+			require.NoError(t, validateAuthSettings(tc.hop))
+			auth, err := newHopAuth(tc.hop)
+			require.NoError(t, err)
+			verifier, err := newHostKeyVerifier(tc.hop)
+			require.NoError(t, err)
+
+			client, err := dialHop(context.Background(), serverDialer(t, &tc.server), tc.hop, auth, verifier)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err, "it should authenticate")
+			require.NoError(t, client.Close())
+		})
+	}
+
+	t.Run("keyboard interactive answers", func(t *testing.T) {
+		auth := &hopAuth{password: "password"}
+		require.Equal(t, []string{"password"}, auth.keyboardInteractiveAnswers([]string{"user's Password: "}), "it should answer password prompts with the password")
+		require.Equal(t, []string{""}, auth.keyboardInteractiveAnswers([]string{"Verification code: "}), "it should not answer other prompts with the password, even if asked alone")
+		require.Equal(t, []string{"password", ""}, auth.keyboardInteractiveAnswers([]string{"Password: ", "Verification code: "}), "it should only answer password prompts among several questions")
+		require.Empty(t, auth.keyboardInteractiveAnswers(nil), "it should answer no questions")
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			hop  JumpHost
+			err  string
+		}{
+			{name: "wrong passphrase", hop: JumpHost{PrivateKey: string(encryptedPrivateKey), PrivateKeyPassphrase: "wrong"}, err: "parsing private key"},
+			{name: "missing passphrase", hop: JumpHost{PrivateKey: string(encryptedPrivateKey)}, err: "parsing private key: ssh: this private key is passphrase protected"},
+			{name: "not a certificate", hop: JumpHost{PrivateKey: string(privateKey), Certificate: string(ssh.MarshalAuthorizedKey(clientSigner.PublicKey()))}, err: "parsing certificate: not a user certificate"},
+			{name: "certificate of another key", hop: JumpHost{PrivateKey: string(encryptedPrivateKey), PrivateKeyPassphrase: "passphrase", Certificate: string(ssh.MarshalAuthorizedKey(otherCertificate(t, caSigner)))}, err: "parsing certificate"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := newHopAuth(tc.hop)
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+
+		for _, tc := range []struct {
+			name string
+			hop  JumpHost
+			err  string
+		}{
+			{name: "no authentication method", hop: JumpHost{}, err: "ssh private key, password or agent socket is required"},
+			{name: "passphrase without private key", hop: JumpHost{Password: "password", PrivateKeyPassphrase: "passphrase"}, err: "ssh private key is required for its passphrase"},
+			{name: "certificate without private key", hop: JumpHost{AgentSocket: agentSocket, Certificate: certificate}, err: "ssh private key is required for its certificate"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				require.ErrorContains(t, validateAuthSettings(tc.hop), tc.err)
+			})
+		}
+	})
+}
+
+// serverDialer returns a dial function connecting to an ssh server listening on loopback, using the config
+func serverDialer(t *testing.T, config *ssh.ServerConfig) func(context.Context, string, string) (net.Conn, error) {
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	require.NoError(t, err)
+	config.AddHostKey(hostSigner)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				if _, chans, reqs, err := ssh.NewServerConn(conn, config); err == nil {
+					go ssh.DiscardRequests(reqs)
+					for newChannel := range chans {
+						_ = newChannel.Reject(ssh.Prohibited, "no channels")
+					}
+				}
+			}()
+		}
+	}()
+	return func(ctx context.Context, network, _ string) (net.Conn, error) {
+		var dialer net.Dialer
+		return dialer.DialContext(ctx, network, l.Addr().String())
+	}
+}
+
+func otherCertificate(t *testing.T, caSigner ssh.Signer) *ssh.Certificate {
+	t.Helper()
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(publicKey)
+	require.NoError(t, err)
+	cert := &ssh.Certificate{Key: key, CertType: ssh.UserCert, ValidPrincipals: []string{"user"}, ValidBefore: ssh.CertTimeInfinity}
+	require.NoError(t, cert.SignCert(rand.Reader, caSigner))
+	return cert
+}
+
+func mustPEM(t *testing.T) func(*pem.Block, error) *pem.Block {
+	return func(block *pem.Block, err error) *pem.Block {
+		require.NoError(t, err)
+		return block
+	}
+}
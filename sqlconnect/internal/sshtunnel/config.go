@@ -1,9 +1,12 @@
 package sshtunnel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/tidwall/gjson"
 
@@ -16,10 +19,89 @@ type Config struct {
 	Host       string `json:"sshHost"`
 	Port       string `json:"sshPort"`
 	PrivateKey string `json:"sshPrivateKey"`
+
+	// PrivateKeyPassphrase is the passphrase of the private key, if it is encrypted
+	PrivateKeyPassphrase string `json:"sshPrivateKeyPassphrase,omitempty"`
+	// Certificate is the OpenSSH user certificate of the private key, in authorized_keys format, e.g. the contents of id_ed25519-cert.pub
+	Certificate string `json:"sshCertificate,omitempty"`
+	// Password is used for password and keyboard-interactive authentication, in case public key authentication fails or no private key is configured
+	Password string `json:"sshPassword,omitempty"`
+	// AgentSocket is the path of an ssh agent's socket, e.g. the value of SSH_AUTH_SOCK, whose keys are used after the private key. An unreachable agent is skipped if a private key or password is configured
+	AgentSocket string `json:"sshAgentSocket,omitempty"`
+
+	// KnownHosts are the known_hosts entries, in OpenSSH's format, used for verifying the host key of the ssh server
+	KnownHosts string `json:"sshKnownHosts,omitempty"`
+	// HostKeyFingerprint is the SHA256 fingerprint of the ssh server's host key, as printed by ssh-keygen -l, e.g. SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s
+	HostKeyFingerprint string `json:"sshHostKeyFingerprint,omitempty"`
+	// HostKeyMode controls how the host key of the ssh server is verified. It defaults to [HostKeyModeStrict] if known hosts or a host key fingerprint are configured
+	// and to [HostKeyModeInsecure] otherwise, for backwards compatibility.
+	HostKeyMode HostKeyMode `json:"sshHostKeyMode,omitempty"`
+
+	// JumpHosts are the ssh servers to connect through for reaching the tunnel's ssh server, in order, like ssh's -J option:
+	// the first jump host is dialled directly, every other host, including the tunnel's ssh server, through the previous one.
+	JumpHosts []JumpHost `json:"sshJumpHosts,omitempty"`
+
+	// KeepaliveInterval is the interval of the keepalive requests sent to the ssh server for detecting lost connections, which the tunnel re-establishes transparently.
+	// It defaults to 30s, while a negative interval disables keepalive requests.
+	KeepaliveInterval time.Duration `json:"sshKeepaliveInterval,omitempty"`
+	// KeepaliveMaxMissed is the number of consecutive keepalive requests left unanswered after which the connection is considered lost. It defaults to 3.
+	KeepaliveMaxMissed int `json:"sshKeepaliveMaxMissed,omitempty"`
+	// OnStatusChange is called whenever the status of the tunnel changes, e.g. when it loses its connection, fails to reconnect or reconnects, for reporting its health.
+	// Consumers of a DB set it using the OnStatusChange method of the DB's SSHTunnel instead.
+	OnStatusChange func(Status) `json:"-"`
+}
+
+// JumpHost represents an SSH server that an SSH tunnel connects through for reaching its own SSH server.
+// Connections to it are authenticated and its host key is verified using its own settings, like the ones of [Config].
+type JumpHost struct {
+	User       string `json:"sshUser"`
+	Host       string `json:"sshHost"`
+	Port       string `json:"sshPort"`
+	PrivateKey string `json:"sshPrivateKey"`
+
+	PrivateKeyPassphrase string `json:"sshPrivateKeyPassphrase,omitempty"`
+	Certificate          string `json:"sshCertificate,omitempty"`
+	Password             string `json:"sshPassword,omitempty"`
+	AgentSocket          string `json:"sshAgentSocket,omitempty"`
+
+	KnownHosts         string      `json:"sshKnownHosts,omitempty"`
+	HostKeyFingerprint string      `json:"sshHostKeyFingerprint,omitempty"`
+	HostKeyMode        HostKeyMode `json:"sshHostKeyMode,omitempty"`
 }
 
 // Validate checks if the Config is valid.
 func (c Config) Validate() error {
+	if c.KeepaliveMaxMissed < 0 {
+		return fmt.Errorf("invalid keepalive max missed: %d", c.KeepaliveMaxMissed)
+	}
+	hops := c.hops()
+	for i, hop := range hops[:len(hops)-1] {
+		if err := hop.Validate(); err != nil {
+			return fmt.Errorf("jump host %d: %w", i, err)
+		}
+	}
+	return hops[len(hops)-1].Validate()
+}
+
+// hops returns the ssh servers to connect to, in order, i.e. the jump hosts followed by the tunnel's ssh server
+func (c Config) hops() []JumpHost {
+	return append(slices.Clone(c.JumpHosts), JumpHost{
+		User:                 c.User,
+		Host:                 c.Host,
+		Port:                 c.Port,
+		PrivateKey:           c.PrivateKey,
+		PrivateKeyPassphrase: c.PrivateKeyPassphrase,
+		Certificate:          c.Certificate,
+		Password:             c.Password,
+		AgentSocket:          c.AgentSocket,
+		KnownHosts:           c.KnownHosts,
+		HostKeyFingerprint:   c.HostKeyFingerprint,
+		HostKeyMode:          c.HostKeyMode,
+	})
+}
+
+// Validate checks if the JumpHost is valid.
+func (c JumpHost) Validate() error {
 	if c.User == "" {
 		return fmt.Errorf("ssh user is required")
 	}
@@ -32,10 +114,10 @@ func (c Config) Validate() error {
 	if _, err := strconv.Atoi(c.Port); err != nil {
 		return fmt.Errorf("invalid port: %s", c.Port)
 	}
-	if c.PrivateKey == "" {
-		return fmt.Errorf("ssh private key is required")
+	if err := validateAuthSettings(c); err != nil {
+		return err
 	}
-	return nil
+	return validateHostKeySettings(c)
 }
 
 // ParseInlineConfig parses the given data as a JSON object and returns a Config if the "useSSH" field is true.
@@ -48,7 +130,7 @@ func ParseInlineConfig(data []byte) (*Config, error) {
 	return nil, nil // nolint: nilnil
 }
 
-// ValidateHost validates the tunnel endpoint, if a tunnel is configured.
+// ValidateHost validates the tunnel endpoint, if a tunnel is configured, along with every jump host the tunnel connects through.
 //
 // The ssh host is caller-supplied like every other connection field, and the
 // tunnel is an outbound connection in its own right — so a benign warehouse
@@ -56,10 +138,20 @@ func ParseInlineConfig(data []byte) (*Config, error) {
 // same options they use for the warehouse host, so a config permitted to use
 // loopback for the database may use it for the tunnel too.
 func ValidateHost(c *Config, opts ...util.HostValidationOption) error {
+	return ValidateHostContext(context.Background(), c, opts...)
+}
+
+// ValidateHostContext is like [ValidateHost], aborting the lookup of the tunnel's hosts if the context is done before it completes.
+func ValidateHostContext(ctx context.Context, c *Config, opts ...util.HostValidationOption) error {
 	if c == nil {
 		return nil
 	}
-	if err := util.ValidateHost(c.Host, opts...); err != nil {
+	for i, jumpHost := range c.JumpHosts {
+		if err := util.ValidateHostContext(ctx, jumpHost.Host, opts...); err != nil {
+			return fmt.Errorf("ssh tunnel jump host %d: %w", i, err)
+		}
+	}
+	if err := util.ValidateHostContext(ctx, c.Host, opts...); err != nil {
 		return fmt.Errorf("ssh tunnel host: %w", err)
 	}
 	return nil
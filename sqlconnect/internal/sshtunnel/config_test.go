@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/sshtunnel"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/util"
 )
 
 func TestValidate(t *testing.T) {
@@ -56,6 +57,23 @@ func TestValidate(t *testing.T) {
 		err := c.Validate()
 		require.Error(t, err, "it should return an error when private key is empty")
 	})
+
+	t.Run("valid jump hosts", func(t *testing.T) {
+		c := c
+		c.JumpHosts = []sshtunnel.JumpHost{{User: "user", Host: "bastion", Port: "22", PrivateKey: "private_key"}}
+		err := c.Validate()
+		require.NoError(t, err, "it should not return an error")
+	})
+
+	t.Run("invalid jump host", func(t *testing.T) {
+		c := c
+		c.JumpHosts = []sshtunnel.JumpHost{
+			{User: "user", Host: "bastion", Port: "22", PrivateKey: "private_key"},
+			{User: "user", Host: "bastion", Port: "invalid", PrivateKey: "private_key"},
+		}
+		err := c.Validate()
+		require.ErrorContains(t, err, "jump host 1: invalid port", "it should return an error when a jump host is invalid")
+	})
 }
 
 func TestParseInlineConfig(t *testing.T) {
@@ -74,6 +92,27 @@ func TestParseInlineConfig(t *testing.T) {
 		require.Equal(t, "host", c.Host, "it should return the correct host")
 		require.Equal(t, "22", c.Port, "it should return the correct port")
 		require.Equal(t, "private_key", c.PrivateKey, "it should return the correct private key")
+		require.Empty(t, c.JumpHosts, "it should return no jump hosts")
+	})
+
+	t.Run("with jump hosts", func(t *testing.T) {
+		config := `{
+			"useSSH": true,
+			"sshUser": "user",
+			"sshHost": "host",
+			"sshPort": "22",
+			"sshPrivateKey" : "private_key",
+			"sshJumpHosts": [
+				{"sshUser": "user1", "sshHost": "bastion1", "sshPort": "2222", "sshPrivateKey": "private_key1"},
+				{"sshUser": "user2", "sshHost": "bastion2", "sshPort": "22", "sshPrivateKey": "private_key2"}
+			]
+		}`
+		c, err := sshtunnel.ParseInlineConfig([]byte(config))
+		require.NoError(t, err, "it should not return an error")
+		require.Equal(t, []sshtunnel.JumpHost{
+			{User: "user1", Host: "bastion1", Port: "2222", PrivateKey: "private_key1"},
+			{User: "user2", Host: "bastion2", Port: "22", PrivateKey: "private_key2"},
+		}, c.JumpHosts, "it should return the jump hosts in order")
 	})
 
 	t.Run("useSSH false", func(t *testing.T) {
@@ -96,3 +135,23 @@ func TestParseInlineConfig(t *testing.T) {
 		require.Error(t, err, "it should return an error")
 	})
 }
+
+func TestValidateHost(t *testing.T) {
+	c := &sshtunnel.Config{
+		User:       "user",
+		Host:       "8.8.8.8",
+		Port:       "22",
+		PrivateKey: "private_key",
+		JumpHosts:  []sshtunnel.JumpHost{{User: "user", Host: "127.0.0.1", Port: "22", PrivateKey: "private_key"}},
+	}
+
+	t.Run("loopback jump host", func(t *testing.T) {
+		err := sshtunnel.ValidateHost(c)
+		require.ErrorContains(t, err, "ssh tunnel jump host 0", "it should validate the jump hosts")
+	})
+
+	t.Run("loopback jump host allowed", func(t *testing.T) {
+		err := sshtunnel.ValidateHost(c, util.AllowLoopback(true))
+		require.NoError(t, err, "it should pass the options to the validation of the jump hosts")
+	})
+}
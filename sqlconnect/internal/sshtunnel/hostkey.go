@@ -0,0 +1,258 @@
+package sshtunnel
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// HostKeyMode controls how the host key of an ssh server is verified
+type HostKeyMode string
+
+const (
+	// HostKeyModeStrict only accepts host keys listed in the known hosts or matching the host key fingerprint
+	HostKeyModeStrict HostKeyMode = "strict"
+	// HostKeyModeTrustOnFirstUse accepts any host key as long as neither known hosts nor a host key fingerprint are configured, verifying the key strictly otherwise.
+	// The fingerprint of the accepted key is available through [Tunnel.HostKeyFingerprint], which is exposed by the SSHTunnel method of the db, for pinning it in subsequent connections.
+	HostKeyModeTrustOnFirstUse HostKeyMode = "trust-on-first-use"
+	// HostKeyModeInsecure accepts any host key
+	HostKeyModeInsecure HostKeyMode = "insecure"
+)
+
+// ErrHostKeyVerification is returned when the host key of an ssh server cannot be verified, e.g. because it doesn't match the known hosts
+var ErrHostKeyVerification = errors.New("ssh host key verification failed")
+
+// validateHostKeySettings checks if the host key settings of an ssh server are valid
+func validateHostKeySettings(hop JumpHost) error {
+	switch hop.HostKeyMode {
+	case "", HostKeyModeStrict, HostKeyModeTrustOnFirstUse, HostKeyModeInsecure:
+	default:
+		return fmt.Errorf("invalid host key mode: %s", hop.HostKeyMode)
+	}
+	v, err := newHostKeyVerifier(hop)
+	if err != nil {
+		return err
+	}
+	if v.mode == HostKeyModeStrict && !v.pinned() {
+		return fmt.Errorf("known hosts or a host key fingerprint are required for strict host key verification")
+	}
+	return nil
+}
+
+// pinHostKeys returns the configuration with the host keys presented on its first connection pinned for the servers trusting them on first use,
+// so that they are verified strictly when reconnecting
+func (c Config) pinHostKeys(fingerprints []string) Config {
+	c.JumpHosts = slices.Clone(c.JumpHosts)
+	for i := range c.JumpHosts {
+		c.JumpHosts[i] = c.JumpHosts[i].pinHostKey(fingerprints[i])
+	}
+	hop := c.hops()[len(c.JumpHosts)].pinHostKey(fingerprints[len(c.JumpHosts)])
+	c.HostKeyFingerprint = hop.HostKeyFingerprint
+	return c
+}
+
+// pinHostKey returns the server with the fingerprint pinned, if it trusts host keys on first use and none is pinned yet
+func (hop JumpHost) pinHostKey(fingerprint string) JumpHost {
+	if hop.HostKeyMode == HostKeyModeTrustOnFirstUse && hop.KnownHosts == "" && hop.HostKeyFingerprint == "" {
+		hop.HostKeyFingerprint = fingerprint
+	}
+	return hop
+}
+
+// hostKeyVerifier verifies the host key of an ssh server according to its host key settings, keeping the fingerprint of the key presented by the server
+type hostKeyVerifier struct {
+	mode        HostKeyMode
+	knownHosts  []knownHost
+	fingerprint string // the configured fingerprint, without its SHA256: prefix
+
+	observed string // the fingerprint of the key presented by the server
+}
+
+func newHostKeyVerifier(hop JumpHost) (*hostKeyVerifier, error) {
+	knownHosts, err := parseKnownHosts(hop.KnownHosts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid known hosts: %w", err)
+	}
+	fingerprint, err := parseFingerprint(hop.HostKeyFingerprint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid host key fingerprint: %w", err)
+	}
+	mode := hop.HostKeyMode
+	if mode == "" {
+		// verifying host keys only if pinned, for backwards compatibility
+		mode = HostKeyModeInsecure
+		if len(knownHosts) > 0 || fingerprint != "" {
+			mode = HostKeyModeStrict
+		}
+	}
+	return &hostKeyVerifier{mode: mode, knownHosts: knownHosts, fingerprint: fingerprint}, nil
+}
+
+// pinned returns whether known hosts or a host key fingerprint are configured
+func (v *hostKeyVerifier) pinned() bool {
+	return len(v.knownHosts) > 0 || v.fingerprint != ""
+}
+
+// verify is an [ssh.HostKeyCallback] verifying the key presented by the server
+func (v *hostKeyVerifier) verify(hostname string, _ net.Addr, key ssh.PublicKey) error {
+	v.observed = ssh.FingerprintSHA256(key)
+	if v.mode == HostKeyModeInsecure || (v.mode == HostKeyModeTrustOnFirstUse && !v.pinned()) {
+		return nil
+	}
+	host := knownhosts.Normalize(hostname)
+	var knownHost bool
+	for _, h := range v.knownHosts {
+		if h.marker == "revoked" && keysEqual(h.key, key) {
+			return fmt.Errorf("%w: host key %s of %s is revoked", ErrHostKeyVerification, v.observed, hostname)
+		}
+		knownHost = knownHost || (h.marker == "" && h.matches(host))
+	}
+	if v.fingerprint != "" && strings.TrimPrefix(v.observed, "SHA256:") == v.fingerprint {
+		return nil
+	}
+	for _, h := range v.knownHosts {
+		if h.marker == "" && h.matches(host) && keysEqual(h.key, key) {
+			return nil
+		}
+	}
+	if knownHost || v.fingerprint != "" {
+		return fmt.Errorf("%w: host key %s of %s does not match the pinned ones", ErrHostKeyVerification, v.observed, hostname)
+	}
+	return fmt.Errorf("%w: %s is not a known host", ErrHostKeyVerification, hostname)
+}
+
+// hostKeyAlgorithms returns the algorithms of the keys known for a host, so that the server presents one of them, or nil if there are no such keys
+func (v *hostKeyVerifier) hostKeyAlgorithms(hostname string) []string {
+	if v.mode == HostKeyModeInsecure || v.fingerprint != "" {
+		return nil // the algorithm of the fingerprint's key is unknown
+	}
+	host := knownhosts.Normalize(hostname)
+	var algorithms []string
+	for _, h := range v.knownHosts {
+		if h.marker != "" || !h.matches(host) {
+			continue
+		}
+		keyAlgorithms := []string{h.key.Type()}
+		if h.key.Type() == ssh.KeyAlgoRSA {
+			keyAlgorithms = []string{ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256, ssh.KeyAlgoRSA}
+		}
+		for _, algorithm := range keyAlgorithms {
+			if !slices.Contains(algorithms, algorithm) {
+				algorithms = append(algorithms, algorithm)
+			}
+		}
+	}
+	return algorithms
+}
+
+// knownHost is an entry of known hosts, in the format of OpenSSH's known_hosts files. Entries marked as cert-authority are ignored.
+type knownHost struct {
+	marker   string   // either revoked, or empty
+	patterns []string // the host patterns of the entry, which may be hashed, negated or contain wildcards
+	key      ssh.PublicKey
+}
+
+func parseKnownHosts(knownHosts string) ([]knownHost, error) {
+	var hosts []knownHost
+	rest := []byte(knownHosts)
+	for {
+		marker, patterns, key, _, r, err := ssh.ParseKnownHosts(rest)
+		if err == io.EOF {
+			return hosts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rest = r
+		if marker != "cert-authority" {
+			hosts = append(hosts, knownHost{marker: marker, patterns: patterns, key: key})
+		}
+	}
+}
+
+// matches returns whether a normalised host matches the patterns of the entry, i.e. at least one of them, without matching any negated one
+func (h knownHost) matches(host string) bool {
+	var matched bool
+	for _, pattern := range h.patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		if !matchHostPattern(strings.TrimPrefix(pattern, "!"), host) {
+			continue
+		}
+		if negated {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// matchHostPattern matches a normalised host against either a hashed host or a host pattern with * and ? wildcards
+func matchHostPattern(pattern, host string) bool {
+	if hashed, ok := strings.CutPrefix(pattern, "|1|"); ok {
+		encodedSalt, encodedHash, ok := strings.Cut(hashed, "|")
+		if !ok {
+			return false
+		}
+		salt, err := base64.StdEncoding.DecodeString(encodedSalt)
+		if err != nil {
+			return false
+		}
+		hash, err := base64.StdEncoding.DecodeString(encodedHash)
+		if err != nil {
+			return false
+		}
+		mac := hmac.New(sha1.New, salt)
+		mac.Write([]byte(host))
+		return hmac.Equal(mac.Sum(nil), hash)
+	}
+	return wildcardMatch(strings.ToLower(pattern), strings.ToLower(host))
+}
+
+func wildcardMatch(pattern, s string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for i := range len(s) + 1 {
+				if wildcardMatch(pattern[1:], s[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if s == "" {
+				return false
+			}
+		default:
+			if s == "" || pattern[0] != s[0] {
+				return false
+			}
+		}
+		pattern, s = pattern[1:], s[1:]
+	}
+	return s == ""
+}
+
+// parseFingerprint returns a SHA256 fingerprint without its SHA256: prefix and padding, as printed by ssh-keygen -l
+func parseFingerprint(fingerprint string) (string, error) {
+	if fingerprint == "" {
+		return "", nil
+	}
+	hash := strings.TrimRight(strings.TrimPrefix(fingerprint, "SHA256:"), "=")
+	if b, err := base64.RawStdEncoding.DecodeString(hash); err != nil || len(b) != 32 {
+		return "", fmt.Errorf("not a SHA256 fingerprint: %s", fingerprint)
+	}
+	return hash, nil
+}
+
+func keysEqual(a, b ssh.PublicKey) bool {
+	return a.Type() == b.Type() && string(a.Marshal()) == string(b.Marshal())
+}
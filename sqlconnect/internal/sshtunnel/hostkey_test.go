@@ -0,0 +1,127 @@
+package sshtunnel
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+func TestHostKeyVerifier(t *testing.T) {
+	key, otherKey := newHostKey(t), newHostKey(t)
+	fingerprint := ssh.FingerprintSHA256(key)
+	authorizedKey := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))) // for known hosts entries with patterns or markers
+	verify := func(hop JumpHost, hostname string, key ssh.PublicKey) (string, error) {
+		t.Helper()
+		require.NoError(t, validateHostKeySettings(hop))
+		v, err := newHostKeyVerifier(hop)
+		require.NoError(t, err)
+		err = v.verify(hostname, nil, key)
+		return v.observed, err
+	}
+
+	t.Run("insecure", func(t *testing.T) {
+		observed, err := verify(JumpHost{HostKeyMode: HostKeyModeInsecure, HostKeyFingerprint: ssh.FingerprintSHA256(otherKey)}, "host:22", key)
+		require.NoError(t, err, "it should accept any host key")
+		require.Equal(t, fingerprint, observed, "it should keep the fingerprint of the host key")
+	})
+
+	t.Run("default mode", func(t *testing.T) {
+		_, err := verify(JumpHost{}, "host:22", key)
+		require.NoError(t, err, "it should accept any host key without known hosts or fingerprint")
+
+		_, err = verify(JumpHost{HostKeyFingerprint: ssh.FingerprintSHA256(otherKey)}, "host:22", key)
+		require.ErrorIs(t, err, ErrHostKeyVerification, "it should verify host keys strictly with a fingerprint")
+	})
+
+	t.Run("strict with fingerprint", func(t *testing.T) {
+		_, err := verify(JumpHost{HostKeyMode: HostKeyModeStrict, HostKeyFingerprint: fingerprint}, "host:22", key)
+		require.NoError(t, err)
+
+		_, err = verify(JumpHost{HostKeyMode: HostKeyModeStrict, HostKeyFingerprint: fingerprint[len("SHA256:"):]}, "host:22", key)
+		require.NoError(t, err, "it should accept fingerprints without their prefix")
+
+		_, err = verify(JumpHost{HostKeyMode: HostKeyModeStrict, HostKeyFingerprint: ssh.FingerprintSHA256(otherKey)}, "host:22", key)
+		require.ErrorIs(t, err, ErrHostKeyVerification)
+		require.ErrorContains(t, err, "does not match the pinned ones")
+	})
+
+	t.Run("strict with known hosts", func(t *testing.T) {
+		for _, tc := range []struct {
+			name       string
+			knownHosts string
+			hostname   string
+			err        string
+		}{
+			{name: "default port", knownHosts: knownhosts.Line([]string{"host"}, key), hostname: "host:22"},
+			{name: "other port", knownHosts: knownhosts.Line([]string{"[host]:2222"}, key), hostname: "host:2222"},
+			{name: "hashed", knownHosts: knownhosts.Line([]string{knownhosts.HashHostname("host")}, key), hostname: "host:22"},
+			{name: "wildcard", knownHosts: "*.example.com " + authorizedKey, hostname: "bastion.example.com:22"},
+			{name: "several entries", knownHosts: knownhosts.Line([]string{"other"}, otherKey) + "\n" + knownhosts.Line([]string{"host"}, otherKey) + "\n" + knownhosts.Line([]string{"host"}, key), hostname: "host:22"},
+			{name: "negated", knownHosts: "*,!host " + authorizedKey, hostname: "host:22", err: "host:22 is not a known host"},
+			{name: "unknown host", knownHosts: knownhosts.Line([]string{"other"}, key), hostname: "host:22", err: "host:22 is not a known host"},
+			{name: "other port unknown", knownHosts: knownhosts.Line([]string{"host"}, key), hostname: "host:2222", err: "host:2222 is not a known host"},
+			{name: "mismatch", knownHosts: knownhosts.Line([]string{"host"}, otherKey), hostname: "host:22", err: "does not match the pinned ones"},
+			{name: "revoked", knownHosts: "@revoked * " + authorizedKey + "\n" + knownhosts.Line([]string{"host"}, key), hostname: "host:22", err: "is revoked"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := verify(JumpHost{HostKeyMode: HostKeyModeStrict, KnownHosts: tc.knownHosts}, tc.hostname, key)
+				if tc.err == "" {
+					require.NoError(t, err)
+					return
+				}
+				require.ErrorIs(t, err, ErrHostKeyVerification)
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+
+	t.Run("trust on first use", func(t *testing.T) {
+		observed, err := verify(JumpHost{HostKeyMode: HostKeyModeTrustOnFirstUse}, "host:22", key)
+		require.NoError(t, err, "it should accept any host key the first time")
+		require.Equal(t, fingerprint, observed, "it should return the fingerprint for pinning it")
+
+		_, err = verify(JumpHost{HostKeyMode: HostKeyModeTrustOnFirstUse, HostKeyFingerprint: observed}, "host:22", key)
+		require.NoError(t, err, "it should accept the pinned host key")
+
+		_, err = verify(JumpHost{HostKeyMode: HostKeyModeTrustOnFirstUse, HostKeyFingerprint: observed}, "host:22", otherKey)
+		require.ErrorIs(t, err, ErrHostKeyVerification, "it should reject other host keys once pinned")
+	})
+
+	t.Run("host key algorithms", func(t *testing.T) {
+		v, err := newHostKeyVerifier(JumpHost{KnownHosts: knownhosts.Line([]string{"host"}, key)})
+		require.NoError(t, err)
+		require.Equal(t, []string{ssh.KeyAlgoED25519}, v.hostKeyAlgorithms("host:22"), "it should prefer the algorithms of the known keys")
+		require.Nil(t, v.hostKeyAlgorithms("other:22"))
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			hop  JumpHost
+			err  string
+		}{
+			{name: "invalid mode", hop: JumpHost{HostKeyMode: "trust"}, err: "invalid host key mode: trust"},
+			{name: "strict without keys", hop: JumpHost{HostKeyMode: HostKeyModeStrict}, err: "known hosts or a host key fingerprint are required"},
+			{name: "invalid fingerprint", hop: JumpHost{HostKeyFingerprint: "MD5:00"}, err: "invalid host key fingerprint"},
+			{name: "invalid known hosts", hop: JumpHost{KnownHosts: "host ssh-ed25519 invalid"}, err: "invalid known hosts"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				require.ErrorContains(t, validateHostKeySettings(tc.hop), tc.err)
+			})
+		}
+	})
+}
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(pub)
+	require.NoError(t, err)
+	return key
+}
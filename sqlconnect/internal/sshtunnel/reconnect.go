@@ -0,0 +1,324 @@
+package sshtunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+const (
+	// defaultKeepaliveInterval is the interval of keepalive requests, unless configured otherwise
+	defaultKeepaliveInterval = 30 * time.Second
+	// defaultKeepaliveMaxMissed is the number of unanswered keepalive requests after which a connection is considered dead, unless configured otherwise
+	defaultKeepaliveMaxMissed = 3
+	// minReconnectWait and maxReconnectWait bound the exponential backoff between reconnection attempts
+	minReconnectWait = 1 * time.Second
+	maxReconnectWait = 30 * time.Second
+	// reconnectWait is the maximum time a connection through the tunnel waits for the tunnel to reconnect, when no deadline is given
+	reconnectWait = 30 * time.Second
+)
+
+// State is the state of an ssh tunnel's connection to its ssh server
+type State = sqlconnect.SSHTunnelState
+
+const (
+	// StateConnected means the tunnel is connected to its ssh server
+	StateConnected = sqlconnect.SSHTunnelStateConnected
+	// StateReconnecting means the tunnel lost its connection to its ssh server and is trying to re-establish it
+	StateReconnecting = sqlconnect.SSHTunnelStateReconnecting
+	// StateClosed means the tunnel has been closed
+	StateClosed = sqlconnect.SSHTunnelStateClosed
+)
+
+// Status is the status of an ssh tunnel
+type Status = sqlconnect.SSHTunnelStatus
+
+// reconnectingClient is a client of the tunnel's ssh server, which detects when its connection is lost, using keepalive requests, and re-establishes it transparently
+type reconnectingClient struct {
+	config             Config // the configuration of the tunnel, with the host keys trusted on first use pinned
+	keepaliveInterval  time.Duration
+	keepaliveMaxMissed int
+	attrs              []attribute.KeyValue // attributes identifying the tunnel in spans and metrics
+
+	ctx    context.Context // done once the client is closed
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	redial chan struct{} // signals that a connection is waiting, for attempting to reconnect without waiting for the backoff
+
+	mu             sync.Mutex
+	client         *sshClient    // the current client, nil while reconnecting
+	connected      chan struct{} // closed once reconnected
+	status         Status
+	onStatusChange func(Status)
+	fingerprints   []string
+}
+
+// newReconnectingClient returns a reconnecting client using the client that has already been connected to the tunnel's ssh server
+func newReconnectingClient(c Config, client *sshClient, attrs []attribute.KeyValue) *reconnectingClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &reconnectingClient{
+		config:             c.pinHostKeys(client.hostKeyFingerprints),
+		keepaliveInterval:  c.KeepaliveInterval,
+		keepaliveMaxMissed: c.KeepaliveMaxMissed,
+		onStatusChange:     c.OnStatusChange,
+		attrs:              attrs,
+		ctx:                ctx,
+		cancel:             cancel,
+		redial:             make(chan struct{}, 1),
+		client:             client,
+		connected:          make(chan struct{}),
+		status:             Status{State: StateConnected, Since: time.Now()},
+		fingerprints:       client.hostKeyFingerprints,
+	}
+	if r.keepaliveInterval == 0 {
+		r.keepaliveInterval = defaultKeepaliveInterval
+	}
+	if r.keepaliveMaxMissed == 0 {
+		r.keepaliveMaxMissed = defaultKeepaliveMaxMissed
+	}
+	r.wg.Go(func() { r.supervise(client) })
+	return r
+}
+
+// Status returns the current status of the tunnel
+func (r *reconnectingClient) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.status
+}
+
+// OnStatusChange sets the function called whenever the status of the tunnel changes, replacing the one of its configuration
+func (r *reconnectingClient) OnStatusChange(fn func(Status)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onStatusChange = fn
+}
+
+// HostKeyFingerprint returns the fingerprint of the host key presented by the tunnel's ssh server on its last connection
+func (r *reconnectingClient) HostKeyFingerprint() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.fingerprints[len(r.fingerprints)-1]
+}
+
+// JumpHostKeyFingerprints returns the fingerprints of the host keys presented by the jump hosts on the last connection, in order
+func (r *reconnectingClient) JumpHostKeyFingerprints() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Clone(r.fingerprints[:len(r.fingerprints)-1])
+}
+
+// DialContext connects to the address through the ssh server, waiting for the tunnel to reconnect if its connection is lost.
+// If dialling fails because the connection turns out to be broken, the tunnel is reconnected and dialling is retried once.
+func (r *reconnectingClient) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, reconnectWait)
+		defer cancel()
+	}
+	for retry := true; ; retry = false {
+		client, err := r.awaitClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		conn, err := client.DialContext(ctx, network, addr)
+		var openErr *ssh.OpenChannelError
+		if err == nil || errors.As(err, &openErr) || ctx.Err() != nil || !retry {
+			return conn, err // the ssh server is reachable, e.g. it rejected the address
+		}
+		r.disconnect(client)
+	}
+}
+
+// awaitClient returns the current client, waiting for the tunnel to reconnect if needed
+func (r *reconnectingClient) awaitClient(ctx context.Context) (*sshClient, error) {
+	for {
+		r.mu.Lock()
+		client, connected := r.client, r.connected
+		r.mu.Unlock()
+		if client != nil {
+			return client, nil
+		}
+		if r.ctx.Err() != nil {
+			return nil, fmt.Errorf("ssh tunnel is closed: %w", net.ErrClosed)
+		}
+		select {
+		case r.redial <- struct{}{}:
+		default:
+		}
+		select {
+		case <-connected:
+		case <-r.ctx.Done():
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for ssh tunnel to reconnect: %w", errors.Join(ctx.Err(), r.Status().Err))
+		}
+	}
+}
+
+// disconnect closes the client if it is still the current one, so that the tunnel reconnects
+func (r *reconnectingClient) disconnect(client *sshClient) {
+	r.mu.Lock()
+	if r.client == client {
+		r.client = nil
+	}
+	r.mu.Unlock()
+	_ = client.Close()
+}
+
+// supervise monitors the connection of the client, reconnecting whenever it is lost, until the reconnecting client is closed
+func (r *reconnectingClient) supervise(client *sshClient) {
+	for {
+		err := r.monitor(client)
+		if r.ctx.Err() != nil {
+			return
+		}
+		r.disconnect(client)
+		r.setStatus(StateReconnecting, err)
+		if client = r.reconnect(); client == nil {
+			return
+		}
+	}
+}
+
+// monitor waits until the connection of the client is closed, or its keepalive requests go unanswered, returning the reason
+func (r *reconnectingClient) monitor(client *sshClient) error {
+	closed := make(chan error, 1)
+	go func() { closed <- client.Wait() }()
+	done := make(chan struct{})
+	defer close(done)
+	keepaliveErr := make(chan error, 1)
+	if r.keepaliveInterval > 0 {
+		go func() { keepaliveErr <- keepalive(client.Client, r.keepaliveInterval, r.keepaliveMaxMissed, done) }()
+	}
+	select {
+	case <-r.ctx.Done():
+		return nil
+	case err := <-keepaliveErr:
+		return err
+	case err := <-closed:
+		if err == nil {
+			return errors.New("ssh connection closed")
+		}
+		return fmt.Errorf("ssh connection closed: %w", err)
+	}
+}
+
+// reconnect connects to the tunnel's ssh server again, backing off exponentially between attempts, until it succeeds or the reconnecting client is closed, in which case it returns nil
+func (r *reconnectingClient) reconnect() *sshClient {
+	wait := minReconnectWait
+	for {
+		client, err := dialSSH(r.ctx, r.config)
+		if err == nil {
+			r.mu.Lock()
+			if r.ctx.Err() != nil {
+				r.mu.Unlock()
+				_ = client.Close()
+				return nil
+			}
+			r.client = client
+			r.fingerprints = client.hostKeyFingerprints
+			close(r.connected)
+			r.connected = make(chan struct{})
+			r.status.Reconnects++
+			r.mu.Unlock()
+			telemetry.RecordSSHTunnelReconnect(r.ctx, r.attrs...)
+			r.setStatus(StateConnected, nil)
+			return client
+		}
+		if r.ctx.Err() != nil {
+			return nil
+		}
+		r.setStatus(StateReconnecting, err)
+		select {
+		case <-time.After(wait):
+		case <-r.redial:
+		case <-r.ctx.Done():
+			return nil
+		}
+		wait = min(wait*2, maxReconnectWait)
+	}
+}
+
+// setStatus sets the state of the tunnel along with its error, notifying the status change callback if any
+func (r *reconnectingClient) setStatus(state State, err error) {
+	r.mu.Lock()
+	if r.status.State != state {
+		r.status.State, r.status.Since = state, time.Now()
+	}
+	r.status.Err = err
+	status, onStatusChange := r.status, r.onStatusChange
+	r.mu.Unlock()
+	if onStatusChange != nil {
+		onStatusChange(status)
+	}
+}
+
+// forwardFailed records the error of a local connection that couldn't be forwarded through the client, notifying the status change callback if any
+func (r *reconnectingClient) forwardFailed(err error) {
+	r.mu.Lock()
+	r.status.ForwardErr = err
+	status, onStatusChange := r.status, r.onStatusChange
+	r.mu.Unlock()
+	telemetry.RecordSSHTunnelForwardError(r.ctx, r.attrs...)
+	if onStatusChange != nil {
+		onStatusChange(status)
+	}
+}
+
+// Close closes the client, stopping any reconnection attempt
+func (r *reconnectingClient) Close() error {
+	r.cancel()
+	r.mu.Lock()
+	client := r.client
+	r.client = nil
+	r.mu.Unlock()
+	var err error
+	if client != nil {
+		err = client.Close()
+	}
+	r.wg.Wait()
+	r.setStatus(StateClosed, nil)
+	return err
+}
+
+// keepalive sends keepalive requests to the ssh server at the interval, until done is closed or maxMissed consecutive requests go unanswered for an interval, returning the reason
+func keepalive(client *ssh.Client, interval time.Duration, maxMissed int, done <-chan struct{}) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var reply chan error // the reply to the pending request, if any
+	var missed int
+	for {
+		select {
+		case <-done:
+			return nil
+		case err := <-reply:
+			if err != nil {
+				return fmt.Errorf("sending keepalive request: %w", err)
+			}
+			reply, missed = nil, 0
+		case <-ticker.C:
+			if reply != nil {
+				if missed++; missed >= maxMissed {
+					return fmt.Errorf("ssh server did not reply to %d keepalive requests", missed)
+				}
+				continue
+			}
+			reply = make(chan error, 1)
+			go func(reply chan<- error) {
+				// servers reply even to requests they don't support, the reply itself proving the connection is alive
+				_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+				reply <- err
+			}(reply)
+		}
+	}
+}
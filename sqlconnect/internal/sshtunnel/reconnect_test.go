@@ -0,0 +1,315 @@
+package sshtunnel
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestReconnectingTunnel(t *testing.T) {
+	_, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	clientSigner, err := ssh.NewSignerFromKey(clientKey)
+	require.NoError(t, err)
+	privateKey := string(pem.EncodeToMemory(mustPEM(t)(ssh.MarshalPrivateKey(clientKey, ""))))
+	echoHost, echoPort := echoServer(t)
+
+	newTunnel := func(t *testing.T, server *forwardingServer, c Config) (Tunnel, <-chan Status) {
+		host, port, _ := net.SplitHostPort(server.addr)
+		c.User, c.Host, c.Port, c.PrivateKey = "user", host, port, privateKey
+		statuses := make(chan Status, 100)
+		c.OnStatusChange = func(s Status) { statuses <- s }
+		tunnel, err := NewTcpTunnel(c, echoHost, echoPort)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = tunnel.Close() })
+		require.Equal(t, StateConnected, tunnel.Status().State)
+		requireEcho(t, tunnel)
+		return tunnel, statuses
+	}
+
+	t.Run("lost connection", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		tunnel, statuses := newTunnel(t, server, Config{})
+
+		server.dropConnections()
+		status := awaitStatus(t, statuses, StateReconnecting)
+		require.ErrorContains(t, status.Err, "ssh connection closed", "it should detect the lost connection")
+		status = awaitStatus(t, statuses, StateConnected)
+		require.Equal(t, 1, status.Reconnects, "it should reconnect")
+		requireEcho(t, tunnel)
+	})
+
+	t.Run("status change function set after opening", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		tunnel, statuses := newTunnel(t, server, Config{})
+		replaced := make(chan Status, 100)
+		tunnel.OnStatusChange(func(s Status) { replaced <- s })
+
+		server.dropConnections()
+		awaitStatus(t, replaced, StateReconnecting)
+		status := awaitStatus(t, replaced, StateConnected)
+		require.Equal(t, 1, status.Reconnects, "it should notify the function set after opening the tunnel")
+		require.Empty(t, statuses, "it should not notify the function of the configuration")
+	})
+
+	t.Run("unresponsive server", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		tunnel, statuses := newTunnel(t, server, Config{KeepaliveInterval: 50 * time.Millisecond, KeepaliveMaxMissed: 2})
+
+		server.unresponsive.Store(true)
+		status := awaitStatus(t, statuses, StateReconnecting)
+		require.ErrorContains(t, status.Err, "did not reply to 2 keepalive requests", "it should detect the unresponsive connection")
+		server.unresponsive.Store(false)
+		require.Eventually(t, func() bool { return tunnel.Status().State == StateConnected }, 10*time.Second, 10*time.Millisecond)
+		requireEcho(t, tunnel)
+	})
+
+	t.Run("server down", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		tunnel, statuses := newTunnel(t, server, Config{})
+
+		server.stop()
+		awaitStatus(t, statuses, StateReconnecting)
+		status := awaitStatus(t, statuses, StateReconnecting)
+		require.ErrorContains(t, status.Err, "dial error", "it should report the failed reconnection attempts")
+
+		server.start()
+		requireEcho(t, tunnel) // waiting for the tunnel to reconnect
+		require.Equal(t, 1, tunnel.Status().Reconnects)
+
+		require.NoError(t, tunnel.Close())
+		awaitStatus(t, statuses, StateClosed)
+		require.Equal(t, StateClosed, tunnel.Status().State)
+		_, err := net.Dial("tcp", tunnel.Addr())
+		require.Error(t, err, "it should stop listening")
+	})
+
+	t.Run("host key trusted on first use", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		tunnel, statuses := newTunnel(t, server, Config{HostKeyMode: HostKeyModeTrustOnFirstUse})
+		fingerprint := tunnel.HostKeyFingerprint()
+
+		server.stop()
+		server.hostKey = newHostSigner(t)
+		server.start()
+		status := awaitStatus(t, statuses, StateReconnecting)
+		for !errors.Is(status.Err, ErrHostKeyVerification) {
+			status = awaitStatus(t, statuses, StateReconnecting)
+		}
+		require.ErrorContains(t, status.Err, "does not match the pinned ones", "it should verify the host key trusted on first use when reconnecting")
+		require.Equal(t, fingerprint, tunnel.HostKeyFingerprint())
+	})
+
+	t.Run("unreachable remote address", func(t *testing.T) {
+		server := newForwardingServer(t, clientSigner.PublicKey())
+		host, port, _ := net.SplitHostPort(server.addr)
+		statuses := make(chan Status, 100)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		closedPort := l.Addr().(*net.TCPAddr).Port
+		require.NoError(t, l.Close())
+		tunnel, err := NewTcpTunnel(Config{User: "user", Host: host, Port: port, PrivateKey: privateKey, OnStatusChange: func(s Status) { statuses <- s }}, "127.0.0.1", closedPort)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = tunnel.Close() })
+
+		conn, err := net.Dial("tcp", tunnel.Addr())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		status := awaitStatus(t, statuses, StateConnected)
+		var openErr *ssh.OpenChannelError
+		require.ErrorAs(t, status.ForwardErr, &openErr, "it should report the error of the connection that couldn't be forwarded")
+		require.ErrorContains(t, tunnel.Status().ForwardErr, "forwarding connection to 127.0.0.1:"+strconv.Itoa(closedPort))
+	})
+
+	t.Run("invalid settings", func(t *testing.T) {
+		err := Config{User: "user", Host: "host", Port: "22", PrivateKey: privateKey, KeepaliveMaxMissed: -1}.Validate()
+		require.ErrorContains(t, err, "invalid keepalive max missed: -1")
+	})
+}
+
+// forwardingServer is an ssh server forwarding direct-tcpip channels, which can drop its connections, stop and start again on the same address, or stop replying to requests
+type forwardingServer struct {
+	t            *testing.T
+	clientKey    ssh.PublicKey
+	hostKey      ssh.Signer
+	addr         string
+	unresponsive atomic.Bool // whether to leave global requests, such as keepalives, unanswered
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    []net.Conn
+}
+
+func newForwardingServer(t *testing.T, clientKey ssh.PublicKey) *forwardingServer {
+	s := &forwardingServer{t: t, clientKey: clientKey, hostKey: newHostSigner(t), addr: "127.0.0.1:0"}
+	s.start()
+	t.Cleanup(s.stop)
+	return s
+}
+
+func (s *forwardingServer) start() {
+	l, err := net.Listen("tcp", s.addr)
+	require.NoError(s.t, err)
+	s.mu.Lock()
+	s.listener, s.addr = l, l.Addr().String()
+	s.mu.Unlock()
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if bytes.Equal(key.Marshal(), s.clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("unknown public key")
+		},
+	}
+	config.AddHostKey(s.hostKey)
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.mu.Unlock()
+			go s.handle(conn, config)
+		}
+	}()
+}
+
+func (s *forwardingServer) handle(conn net.Conn, config *ssh.ServerConfig) {
+	defer func() { _ = conn.Close() }()
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go func() {
+		for req := range reqs {
+			if req.WantReply && !s.unresponsive.Load() {
+				_ = req.Reply(false, nil)
+			}
+		}
+	}()
+	for newChannel := range chans {
+		var target struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if newChannel.ChannelType() != "direct-tcpip" || ssh.Unmarshal(newChannel.ExtraData(), &target) != nil {
+			_ = newChannel.Reject(ssh.Prohibited, "only direct-tcpip channels are supported")
+			continue
+		}
+		targetConn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+		if err != nil {
+			_ = newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			_ = targetConn.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+		go func() {
+			_, _ = io.Copy(targetConn, channel)
+			_ = targetConn.Close()
+		}()
+		go func() {
+			_, _ = io.Copy(channel, targetConn)
+			_ = channel.Close()
+		}()
+	}
+}
+
+// dropConnections closes the connections of the server, without stopping it
+func (s *forwardingServer) dropConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.conns = nil
+}
+
+// stop closes the listener of the server along with its connections
+func (s *forwardingServer) stop() {
+	s.mu.Lock()
+	_ = s.listener.Close()
+	s.mu.Unlock()
+	s.dropConnections()
+}
+
+func newHostSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signer, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+	return signer
+}
+
+// echoServer starts a tcp server echoing back whatever it receives, returning its host and port
+func echoServer(t *testing.T) (string, int) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() { _ = conn.Close() }()
+				_, _ = io.Copy(conn, conn)
+			}()
+		}
+	}()
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p
+}
+
+// requireEcho checks that data sent through the tunnel to the echo server is echoed back
+func requireEcho(t *testing.T, tunnel Tunnel) {
+	t.Helper()
+	conn, err := net.Dial("tcp", tunnel.Addr())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(reconnectWait)))
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	reply := make([]byte, 4)
+	_, err = io.ReadFull(conn, reply)
+	require.NoError(t, err, "it should forward the connection through the tunnel")
+	require.Equal(t, "ping", string(reply))
+}
+
+// awaitStatus returns the next status with the state
+func awaitStatus(t *testing.T, statuses <-chan Status, state State) Status {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case status := <-statuses:
+			if status.State == state {
+				return status
+			}
+		case <-timeout:
+			require.FailNow(t, "timed out waiting for status", "state: %s", state)
+		}
+	}
+}
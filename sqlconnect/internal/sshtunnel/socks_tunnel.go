@@ -9,55 +9,46 @@ import (
 	"net/url"
 	"strconv"
 	"sync"
-	"time"
 
 	"github.com/armon/go-socks5"
-	"golang.org/x/crypto/ssh"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
 )
 
 // NewSocks5Tunnel creates a new socks5 proxy using the ssh tunnel
 func NewSocks5Tunnel(c Config) (Tunnel, error) {
-	if err := c.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid ssh tunnel configuration: %w", err)
-	}
-	sshSigner, err := ssh.ParsePrivateKey([]byte(c.PrivateKey))
-	if err != nil {
-		return nil, fmt.Errorf("parsing private key: %w", err)
-	}
+	return NewSocks5TunnelContext(context.Background(), c)
+}
 
-	endpoint := net.JoinHostPort(c.Host, c.Port)
-	sshClient, err := ssh.Dial("tcp", endpoint, &ssh.ClientConfig{
-		User: c.User,
-		Auth: []ssh.AuthMethod{
-			ssh.PublicKeys(sshSigner),
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
-		BannerCallback:  ssh.BannerDisplayStderr(),
-		Timeout:         10 * time.Second,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("server %q dial error: %w", endpoint, err)
-	}
+// NewSocks5TunnelContext creates a new socks5 proxy using the ssh tunnel, aborting the connection to the ssh server if the context is done before it is established
+func NewSocks5TunnelContext(ctx context.Context, c Config) (_ Tunnel, err error) {
+	attrs := telemetryAttributes(c, "socks5")
+	ctx, span := startOpenSpan(ctx, attrs)
+	defer func() { telemetry.EndSpan(span, err) }()
 
-	conf := &socks5.Config{
-		Dial: func(ctx context.Context, network, addr string) (net.Conn, error) {
-			return sshClient.Dial(network, addr)
-		},
+	client, err := dialSSH(ctx, c)
+	if err != nil {
+		return nil, err
 	}
-	socksServer, _ := socks5.New(conf)
 	l, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
+		_ = client.Close()
 		return nil, fmt.Errorf("creating listener: %w", err)
 	}
-	var wg sync.WaitGroup
-	wg.Go(func() {
-		_ = socksServer.Serve(l)
-	})
-	return &socksTunnel{
+	sshClient := newReconnectingClient(c, client, attrs)
+	socksServer, _ := socks5.New(&socks5.Config{Dial: sshClient.DialContext})
+	t := &socksTunnel{
 		sshClient: sshClient,
 		listener:  l,
 		addr:      l.Addr().String(),
-	}, nil
+		attrs:     attrs,
+	}
+	t.wg.Go(func() {
+		_ = socksServer.Serve(l)
+	})
+	tunnelOpened(ctx, span, t.addr, attrs)
+	return t, nil
 }
 
 // Socks5HTTPTransport returns an http.Transport that uses the provided host and port as a socks5 proxy.
@@ -84,9 +75,12 @@ func Socks5HTTPTransport(host string, port int) *http.Transport {
 
 type socksTunnel struct {
 	wg        sync.WaitGroup
-	sshClient *ssh.Client
+	sshClient *reconnectingClient
 	listener  net.Listener
 	addr      string
+	attrs     []attribute.KeyValue // attributes identifying the tunnel in spans and metrics
+	closeOnce sync.Once
+	closeErr  error
 }
 
 func (t *socksTunnel) Addr() string {
@@ -104,8 +98,29 @@ func (t *socksTunnel) Port() int {
 	return p
 }
 
+func (t *socksTunnel) HostKeyFingerprint() string {
+	return t.sshClient.HostKeyFingerprint()
+}
+
+func (t *socksTunnel) JumpHostKeyFingerprints() []string {
+	return t.sshClient.JumpHostKeyFingerprints()
+}
+
+func (t *socksTunnel) Status() Status {
+	return t.sshClient.Status()
+}
+
+func (t *socksTunnel) OnStatusChange(fn func(Status)) {
+	t.sshClient.OnStatusChange(fn)
+}
+
 func (t *socksTunnel) Close() error {
-	err := errors.Join(t.listener.Close(), t.sshClient.Close())
-	t.wg.Wait()
-	return err
+	t.closeOnce.Do(func() {
+		t.closeErr = closeTunnel(t.attrs, func() error {
+			err := errors.Join(t.listener.Close(), t.sshClient.Close())
+			t.wg.Wait()
+			return err
+		})
+	})
+	return t.closeErr
 }
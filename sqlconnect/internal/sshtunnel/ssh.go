@@ -0,0 +1,107 @@
+package sshtunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// dialTimeout is the maximum time allowed for connecting to each ssh server of the tunnel, including the ssh handshake
+const dialTimeout = 10 * time.Second
+
+// sshClient is a client of the tunnel's ssh server, connected through the clients of its jump hosts
+type sshClient struct {
+	*ssh.Client
+	jumpClients []*ssh.Client
+
+	hostKeyFingerprints []string // the fingerprints of the host keys presented by the jump hosts, followed by the one of the ssh server
+}
+
+// Close closes the client of the tunnel's ssh server, followed by the clients of its jump hosts in reverse order
+func (c *sshClient) Close() error {
+	err := c.Client.Close()
+	for _, jumpClient := range slices.Backward(c.jumpClients) {
+		err = errors.Join(err, jumpClient.Close())
+	}
+	return err
+}
+
+// dialSSH connects to the ssh server of the tunnel, through its jump hosts if any. Both dialling and the ssh handshakes are aborted if the context is done before they complete.
+func dialSSH(ctx context.Context, c Config) (*sshClient, error) {
+	if err := c.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid ssh tunnel configuration: %w", err)
+	}
+	hops := c.hops()
+	auths := make([]*hopAuth, len(hops))
+	verifiers := make([]*hostKeyVerifier, len(hops))
+	for i, hop := range hops {
+		auth, err := newHopAuth(hop)
+		if err != nil {
+			if i < len(hops)-1 {
+				return nil, fmt.Errorf("jump host %d: %w", i, err)
+			}
+			return nil, err
+		}
+		auths[i] = auth
+		if verifiers[i], err = newHostKeyVerifier(hop); err != nil {
+			return nil, fmt.Errorf("invalid ssh tunnel configuration: %w", err) // already validated
+		}
+	}
+
+	var clients []*ssh.Client
+	var dialer net.Dialer
+	dial := dialer.DialContext
+	for i, hop := range hops {
+		client, err := dialHop(ctx, dial, hop, auths[i], verifiers[i])
+		if err != nil {
+			for _, client := range slices.Backward(clients) {
+				_ = client.Close()
+			}
+			return nil, err
+		}
+		clients = append(clients, client)
+		dial = client.DialContext
+	}
+	fingerprints := make([]string, len(verifiers))
+	for i, verifier := range verifiers {
+		fingerprints[i] = verifier.observed
+	}
+	return &sshClient{Client: clients[len(clients)-1], jumpClients: clients[:len(clients)-1], hostKeyFingerprints: fingerprints}, nil
+}
+
+// dialHop connects to an ssh server using the dial function, i.e. either directly or through the client of the previous jump host, authenticating using auth and verifying its host key using the verifier
+func dialHop(ctx context.Context, dial func(ctx context.Context, network, addr string) (net.Conn, error), hop JumpHost, auth *hopAuth, verifier *hostKeyVerifier) (*ssh.Client, error) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	endpoint := net.JoinHostPort(hop.Host, hop.Port)
+	conn, err := dial(ctx, "tcp", endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("server %q dial error: %w", endpoint, err)
+	}
+	// the ssh handshake doesn't accept a context, thus the connection is closed for aborting it
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer auth.close()
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, endpoint, &ssh.ClientConfig{
+		User:              hop.User,
+		Auth:              auth.methods(),
+		HostKeyCallback:   verifier.verify,
+		HostKeyAlgorithms: verifier.hostKeyAlgorithms(endpoint),
+		BannerCallback:    ssh.BannerDisplayStderr(),
+	})
+	if !stop() {
+		if err == nil {
+			_ = sshConn.Close()
+		}
+		return nil, fmt.Errorf("server %q dial error: %w", endpoint, ctx.Err())
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("server %q dial error: %w", endpoint, err)
+	}
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
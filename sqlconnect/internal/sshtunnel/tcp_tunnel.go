@@ -1,40 +1,93 @@
 package sshtunnel
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strconv"
+	"sync"
 
-	"github.com/rudderlabs/sql-tunnels/tunnel"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
 )
 
 // NewTcpTunnel creates a new ssh tunnel forwading tcp traffic
 func NewTcpTunnel(c Config, remoteHost string, remotePort int) (Tunnel, error) {
-	if err := c.Validate(); err != nil {
-		return nil, fmt.Errorf("invalid ssh tunnel configuration: %w", err)
+	return NewTcpTunnelContext(context.Background(), c, remoteHost, remotePort)
+}
+
+// NewTcpTunnelContext creates a new ssh tunnel forwading tcp traffic, aborting the connection to the ssh server if the context is done before it is established
+func NewTcpTunnelContext(ctx context.Context, c Config, remoteHost string, remotePort int) (_ Tunnel, err error) {
+	attrs := telemetryAttributes(c, "tcp")
+	ctx, span := startOpenSpan(ctx, attrs)
+	defer func() { telemetry.EndSpan(span, err) }()
+
+	sshClient, err := dialSSH(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		_ = sshClient.Close()
+		return nil, fmt.Errorf("creating listener: %w", err)
+	}
+	t := &tcpTunnel{
+		sshClient:  newReconnectingClient(c, sshClient, attrs),
+		listener:   l,
+		remoteAddr: net.JoinHostPort(remoteHost, strconv.Itoa(remotePort)),
+		attrs:      attrs,
 	}
-	port, _ := strconv.Atoi(c.Port)
+	t.wg.Go(t.serve)
+	tunnelOpened(ctx, span, t.Addr(), attrs)
+	return t, nil
+}
 
-	tunnelConfig := tunnel.SSHConfig{
-		User:       c.User,
-		Host:       c.Host,
-		Port:       port,
-		PrivateKey: []byte(c.PrivateKey),
+type tcpTunnel struct {
+	wg         sync.WaitGroup
+	sshClient  *reconnectingClient
+	listener   net.Listener
+	remoteAddr string
+	attrs      []attribute.KeyValue // attributes identifying the tunnel in spans and metrics
+	closeOnce  sync.Once
+	closeErr   error
+}
 
-		RemoteHost: remoteHost,
-		RemotePort: remotePort,
+// serve accepts local connections until the listener is closed, forwarding each one of them to the remote address through the ssh server
+func (t *tcpTunnel) serve() {
+	for {
+		localConn, err := t.listener.Accept()
+		if err != nil {
+			return
+		}
+		t.wg.Go(func() { t.forward(localConn) })
 	}
+}
 
-	t, err := tunnel.ListenAndForward(&tunnelConfig)
+// forward forwards the local connection to the remote address through the ssh server, recording the error in the tunnel's status if it can't be forwarded
+func (t *tcpTunnel) forward(localConn net.Conn) {
+	defer func() { _ = localConn.Close() }()
+	remoteConn, err := t.sshClient.DialContext(context.Background(), "tcp", t.remoteAddr)
 	if err != nil {
-		return nil, fmt.Errorf("creating ssh tunnel: %w", err)
+		t.sshClient.forwardFailed(fmt.Errorf("forwarding connection to %s: %w", t.remoteAddr, err))
+		return
 	}
+	defer func() { _ = remoteConn.Close() }()
 
-	return &tcpTunnel{t}, nil
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		_, _ = io.Copy(remoteConn, localConn)
+		_ = remoteConn.Close()
+	})
+	_, _ = io.Copy(localConn, remoteConn)
+	_ = localConn.Close()
+	wg.Wait()
 }
 
-type tcpTunnel struct {
-	*tunnel.SSH
+func (t *tcpTunnel) Addr() string {
+	return t.listener.Addr().String()
 }
 
 func (t *tcpTunnel) Host() string {
@@ -47,3 +100,30 @@ func (t *tcpTunnel) Port() int {
 	p, _ := strconv.Atoi(port)
 	return p
 }
+
+func (t *tcpTunnel) HostKeyFingerprint() string {
+	return t.sshClient.HostKeyFingerprint()
+}
+
+func (t *tcpTunnel) JumpHostKeyFingerprints() []string {
+	return t.sshClient.JumpHostKeyFingerprints()
+}
+
+func (t *tcpTunnel) Status() Status {
+	return t.sshClient.Status()
+}
+
+func (t *tcpTunnel) OnStatusChange(fn func(Status)) {
+	t.sshClient.OnStatusChange(fn)
+}
+
+func (t *tcpTunnel) Close() error {
+	t.closeOnce.Do(func() {
+		t.closeErr = closeTunnel(t.attrs, func() error {
+			err := errors.Join(t.listener.Close(), t.sshClient.Close())
+			t.wg.Wait()
+			return err
+		})
+	})
+	return t.closeErr
+}
@@ -1,8 +1,11 @@
 package sshtunnel_test
 
 import (
+	"context"
+	"net"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -35,6 +38,15 @@ func TestTcpTunnelErrors(t *testing.T) {
 		require.ErrorContains(t, err, "parsing private key")
 	})
 
+	t.Run("invalid jump host private key", func(t *testing.T) {
+		privateKey, _ := tunnelhelper.SSHKeyPairs(t)
+		c := c
+		c.PrivateKey = string(privateKey)
+		c.JumpHosts = []sshtunnel.JumpHost{{User: "user", Host: "bastion", Port: "22", PrivateKey: "private_key"}}
+		_, err := sshtunnel.NewTcpTunnel(c, remoteHost, remotePort)
+		require.ErrorContains(t, err, "jump host 0: parsing private key", "it should return an error when a jump host's private key is invalid")
+	})
+
 	t.Run("invalid endoint", func(t *testing.T) {
 		privateKey, _ := tunnelhelper.SSHKeyPairs(t)
 		port, err := testhelper.GetFreePort()
@@ -46,4 +58,32 @@ func TestTcpTunnelErrors(t *testing.T) {
 		require.Error(t, err, "it should return an error when endpoint is invalid")
 		require.ErrorContains(t, err, "dial error")
 	})
+
+	t.Run("unresponsive server", func(t *testing.T) {
+		// a server accepting connections without ever completing the ssh handshake
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer func() { _ = l.Close() }()
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			if conn, err := l.Accept(); err == nil {
+				<-done
+				_ = conn.Close()
+			}
+		}()
+
+		privateKey, _ := tunnelhelper.SSHKeyPairs(t)
+		host, port, _ := net.SplitHostPort(l.Addr().String())
+		c := c
+		c.PrivateKey = string(privateKey)
+		c.Host = host
+		c.Port = port
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err = sshtunnel.NewTcpTunnelContext(ctx, c, remoteHost, remotePort)
+		require.ErrorIs(t, err, context.DeadlineExceeded, "it should abort the ssh handshake when the context is done")
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
 }
@@ -0,0 +1,43 @@
+package sshtunnel
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+// tunnelTypeKey is the attribute reporting the type of an ssh tunnel, i.e. tcp or socks5
+const tunnelTypeKey = attribute.Key("sqlconnect.ssh_tunnel.type")
+
+// telemetryAttributes returns the attributes identifying an ssh tunnel in spans and metrics
+func telemetryAttributes(c Config, tunnelType string) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{semconv.ServerAddressKey.String(c.Host), tunnelTypeKey.String(tunnelType)}
+	if port, err := strconv.Atoi(c.Port); err == nil {
+		attrs = append(attrs, semconv.ServerPortKey.Int(port))
+	}
+	return attrs
+}
+
+// startOpenSpan starts a span for opening an ssh tunnel
+func startOpenSpan(ctx context.Context, attrs []attribute.KeyValue) (context.Context, trace.Span) {
+	return telemetry.StartSpan(ctx, "sshtunnel.Open", attrs...)
+}
+
+// tunnelOpened records an ssh tunnel as open, listening on the given address
+func tunnelOpened(ctx context.Context, span trace.Span, addr string, attrs []attribute.KeyValue) {
+	span.AddEvent("ssh tunnel opened", trace.WithAttributes(attribute.String("sqlconnect.ssh_tunnel.local_address", addr)))
+	telemetry.RecordSSHTunnel(ctx, 1, attrs...)
+}
+
+// closeTunnel closes an ssh tunnel using the close function, reporting a span for it and recording the tunnel as closed
+func closeTunnel(attrs []attribute.KeyValue, close func() error) (err error) {
+	ctx, span := telemetry.StartSpan(context.Background(), "sshtunnel.Close", attrs...)
+	defer func() { telemetry.EndSpan(span, err) }()
+	telemetry.RecordSSHTunnel(ctx, -1, attrs...)
+	return close()
+}
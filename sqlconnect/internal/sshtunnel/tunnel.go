@@ -8,6 +8,15 @@ type Tunnel interface {
 	Host() string
 	// Port returns the port that the tunnel is listening on
 	Port() int
+	// HostKeyFingerprint returns the SHA256 fingerprint of the host key presented by the tunnel's ssh server,
+	// e.g. for pinning it using [Config.HostKeyFingerprint] after trusting it on first use
+	HostKeyFingerprint() string
+	// JumpHostKeyFingerprints returns the SHA256 fingerprints of the host keys presented by the jump hosts of the tunnel, in order
+	JumpHostKeyFingerprints() []string
+	// Status returns the status of the tunnel's connection to its ssh server, which is re-established transparently whenever it is lost
+	Status() Status
+	// OnStatusChange sets the function called whenever the status of the tunnel changes, replacing [Config.OnStatusChange]
+	OnStatusChange(fn func(Status))
 	// Close closes the tunnel
 	Close() error
 }
@@ -0,0 +1,149 @@
+package telemetry
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxQueryTextLength is the maximum length of the sanitized query text reported in spans
+const maxQueryTextLength = 4096
+
+// SanitizeQuery replaces the string and numeric literals of a sql query with placeholders, so that the query text can be reported without leaking any values.
+// Comments are stripped, since they may contain values too, e.g. query tags, whereas quoted identifiers are preserved, while the result is truncated to a few kilobytes.
+func SanitizeQuery(query string) string {
+	var sb strings.Builder
+	sb.Grow(min(len(query), maxQueryTextLength))
+	var prev rune // the previous rune written, for telling numeric literals apart from identifiers containing digits
+	for i := 0; i < len(query) && sb.Len() < maxQueryTextLength; {
+		r, size := utf8.DecodeRuneInString(query[i:])
+		switch {
+		case r == '\'': // string literal, with quotes escaped by doubling them or, e.g. in mysql, bigquery and databricks, by a backslash
+			j := i + 1
+			for j < len(query) {
+				if query[j] == '\\' {
+					j += 2
+					continue
+				}
+				if query[j] == '\'' {
+					if j+1 < len(query) && query[j+1] == '\'' {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			sb.WriteByte('?')
+			i, prev = min(j+1, len(query)), '?'
+			continue
+		case r == '$' && !isIdentifierRune(prev) && dollarQuoteTag(query[i:]) != "": // postgres dollar-quoted string, e.g. $$body$$ or $tag$body$tag$
+			tag := dollarQuoteTag(query[i:])
+			end := len(query)
+			if j := strings.Index(query[i+len(tag):], tag); j >= 0 {
+				end = i + len(tag) + j + len(tag)
+			}
+			sb.WriteByte('?')
+			i, prev = end, '?'
+			continue
+		case r == '"' || r == '`': // quoted identifier
+			j := strings.IndexRune(query[i+1:], r)
+			end := len(query)
+			if j >= 0 {
+				end = i + 1 + j + 1
+			}
+			sb.WriteString(query[i:end])
+			i, prev = end, r
+			continue
+		case strings.HasPrefix(query[i:], "--"): // line comment, which may contain values, e.g. query tags
+			end := len(query)
+			if j := strings.IndexByte(query[i:], '\n'); j >= 0 {
+				end = i + j
+			}
+			i = end
+			continue
+		case strings.HasPrefix(query[i:], "/*"): // block comment, replaced by a space for keeping the tokens around it apart
+			end := len(query)
+			if j := strings.Index(query[i+2:], "*/"); j >= 0 {
+				end = i + 2 + j + 2
+			}
+			if !unicode.IsSpace(prev) && prev != 0 {
+				sb.WriteByte(' ')
+				prev = ' '
+			}
+			i = end
+			continue
+		case unicode.IsDigit(r) && !isIdentifierRune(prev): // numeric literal
+			j := i
+			for j < len(query) && (isDigit(query[j]) || query[j] == '.' || query[j] == 'e' || query[j] == 'E') {
+				j++
+			}
+			sb.WriteByte('?')
+			i, prev = j, '?'
+			continue
+		}
+		sb.WriteRune(r)
+		i, prev = i+size, r
+	}
+	sanitized := strings.TrimLeftFunc(sb.String(), unicode.IsSpace) // leading and trailing comments leave whitespace behind
+	if len(sanitized) < maxQueryTextLength {
+		return strings.TrimRightFunc(sanitized, unicode.IsSpace)
+	}
+	if len(sanitized) == maxQueryTextLength {
+		return sanitized
+	}
+	end := maxQueryTextLength
+	for end > 0 && !utf8.RuneStart(sanitized[end]) {
+		end--
+	}
+	return sanitized[:end]
+}
+
+// OperationName returns the name of the operation performed by a sql query, i.e. its first keyword in upper case, e.g. SELECT
+func OperationName(query string) string {
+	query = strings.TrimLeftFunc(query, func(r rune) bool { return unicode.IsSpace(r) || r == '(' })
+	for strings.HasPrefix(query, "--") || strings.HasPrefix(query, "/*") {
+		if strings.HasPrefix(query, "--") {
+			j := strings.IndexByte(query, '\n')
+			if j < 0 {
+				return ""
+			}
+			query = query[j+1:]
+		} else {
+			j := strings.Index(query, "*/")
+			if j < 0 {
+				return ""
+			}
+			query = query[j+2:]
+		}
+		query = strings.TrimLeftFunc(query, func(r rune) bool { return unicode.IsSpace(r) || r == '(' })
+	}
+	end := strings.IndexFunc(query, func(r rune) bool { return !unicode.IsLetter(r) && r != '_' })
+	if end < 0 {
+		end = len(query)
+	}
+	return strings.ToUpper(query[:end])
+}
+
+// dollarQuoteTag returns the tag opening a dollar-quoted string at the start of s, e.g. $$ or $tag$, or an empty string if there is none.
+// Tags cannot start with a digit, thus positional parameters like $1 are not mistaken for them.
+func dollarQuoteTag(s string) string {
+	j := 1
+	if j < len(s) && (s[j] == '_' || unicode.IsLetter(rune(s[j]))) {
+		for j < len(s) && (s[j] == '_' || unicode.IsLetter(rune(s[j])) || isDigit(s[j])) {
+			j++
+		}
+	}
+	if j < len(s) && s[j] == '$' {
+		return s[:j+1]
+	}
+	return ""
+}
+
+func isIdentifierRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '$' || r == '@' || r == ':'
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
@@ -0,0 +1,52 @@
+package telemetry_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/telemetry"
+)
+
+func TestSanitizeQuery(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT 1", "SELECT ?"},
+		{"SELECT * FROM t WHERE name = 'john' AND age > 42.5", "SELECT * FROM t WHERE name = ? AND age > ?"},
+		{"SELECT * FROM t WHERE name = 'o''brien'", "SELECT * FROM t WHERE name = ?"},
+		{`SELECT c1, "c2 = 'x'", ` + "`c3`" + ` FROM t1 WHERE id = $1 OR id = ?`, `SELECT c1, "c2 = 'x'", ` + "`c3`" + ` FROM t1 WHERE id = $1 OR id = ?`},
+		{"INSERT INTO t (c1, c2) VALUES (1, 'one'), (2, 'two')", "INSERT INTO t (c1, c2) VALUES (?, ?), (?, ?)"},
+		{"/* tag: 'value' */ SELECT 1 -- comment 'x'\nFROM t", "SELECT ? \nFROM t"},
+		{"SELECT/* user_id: 42 */c1 FROM t -- email: john@example.com", "SELECT c1 FROM t"},
+		{"SELECT 1 /* unterminated 'secret'", "SELECT ?"},
+		{"SELECT 'unterminated", "SELECT ?"},
+		{`SELECT * FROM t WHERE pw = 'ab\'secret123' AND c = 'x\\'`, "SELECT * FROM t WHERE pw = ? AND c = ?"},
+		{"SELECT $$it's a secret$$, $body$ $$ 'secret' $body$ FROM t WHERE id = $1", "SELECT ?, ? FROM t WHERE id = $1"},
+		{"CREATE FUNCTION f() RETURNS int AS $fn$ SELECT 42 $fn$ LANGUAGE sql", "CREATE FUNCTION f() RETURNS int AS ? LANGUAGE sql"},
+		{"SELECT $tag$unterminated secret", "SELECT ?"},
+		{"SELECT a$b$ FROM t", "SELECT a$b$ FROM t"},
+	} {
+		require.Equal(t, tc.expected, telemetry.SanitizeQuery(tc.query), "it should sanitize query %q", tc.query)
+	}
+
+	t.Run("long query", func(t *testing.T) {
+		sanitized := telemetry.SanitizeQuery("SELECT " + strings.Repeat("c, ", 10000) + "c FROM t")
+		require.Len(t, sanitized, 4096, "it should truncate the query text")
+	})
+}
+
+func TestOperationName(t *testing.T) {
+	for query, expected := range map[string]string{
+		"select 1":                      "SELECT",
+		"  (SELECT 1) UNION (SELECT 2)": "SELECT",
+		"-- comment\nINSERT INTO t":     "INSERT",
+		"/* tag */ create table t":      "CREATE",
+		"WITH cte AS (SELECT 1)":        "WITH",
+		"":                              "",
+	} {
+		require.Equal(t, expected, telemetry.OperationName(query), "it should return the operation name of %q", query)
+	}
+}
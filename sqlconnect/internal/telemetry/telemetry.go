@@ -0,0 +1,240 @@
+// Package telemetry provides the OpenTelemetry instrumentation shared by sqlconnect's clients, drivers and ssh tunnels.
+// Spans and metrics are reported using the global tracer and meter providers, see [otel.SetTracerProvider] and [otel.SetMeterProvider].
+package telemetry
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+// ScopeName is the instrumentation scope of all spans and metrics reported by sqlconnect
+const ScopeName = "github.com/rudderlabs/sqlconnect-go/sqlconnect"
+
+// ReturnedRowsKey is the span attribute reporting the number of rows returned by a query, when known upfront
+const ReturnedRowsKey = attribute.Key("db.response.returned_rows")
+
+const (
+	returnedRowsName        = "db.client.response.returned_rows"
+	operationErrorsName     = "db.client.operation.errors"
+	connectionWaitCountName = "db.client.connection.wait_count"
+	connectionWaitTimeName  = "db.client.connection.wait_duration"
+	sshTunnelsOpenName      = "sqlconnect.ssh_tunnel.open"
+	sshTunnelReconnectsName = "sqlconnect.ssh_tunnel.reconnects"
+	sshForwardErrorsName    = "sqlconnect.ssh_tunnel.forward_errors"
+)
+
+// instruments are the metric instruments used for reporting operations
+type instruments struct {
+	operationDuration metric.Float64Histogram
+	operationErrors   metric.Int64Counter
+	returnedRows      metric.Int64Histogram
+	sshTunnelsOpen    metric.Int64UpDownCounter
+	sshReconnects     metric.Int64Counter
+	sshForwardErrors  metric.Int64Counter
+}
+
+// providerInstruments are the instruments created using a meter provider
+type providerInstruments struct {
+	provider    metric.MeterProvider
+	instruments *instruments
+}
+
+// cachedInstruments are the instruments of the last global meter provider used
+var cachedInstruments atomic.Pointer[providerInstruments]
+
+// getInstruments returns the instruments of the global meter provider, creating them whenever the global meter provider changes.
+// Instruments created before a meter provider is set are delegated to it once it is set.
+func getInstruments() *instruments {
+	provider := otel.GetMeterProvider()
+	if cached := cachedInstruments.Load(); cached != nil && cached.provider == provider {
+		return cached.instruments
+	}
+	i := newInstruments(provider.Meter(ScopeName))
+	cachedInstruments.Store(&providerInstruments{provider: provider, instruments: i})
+	return i
+}
+
+// newInstruments creates the instruments using the meter, falling back to no-op instruments if any of them can't be created
+func newInstruments(meter metric.Meter) *instruments {
+	var errs []error
+	operationDuration, err := meter.Float64Histogram(semconv.DBClientOperationDurationName,
+		metric.WithUnit(semconv.DBClientOperationDurationUnit),
+		metric.WithDescription(semconv.DBClientOperationDurationDescription),
+		metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300),
+	)
+	errs = append(errs, err)
+	operationErrors, err := meter.Int64Counter(operationErrorsName,
+		metric.WithUnit("{error}"),
+		metric.WithDescription("Number of database client operations that failed, by error class."),
+	)
+	errs = append(errs, err)
+	returnedRows, err := meter.Int64Histogram(returnedRowsName,
+		metric.WithUnit("{row}"),
+		metric.WithDescription("Number of rows returned by database client queries."),
+	)
+	errs = append(errs, err)
+	sshTunnelsOpen, err := meter.Int64UpDownCounter(sshTunnelsOpenName,
+		metric.WithUnit("{tunnel}"),
+		metric.WithDescription("Number of ssh tunnels currently open."),
+	)
+	errs = append(errs, err)
+	sshReconnects, err := meter.Int64Counter(sshTunnelReconnectsName,
+		metric.WithUnit("{reconnect}"),
+		metric.WithDescription("Number of times ssh tunnels reconnected to their ssh server after losing their connection."),
+	)
+	errs = append(errs, err)
+	sshForwardErrors, err := meter.Int64Counter(sshForwardErrorsName,
+		metric.WithUnit("{connection}"),
+		metric.WithDescription("Number of local connections that ssh tunnels failed to forward to their remote address."),
+	)
+	errs = append(errs, err)
+	if err := errors.Join(errs...); err != nil {
+		otel.Handle(err)
+		meter := noop.NewMeterProvider().Meter(ScopeName)
+		operationDuration, _ = meter.Float64Histogram(semconv.DBClientOperationDurationName)
+		operationErrors, _ = meter.Int64Counter(operationErrorsName)
+		returnedRows, _ = meter.Int64Histogram(returnedRowsName)
+		sshTunnelsOpen, _ = meter.Int64UpDownCounter(sshTunnelsOpenName)
+		sshReconnects, _ = meter.Int64Counter(sshTunnelReconnectsName)
+		sshForwardErrors, _ = meter.Int64Counter(sshForwardErrorsName)
+	}
+	return &instruments{
+		operationDuration: operationDuration,
+		operationErrors:   operationErrors,
+		returnedRows:      returnedRows,
+		sshTunnelsOpen:    sshTunnelsOpen,
+		sshReconnects:     sshReconnects,
+		sshForwardErrors:  sshForwardErrors,
+	}
+}
+
+// StartSpan starts a client span for a database operation using the global tracer provider
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(ScopeName).Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, recording the error if any, along with its class
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(semconv.ErrorTypeKey.String(ErrorType(err)))
+	}
+	span.End()
+}
+
+// RecordOperation records the duration of a database operation, along with its error class if it failed
+func RecordOperation(ctx context.Context, duration time.Duration, err error, attrs ...attribute.KeyValue) {
+	i := getInstruments()
+	if err != nil {
+		attrs = append(attrs[:len(attrs):len(attrs)], semconv.ErrorTypeKey.String(ErrorType(err)))
+		i.operationErrors.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	i.operationDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(attrs...))
+}
+
+// RecordReturnedRows records the number of rows returned by a query
+func RecordReturnedRows(ctx context.Context, rows int64, attrs ...attribute.KeyValue) {
+	getInstruments().returnedRows.Record(ctx, rows, metric.WithAttributes(attrs...))
+}
+
+// RecordSSHTunnel records an ssh tunnel being opened (delta 1) or closed (delta -1)
+func RecordSSHTunnel(ctx context.Context, delta int64, attrs ...attribute.KeyValue) {
+	getInstruments().sshTunnelsOpen.Add(ctx, delta, metric.WithAttributes(attrs...))
+}
+
+// RecordSSHTunnelReconnect records an ssh tunnel reconnecting to its ssh server
+func RecordSSHTunnelReconnect(ctx context.Context, attrs ...attribute.KeyValue) {
+	getInstruments().sshReconnects.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+// RecordSSHTunnelForwardError records an ssh tunnel failing to forward a local connection to its remote address
+func RecordSSHTunnelForwardError(ctx context.Context, attrs ...attribute.KeyValue) {
+	getInstruments().sshForwardErrors.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+// errorTypes are the values of the error.type attribute for each error class, in order of precedence
+var errorTypes = []struct {
+	class error
+	name  string
+}{
+	{sqlconnect.ErrAuthentication, "authentication"},
+	{sqlconnect.ErrPermissionDenied, "permission_denied"},
+	{sqlconnect.ErrRelationNotFound, "relation_not_found"},
+	{sqlconnect.ErrSchemaNotFound, "schema_not_found"},
+	{sqlconnect.ErrSyntax, "syntax"},
+	{sqlconnect.ErrQuotaExceeded, "quota_exceeded"},
+	{sqlconnect.ErrQueryTimeout, "query_timeout"},
+	{sqlconnect.ErrRetryable, "retryable"},
+	{context.Canceled, "canceled"},
+	{context.DeadlineExceeded, "deadline_exceeded"},
+}
+
+// ErrorType returns the value of the error.type attribute for an error, i.e. the name of its class, or _OTHER if it hasn't been classified
+func ErrorType(err error) string {
+	for _, t := range errorTypes {
+		if errors.Is(err, t.class) {
+			return t.name
+		}
+	}
+	return semconv.ErrorTypeOther.Value.AsString()
+}
+
+// RegisterPoolMetrics reports the connection pool statistics of the db, as returned by [sql.DB.Stats], until the returned function is called
+func RegisterPoolMetrics(db *sql.DB, attrs ...attribute.KeyValue) (unregister func() error) {
+	if db == nil {
+		return func() error { return nil }
+	}
+	meter := otel.Meter(ScopeName)
+	count, err1 := meter.Int64ObservableGauge(semconv.DBClientConnectionCountName,
+		metric.WithUnit(semconv.DBClientConnectionCountUnit),
+		metric.WithDescription(semconv.DBClientConnectionCountDescription),
+	)
+	maxCount, err2 := meter.Int64ObservableGauge(semconv.DBClientConnectionMaxName,
+		metric.WithUnit(semconv.DBClientConnectionMaxUnit),
+		metric.WithDescription(semconv.DBClientConnectionMaxDescription),
+	)
+	waitCount, err3 := meter.Int64ObservableCounter(connectionWaitCountName,
+		metric.WithUnit("{request}"),
+		metric.WithDescription("The total number of connection requests that had to wait for a connection to become available."),
+	)
+	waitTime, err4 := meter.Float64ObservableCounter(connectionWaitTimeName,
+		metric.WithUnit("s"),
+		metric.WithDescription("The total time spent waiting for a connection to become available."),
+	)
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		otel.Handle(err)
+		return func() error { return nil }
+	}
+	idle := metric.WithAttributes(append(attrs[:len(attrs):len(attrs)], semconv.DBClientConnectionsStateIdle)...)
+	used := metric.WithAttributes(append(attrs[:len(attrs):len(attrs)], semconv.DBClientConnectionsStateUsed)...)
+	all := metric.WithAttributes(attrs...)
+	registration, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		stats := db.Stats()
+		o.ObserveInt64(count, int64(stats.Idle), idle)
+		o.ObserveInt64(count, int64(stats.InUse), used)
+		o.ObserveInt64(maxCount, int64(stats.MaxOpenConnections), all)
+		o.ObserveInt64(waitCount, stats.WaitCount, all)
+		o.ObserveFloat64(waitTime, stats.WaitDuration.Seconds(), all)
+		return nil
+	}, count, maxCount, waitCount, waitTime)
+	if err != nil {
+		otel.Handle(err)
+		return func() error { return nil }
+	}
+	return sync.OnceValue(registration.Unregister)
+}
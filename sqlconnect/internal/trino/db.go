@@ -23,12 +23,17 @@ const (
 
 // NewDB creates a new trino db client
 func NewDB(configJSON json.RawMessage) (*DB, error) {
+	return NewDBContext(context.Background(), configJSON)
+}
+
+// NewDBContext creates a new trino db client, aborting its setup if the context is done before it completes
+func NewDBContext(ctx context.Context, configJSON json.RawMessage) (*DB, error) {
 	var config Config
-	err := config.Parse(configJSON)
+	err := config.ParseContext(ctx, configJSON)
 	if err != nil {
 		return nil, err
 	}
-	tunnelCloser, err := sshTunnelling(&config)
+	tunnel, tunnelCloser, err := sshTunnelling(ctx, &config)
 	if err != nil {
 		return nil, fmt.Errorf("configuring ssh tunnel: %w", err)
 	}
@@ -45,9 +50,14 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 		DB: base.NewDB(
 			db,
 			tunnelCloser,
+			base.WithSSHTunnel(tunnel),
 			base.WithDialect(newDialect()),
 			base.WithColumnTypeMapper(columnTypeMapper),
 			base.WithJsonRowMapper(jsonRowMapper),
+			base.WithTypeTreeMapper(typeTreeMapper),
+			base.WithColumnDDLTypes(columnDDLTypes),
+			base.WithErrorClassifier(classifyError),
+			base.WithTelemetry("trino", config.Catalog),
 			base.WithSQLCommandsOverride(func(cmds base.SQLCommands) base.SQLCommands {
 				cmds.ListCatalogs = func() (string, string) {
 					return "SHOW CATALOGS", "Catalog"
@@ -89,6 +99,48 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 				cmds.TruncateTable = func(table base.QuotedIdentifier) string {
 					return fmt.Sprintf(`DELETE FROM %[1]s`, table)
 				}
+				cmds.ListColumnDetails = func(catalog, schema, table base.UnquotedIdentifier) (string, base.ColumnDetailsColumns) {
+					stmt := fmt.Sprintf("SELECT column_name, data_type, ordinal_position, is_nullable, column_default FROM information_schema.columns WHERE table_schema = '%[1]s' AND table_name = '%[2]s'", base.EscapeSqlString(schema), base.EscapeSqlString(table))
+					if catalog != "" {
+						stmt += fmt.Sprintf(" AND table_catalog = '%[1]s'", base.EscapeSqlString(catalog))
+					}
+					// precision, scale and max length are parsed from the data type, e.g. decimal(10,2)
+					return stmt + " ORDER BY ordinal_position ASC", base.ColumnDetailsColumns{
+						Name:     "column_name",
+						Type:     "data_type",
+						Ordinal:  "ordinal_position",
+						Nullable: "is_nullable",
+						Default:  "column_default",
+					}
+				}
+				cmds.CreateTable = func(table base.QuotedIdentifier, columns []string, primaryKey []base.QuotedIdentifier, ifNotExists bool, partitionBy, clusterBy []base.QuotedIdentifier) (string, error) {
+					// primary keys, partitioning and clustering are not part of trino's sql, but properties specific to each connector
+					if len(primaryKey) > 0 {
+						return "", fmt.Errorf("primary keys: %w", sqlconnect.ErrNotSupported)
+					}
+					if len(partitionBy) > 0 {
+						return "", fmt.Errorf("partitioning tables: %w", sqlconnect.ErrNotSupported)
+					}
+					if len(clusterBy) > 0 {
+						return "", fmt.Errorf("clustering tables: %w", sqlconnect.ErrNotSupported)
+					}
+					return base.CreateTableCommand(table, ifNotExists, columns), nil
+				}
+				cmds.AddColumns = func(table base.QuotedIdentifier, columns []string) []string { // trino can only add one column per statement
+					return lo.Map(columns, func(col string, _ int) string { return fmt.Sprintf("ALTER TABLE %[1]s ADD COLUMN %[2]s", table, col) })
+				}
+				cmds.DropColumns = func(table base.QuotedIdentifier, columns []base.QuotedIdentifier) []string { // trino can only drop one column per statement
+					return lo.Map(columns, func(col base.QuotedIdentifier, _ int) string {
+						return fmt.Sprintf("ALTER TABLE %[1]s DROP COLUMN %[2]s", table, col)
+					})
+				}
+				cmds.AlterColumnType = func(table, column base.QuotedIdentifier, _, _, ddlType string) ([]string, error) {
+					return []string{fmt.Sprintf("ALTER TABLE %[1]s ALTER COLUMN %[2]s SET DATA TYPE %[3]s", table, column, ddlType)}, nil
+				}
+				cmds.ExplainQuery = func(sql string) (string, func(string) (sqlconnect.QueryEstimate, error)) {
+					return "EXPLAIN " + base.TrimQuery(sql), parseQueryPlan
+				}
+				cmds.Merge = base.MergeInto
 				return cmds
 			}),
 		),
@@ -96,12 +148,12 @@ func NewDB(configJSON json.RawMessage) (*DB, error) {
 }
 
 // passing config as a pointer since we might need to modify [customClientName]
-func sshTunnelling(config *Config) (tunnelCloser func() error, err error) {
+func sshTunnelling(ctx context.Context, config *Config) (tunnel sshtunnel.Tunnel, tunnelCloser func() error, err error) {
 	tunnelCloser = func() error { return nil }
 	if config.TunnelInfo != nil {
-		tunnel, err := sshtunnel.NewSocks5Tunnel(*config.TunnelInfo)
+		tunnel, err = sshtunnel.NewSocks5TunnelContext(ctx, *config.TunnelInfo)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		customClientKey := uuid.New().String()
 		config.customClientName = customClientKey
@@ -113,12 +165,12 @@ func sshTunnelling(config *Config) (tunnelCloser func() error, err error) {
 			return tunnel.Close()
 		}
 	}
-	return tunnelCloser, nil
+	return tunnel, tunnelCloser, nil
 }
 
 func init() {
-	sqlconnect.RegisterDBFactory(DatabaseType, func(credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
-		return NewDB(credentialsJSON)
+	sqlconnect.RegisterDBContextFactory(DatabaseType, func(ctx context.Context, credentialsJSON json.RawMessage) (sqlconnect.DB, error) {
+		return NewDBContext(ctx, credentialsJSON)
 	})
 }
 
@@ -126,6 +178,11 @@ type DB struct {
 	*base.DB
 }
 
+// WithInterceptors returns a copy of the db sharing its connection pool and configuration, which runs the given interceptors around its queries and statements
+func (db *DB) WithInterceptors(interceptors ...sqlconnect.Interceptor) sqlconnect.DB {
+	return &DB{DB: db.DB.WithInterceptors(interceptors...)}
+}
+
 func (db *DB) Ping() error {
 	return db.PingContext(context.Background())
 }
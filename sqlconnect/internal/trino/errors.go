@@ -0,0 +1,50 @@
+package trino
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/trinodb/trino-go-client/trino"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+// trinoErrorClasses maps the names of trino errors to their error classes
+var trinoErrorClasses = map[string][]error{
+	"PERMISSION_DENIED":            {sqlconnect.ErrPermissionDenied},
+	"TABLE_NOT_FOUND":              {sqlconnect.ErrRelationNotFound},
+	"SCHEMA_NOT_FOUND":             {sqlconnect.ErrSchemaNotFound},
+	"CATALOG_NOT_FOUND":            {sqlconnect.ErrSchemaNotFound},
+	"SYNTAX_ERROR":                 {sqlconnect.ErrSyntax},
+	"EXCEEDED_TIME_LIMIT":          {sqlconnect.ErrQueryTimeout},
+	"ABANDONED_QUERY":              {sqlconnect.ErrQueryTimeout},
+	"QUERY_QUEUE_FULL":             {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"QUERY_REJECTED":               {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"SERVER_STARTING_UP":           {sqlconnect.ErrRetryable},
+	"SERVER_SHUTTING_DOWN":         {sqlconnect.ErrRetryable},
+	"NO_NODES_AVAILABLE":           {sqlconnect.ErrRetryable},
+	"REMOTE_TASK_ERROR":            {sqlconnect.ErrRetryable},
+	"PAGE_TRANSPORT_TIMEOUT":       {sqlconnect.ErrRetryable},
+	"TOO_MANY_REQUESTS_FAILED":     {sqlconnect.ErrRetryable},
+	"CLUSTER_OUT_OF_MEMORY":        {sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable},
+	"EXCEEDED_GLOBAL_MEMORY_LIMIT": {sqlconnect.ErrQuotaExceeded},
+}
+
+// classifyError classifies trino errors using the name of the error reported by the server, or the status code of the response for failed requests
+func classifyError(err error) []error {
+	queryErr, ok := errors.AsType[*trino.ErrQueryFailed](err)
+	if !ok {
+		return nil
+	}
+	if trinoErr, ok := errors.AsType[*trino.ErrTrino](queryErr.Unwrap()); ok {
+		if classes, ok := trinoErrorClasses[trinoErr.ErrorName]; ok {
+			return classes
+		}
+		if strings.HasPrefix(trinoErr.ErrorName, "EXCEEDED_") { // e.g. EXCEEDED_LOCAL_MEMORY_LIMIT or EXCEEDED_CPU_LIMIT
+			return []error{sqlconnect.ErrQuotaExceeded}
+		}
+		return nil
+	}
+	return base.ClassifyHTTPStatus(queryErr.StatusCode)
+}
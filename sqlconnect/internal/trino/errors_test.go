@@ -0,0 +1,41 @@
+package trino
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/trinodb/trino-go-client/trino"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestClassifyError(t *testing.T) {
+	queryFailed := func(errorName string) error {
+		return &trino.ErrQueryFailed{StatusCode: http.StatusOK, Reason: &trino.ErrTrino{ErrorName: errorName, ErrorType: "USER_ERROR"}}
+	}
+	for _, tc := range []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "table not found", err: queryFailed("TABLE_NOT_FOUND"), expected: []error{sqlconnect.ErrRelationNotFound}},
+		{name: "schema not found", err: queryFailed("SCHEMA_NOT_FOUND"), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "catalog not found", err: fmt.Errorf("listing schemas: %w", queryFailed("CATALOG_NOT_FOUND")), expected: []error{sqlconnect.ErrSchemaNotFound}},
+		{name: "syntax error", err: queryFailed("SYNTAX_ERROR"), expected: []error{sqlconnect.ErrSyntax}},
+		{name: "permission denied", err: queryFailed("PERMISSION_DENIED"), expected: []error{sqlconnect.ErrPermissionDenied}},
+		{name: "exceeded time limit", err: queryFailed("EXCEEDED_TIME_LIMIT"), expected: []error{sqlconnect.ErrQueryTimeout}},
+		{name: "exceeded cpu limit", err: queryFailed("EXCEEDED_CPU_LIMIT"), expected: []error{sqlconnect.ErrQuotaExceeded}},
+		{name: "query queue full", err: queryFailed("QUERY_QUEUE_FULL"), expected: []error{sqlconnect.ErrQuotaExceeded, sqlconnect.ErrRetryable}},
+		{name: "unauthorized", err: &trino.ErrQueryFailed{StatusCode: http.StatusUnauthorized, Reason: errors.New("unauthorized")}, expected: []error{sqlconnect.ErrAuthentication}},
+		{name: "service unavailable", err: &trino.ErrQueryFailed{StatusCode: http.StatusServiceUnavailable, Reason: errors.New("unavailable")}, expected: []error{sqlconnect.ErrRetryable}},
+		{name: "other trino error", err: queryFailed("DIVISION_BY_ZERO")},
+		{name: "other error", err: errors.New("other")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyError(tc.err))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package trino
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
+)
+
+var (
+	// planNodeRegex matches the name of a plan node, e.g. └─ TableScan[table = memory:0]
+	planNodeRegex = regexp.MustCompile(`^[\s│├└─]*([A-Za-z]+)\[`)
+	// planEstimatesRegex matches the row and size estimates of a plan node, e.g. Estimates: {rows: 5 (25B), cpu: 25, memory: 0B, network: 0B}
+	planEstimatesRegex = regexp.MustCompile(`Estimates: \{rows: ([0-9,?]+) \(([^)]*)\)`)
+)
+
+// parseQueryPlan extracts an estimate out of a plan returned by EXPLAIN.
+// Rows are estimated using the output of the plan's root node, whereas bytes are summed across the nodes scanning tables.
+// Estimates are left empty if the connector doesn't provide table statistics, in which case trino reports them as unknown (?).
+func parseQueryPlan(plan string) (sqlconnect.QueryEstimate, error) {
+	var (
+		estimate      sqlconnect.QueryEstimate
+		node          string
+		bytes         int64
+		bytesKnown    = true
+		scansFound    bool
+		rootEstimated bool
+	)
+	for line := range strings.Lines(plan) {
+		if match := planNodeRegex.FindStringSubmatch(line); match != nil {
+			node = match[1]
+			continue
+		}
+		match := planEstimatesRegex.FindStringSubmatch(line)
+		if match == nil || node == "" {
+			continue
+		}
+		if !rootEstimated {
+			rootEstimated = true
+			if rows, err := strconv.ParseInt(strings.ReplaceAll(match[1], ",", ""), 10, 64); err == nil {
+				estimate.Rows = &rows
+			}
+		}
+		if strings.HasPrefix(node, "TableScan") || strings.HasPrefix(node, "Scan") { // e.g. ScanFilterProject
+			scansFound = true
+			size, err := base.ParseByteSize(match[2])
+			if err != nil {
+				bytesKnown = false
+			}
+			bytes += size
+		}
+		node = "" // only the first estimates of a node are considered, i.e. the output of the scan for nodes combining a scan with other operations
+	}
+	if !rootEstimated {
+		return sqlconnect.QueryEstimate{}, fmt.Errorf("no estimates found in plan: %q", plan)
+	}
+	if scansFound && bytesKnown {
+		estimate.Bytes = &bytes
+	}
+	return estimate, nil
+}
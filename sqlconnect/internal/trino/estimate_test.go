@@ -0,0 +1,54 @@
+package trino
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQueryPlan(t *testing.T) {
+	t.Run("with statistics", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`Trino version: 435
+Fragment 0 [SINGLE]
+    Output layout: [a, b]
+    Output partitioning: SINGLE []
+    Output[columnNames = [a, b]]
+    │   Layout: [a:integer, b:varchar]
+    │   Estimates: {rows: 1,200 (11.72kB), cpu: 0, memory: 0B, network: 11.72kB}
+    └─ RemoteSource[sourceFragmentIds = [1]]
+           Layout: [a:integer, b:varchar]
+
+Fragment 1 [SOURCE]
+    Output layout: [a, b]
+    Output partitioning: SINGLE []
+    ScanFilterProject[table = memory:0, filterPredicate = (a > 1)]
+        Layout: [a:integer, b:varchar]
+        Estimates: {rows: 2,000 (20kB), cpu: 20k, memory: 0B, network: 0B}/{rows: 1,200 (11.72kB), cpu: 40k, memory: 0B, network: 0B}/{rows: 1,200 (11.72kB), cpu: 40k, memory: 0B, network: 0B}
+`)
+		require.NoError(t, err)
+		require.NotNil(t, estimate.Rows)
+		require.EqualValues(t, 1200, *estimate.Rows)
+		require.NotNil(t, estimate.Bytes)
+		require.EqualValues(t, 20*1024, *estimate.Bytes)
+		require.Nil(t, estimate.Partitions)
+	})
+
+	t.Run("without statistics", func(t *testing.T) {
+		estimate, err := parseQueryPlan(`Fragment 0 [SINGLE]
+    Output[columnNames = [a]]
+    │   Layout: [a:integer]
+    │   Estimates: {rows: ? (?), cpu: ?, memory: 0B, network: 0B}
+    └─ TableScan[table = postgresql:public.t]
+           Layout: [a:integer]
+           Estimates: {rows: ? (?), cpu: ?, memory: 0B, network: 0B}
+`)
+		require.NoError(t, err)
+		require.Nil(t, estimate.Rows)
+		require.Nil(t, estimate.Bytes)
+	})
+
+	t.Run("invalid plan", func(t *testing.T) {
+		_, err := parseQueryPlan(`Output[columnNames = [a]]`)
+		require.ErrorContains(t, err, "no estimates found in plan")
+	})
+}
@@ -1,6 +1,7 @@
 package trino_test
 
 import (
+	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -26,6 +27,12 @@ func TestTrinoDB(t *testing.T) {
 		strings.ToLower,
 		integrationtest.Options{
 			SpecialCharactersInQuotedTable: "_12", // No special characters allowed in table names :/
+			LongRunningQuery: func(marker string) string {
+				return fmt.Sprintf("SELECT '%s', COUNT(*) FROM UNNEST(sequence(1, 10000)) a(x) CROSS JOIN UNNEST(sequence(1, 10000)) b(y) CROSS JOIN UNNEST(sequence(1, 10000)) c(z) WHERE x + y + z = 0", marker)
+			},
+			RunningQueries: func(marker string) string {
+				return fmt.Sprintf("SELECT COUNT(*) FROM system.runtime.queries WHERE state NOT IN ('FINISHED', 'FAILED') AND query LIKE '%%%s%%' AND query NOT LIKE '%%system.runtime.queries%%'", marker)
+			},
 		},
 	)
 
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
@@ -36,6 +37,9 @@ var columnTypeMappings = map[string]string{
 	"MAP":   "json",
 }
 
+// mapping of rudder types to database column types used for creating tables, the reverse of [columnTypeMappings]
+var columnDDLTypes = base.ColumnDDLTypes(columnTypeMappings, "BIGINT", "DOUBLE", "VARCHAR", "TIMESTAMP(6) WITH TIME ZONE", "BOOLEAN", "JSON")
+
 var re = regexp.MustCompile(`(\(.+\)|<.+>)`) // remove type parameters [<>] and size constraints [()]
 
 func columnTypeMapper(columnType base.ColumnType) string {
@@ -55,6 +59,16 @@ func columnTypeMapper(columnType base.ColumnType) string {
 	return databaseTypeName
 }
 
+// typeTreeMapper parses the type trees of array, map and row columns.
+// Field names of row types are lowercased, since the driver reports them upper-cased for query results.
+func typeTreeMapper(columnType base.ColumnType) *sqlconnect.TypeNode {
+	tree := base.NestedTypeTree(columnType.DatabaseTypeName())
+	base.WalkTypeTree(tree, func(node *sqlconnect.TypeNode) {
+		node.Name = strings.ToLower(node.Name)
+	})
+	return tree
+}
+
 // jsonRowMapper maps a row's scanned column to a json object's field
 func jsonRowMapper(databaseTypeName string, value any) any {
 	switch databaseTypeName {
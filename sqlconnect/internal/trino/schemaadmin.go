@@ -4,8 +4,6 @@ import (
 	"context"
 	"errors"
 
-	"github.com/trinodb/trino-go-client/trino"
-
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
 )
 
@@ -33,16 +31,7 @@ func (db *DB) ListSchemas(ctx context.Context, opts ...sqlconnect.Option) ([]sql
 	return schemas, nil
 }
 
-// trinoCatalogNotFoundErrorCode is the Trino error code for "catalog not found"
-const trinoCatalogNotFoundErrorCode = 44
-
+// isCatalogNotFoundError returns true if the error is caused by a catalog (or schema) that doesn't exist
 func isCatalogNotFoundError(err error) bool {
-	var queryErr *trino.ErrQueryFailed
-	if errors.As(err, &queryErr) {
-		var trinoErr *trino.ErrTrino
-		if errors.As(queryErr.Unwrap(), &trinoErr) {
-			return trinoErr.ErrorCode == trinoCatalogNotFoundErrorCode
-		}
-	}
-	return false
+	return errors.Is(err, sqlconnect.ErrSchemaNotFound)
 }
@@ -2,8 +2,10 @@ package trino
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect/internal/base"
 )
 
 // ListTables overrides the base implementation to handle nonexistent catalog gracefully
@@ -17,3 +19,33 @@ func (db *DB) ListTables(ctx context.Context, schema sqlconnect.SchemaRef, opts
 	}
 	return tables, nil
 }
+
+// ListColumnsForSqlQuery overrides the base implementation to describe the query's output using a prepared statement, without executing it
+func (db *DB) ListColumnsForSqlQuery(ctx context.Context, sql string, opts ...sqlconnect.Option) ([]sqlconnect.ColumnRef, error) {
+	return db.DB.ListColumnsForSqlQueryUsing(ctx, sql, db.describeQuery, opts...)
+}
+
+// describeQuery prepares the query and describes its output. Prepared statements are scoped to a connection, so all statements run on the same one.
+func (db *DB) describeQuery(ctx context.Context, sql string) ([]base.QueryColumn, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting connection for describing query: %w", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("PREPARE %[1]s FROM %[2]s", describeStatementName, base.TrimQuery(sql))); err != nil {
+		return nil, fmt.Errorf("preparing query: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "DEALLOCATE PREPARE "+describeStatementName)
+	}()
+
+	rows, err := conn.QueryContext(ctx, "DESCRIBE OUTPUT "+describeStatementName)
+	if err != nil {
+		return nil, fmt.Errorf("describing query output: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+	return base.ScanQueryColumns(rows, "Column Name", "Type")
+}
+
+const describeStatementName = "sqlconnect_describe"
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"net"
 )
@@ -41,12 +42,17 @@ func AllowLoopback(allow bool) HostValidationOption {
 // not necessarily the address dialled later. It raises the bar for a
 // caller-supplied host without claiming to close that gap.
 func ValidateHost(hostname string, opts ...HostValidationOption) error {
+	return ValidateHostContext(context.Background(), hostname, opts...)
+}
+
+// ValidateHostContext is like [ValidateHost], aborting the hostname's lookup if the context is done before it completes.
+func ValidateHostContext(ctx context.Context, hostname string, opts ...HostValidationOption) error {
 	var options hostValidationOptions
 	for _, opt := range opts {
 		opt(&options)
 	}
 
-	addrs, err := net.LookupHost(hostname)
+	addrs, err := net.DefaultResolver.LookupHost(ctx, hostname)
 	if err != nil {
 		return fmt.Errorf("looking up hostname %s: %w", hostname, err)
 	}
@@ -1,14 +1,28 @@
 package sqlconnect
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"time"
+)
 
 type Option func(options *Options)
 
 type Options struct {
-	Schema  string
-	Catalog string
-	Type    RelationType
-	Prefix  string
+	Schema      string
+	Catalog     string
+	Type        RelationType
+	Prefix      string
+	BatchSize   int
+	IfNotExists bool
+	PartitionBy []string
+	ClusterBy   []string
+	// ExecuteFallback allows executing a query for listing its columns
+	ExecuteFallback bool
+	// MaxLatency is the maximum time a partial batch of rows is held before being sent
+	MaxLatency time.Duration
+	// BufferSize is the number of batches of rows that can be read ahead of the receiver
+	BufferSize int
 }
 
 func WithSchema(schema string) Option {
@@ -35,6 +49,55 @@ func WithPrefix(prefix string) Option {
 	}
 }
 
+// WithBatchSize sets the number of rows to be sent to the database in a single batch
+func WithBatchSize(batchSize int) Option {
+	return func(options *Options) {
+		options.BatchSize = batchSize
+	}
+}
+
+// WithIfNotExists skips creating a table if it already exists
+func WithIfNotExists() Option {
+	return func(options *Options) {
+		options.IfNotExists = true
+	}
+}
+
+// WithPartitionBy sets the columns to partition a table by
+func WithPartitionBy(columns ...string) Option {
+	return func(options *Options) {
+		options.PartitionBy = columns
+	}
+}
+
+// WithClusterBy sets the columns to cluster a table by
+func WithClusterBy(columns ...string) Option {
+	return func(options *Options) {
+		options.ClusterBy = columns
+	}
+}
+
+// WithExecuteFallback allows listing the columns of a query by executing it, if the query's columns cannot be retrieved without executing it
+func WithExecuteFallback() Option {
+	return func(options *Options) {
+		options.ExecuteFallback = true
+	}
+}
+
+// WithMaxLatency sends a partial batch of rows once its first row has been waiting for the given duration, instead of waiting for the batch to fill up
+func WithMaxLatency(maxLatency time.Duration) Option {
+	return func(options *Options) {
+		options.MaxLatency = maxLatency
+	}
+}
+
+// WithBufferSize sets the number of batches of rows that can be read ahead of the receiver, i.e. the buffer of the channel receiving them
+func WithBufferSize(bufferSize int) Option {
+	return func(options *Options) {
+		options.BufferSize = bufferSize
+	}
+}
+
 func NewOptions(opts ...Option) Options {
 	var o Options
 	for _, opt := range opts {
@@ -43,6 +106,57 @@ func NewOptions(opts ...Option) Options {
 	return o
 }
 
+// optionField identifies a field of [Options], for declaring the options supported by an operation
+type optionField int
+
+const (
+	schemaOption optionField = iota
+	catalogOption
+	typeOption
+	prefixOption
+	batchSizeOption
+	ifNotExistsOption
+	partitionByOption
+	clusterByOption
+	executeFallbackOption
+	maxLatencyOption
+	bufferSizeOption
+)
+
+// optionFields describe the fields of [Options], returning the value of a field if it is set, or nil for flags
+var optionFields = []struct {
+	field optionField
+	name  string
+	value func(o Options) (value any, set bool)
+}{
+	{schemaOption, "schema", func(o Options) (any, bool) { return o.Schema, o.Schema != "" }},
+	{catalogOption, "catalog", func(o Options) (any, bool) { return o.Catalog, o.Catalog != "" }},
+	{typeOption, "type", func(o Options) (any, bool) { return o.Type, o.Type != "" }},
+	{prefixOption, "prefix", func(o Options) (any, bool) { return o.Prefix, o.Prefix != "" }},
+	{batchSizeOption, "batch size", func(o Options) (any, bool) { return o.BatchSize, o.BatchSize != 0 }},
+	{ifNotExistsOption, "if not exists", func(o Options) (any, bool) { return nil, o.IfNotExists }},
+	{partitionByOption, "partition by", func(o Options) (any, bool) { return o.PartitionBy, len(o.PartitionBy) > 0 }},
+	{clusterByOption, "cluster by", func(o Options) (any, bool) { return o.ClusterBy, len(o.ClusterBy) > 0 }},
+	{executeFallbackOption, "execute fallback", func(o Options) (any, bool) { return nil, o.ExecuteFallback }},
+	{maxLatencyOption, "max latency", func(o Options) (any, bool) { return o.MaxLatency, o.MaxLatency != 0 }},
+	{bufferSizeOption, "buffer size", func(o Options) (any, bool) { return o.BufferSize, o.BufferSize != 0 }},
+}
+
+// checkSupported returns an error for the first option which is set, but isn't one of the options supported by the operation
+func (o Options) checkSupported(operation string, supported ...optionField) error {
+	for _, f := range optionFields {
+		value, set := f.value(o)
+		if !set || slices.Contains(supported, f.field) {
+			continue
+		}
+		if value == nil {
+			return fmt.Errorf("%s is not supported for %s", f.name, operation)
+		}
+		return fmt.Errorf("%s is not supported for %s: %v", f.name, operation, value)
+	}
+	return nil
+}
+
 type TableListOptions struct {
 	Catalog string
 	Prefix  string
@@ -50,13 +164,9 @@ type TableListOptions struct {
 
 func NewTableListOptions(opts ...Option) (TableListOptions, error) {
 	o := NewOptions(opts...)
-	if o.Schema != "" {
-		return TableListOptions{}, fmt.Errorf("schema is not supported for table listing: %s", o.Schema)
-	}
-	if o.Type != "" {
-		return TableListOptions{}, fmt.Errorf("type is not supported for table listing: %s", o.Type)
+	if err := o.checkSupported("table listing", catalogOption, prefixOption); err != nil {
+		return TableListOptions{}, err
 	}
-
 	return TableListOptions{
 		Catalog: o.Catalog,
 		Prefix:  o.Prefix,
@@ -69,17 +179,102 @@ type FilterOptions struct {
 
 func NewFilterOptions(opts ...Option) (FilterOptions, error) {
 	o := NewOptions(opts...)
-	if o.Schema != "" {
-		return FilterOptions{}, fmt.Errorf("schema is not supported for filtering: %s", o.Schema)
+	if err := o.checkSupported("filtering", catalogOption); err != nil {
+		return FilterOptions{}, err
 	}
-	if o.Prefix != "" {
-		return FilterOptions{}, fmt.Errorf("prefix is not supported for filtering: %s", o.Prefix)
+	return FilterOptions{
+		Catalog: o.Catalog,
+	}, nil
+}
+
+// DefaultInsertBatchSize is the number of rows inserted in a single batch when [WithBatchSize] is not provided
+const DefaultInsertBatchSize = 1000
+
+type InsertOptions struct {
+	BatchSize int
+}
+
+func NewInsertOptions(opts ...Option) (InsertOptions, error) {
+	o := NewOptions(opts...)
+	if err := o.checkSupported("inserting rows", batchSizeOption); err != nil {
+		return InsertOptions{}, err
+	}
+	if o.BatchSize < 0 {
+		return InsertOptions{}, fmt.Errorf("invalid batch size: %d", o.BatchSize)
+	}
+	batchSize := o.BatchSize
+	if batchSize == 0 {
+		batchSize = DefaultInsertBatchSize
 	}
-	if o.Type != "" {
-		return FilterOptions{}, fmt.Errorf("type is not supported for filtering: %s", o.Type)
+	return InsertOptions{
+		BatchSize: batchSize,
+	}, nil
+}
+
+// MergeOptions are the options for merging tables. No options are currently supported.
+type MergeOptions struct{}
+
+func NewMergeOptions(opts ...Option) (MergeOptions, error) {
+	o := NewOptions(opts...)
+	if err := o.checkSupported("merging tables"); err != nil {
+		return MergeOptions{}, err
 	}
+	return MergeOptions{}, nil
+}
 
-	return FilterOptions{
-		Catalog: o.Catalog,
+type CreateTableOptions struct {
+	IfNotExists bool
+	PartitionBy []string
+	ClusterBy   []string
+}
+
+func NewCreateTableOptions(opts ...Option) (CreateTableOptions, error) {
+	o := NewOptions(opts...)
+	if err := o.checkSupported("creating tables", ifNotExistsOption, partitionByOption, clusterByOption); err != nil {
+		return CreateTableOptions{}, err
+	}
+	return CreateTableOptions{
+		IfNotExists: o.IfNotExists,
+		PartitionBy: o.PartitionBy,
+		ClusterBy:   o.ClusterBy,
+	}, nil
+}
+
+type ColumnsForSqlQueryOptions struct {
+	ExecuteFallback bool
+}
+
+func NewColumnsForSqlQueryOptions(opts ...Option) (ColumnsForSqlQueryOptions, error) {
+	o := NewOptions(opts...)
+	if err := o.checkSupported("listing query columns", executeFallbackOption); err != nil {
+		return ColumnsForSqlQueryOptions{}, err
+	}
+	return ColumnsForSqlQueryOptions{
+		ExecuteFallback: o.ExecuteFallback,
+	}, nil
+}
+
+type QueryBatchesOptions struct {
+	MaxLatency time.Duration
+	BufferSize int
+}
+
+func NewQueryBatchesOptions(opts ...Option) (QueryBatchesOptions, error) {
+	o := NewOptions(opts...)
+	if o.BatchSize != 0 {
+		return QueryBatchesOptions{}, fmt.Errorf("batch size option is not supported for querying batches, since it is an argument: %d", o.BatchSize)
+	}
+	if err := o.checkSupported("querying batches", maxLatencyOption, bufferSizeOption); err != nil {
+		return QueryBatchesOptions{}, err
+	}
+	if o.MaxLatency < 0 {
+		return QueryBatchesOptions{}, fmt.Errorf("invalid max latency: %s", o.MaxLatency)
+	}
+	if o.BufferSize < 0 {
+		return QueryBatchesOptions{}, fmt.Errorf("invalid buffer size: %d", o.BufferSize)
+	}
+	return QueryBatchesOptions{
+		MaxLatency: o.MaxLatency,
+		BufferSize: o.BufferSize,
 	}, nil
 }
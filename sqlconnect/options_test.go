@@ -1,7 +1,9 @@
 package sqlconnect
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -12,11 +14,13 @@ func TestNewOptions(t *testing.T) {
 		WithCatalog("catalog"),
 		WithRelationType(TableRelation),
 		WithPrefix("prefix"),
+		WithBatchSize(10),
 	)
 	require.Equal(t, "schema", o.Schema)
 	require.Equal(t, "catalog", o.Catalog)
 	require.Equal(t, TableRelation, o.Type)
 	require.Equal(t, "prefix", o.Prefix)
+	require.Equal(t, 10, o.BatchSize)
 }
 
 func TestNewTableListOptions(t *testing.T) {
@@ -78,3 +82,138 @@ func TestNewFilterOptions(t *testing.T) {
 		require.Contains(t, err.Error(), "type is not supported for filtering")
 	})
 }
+
+func TestNewInsertOptions(t *testing.T) {
+	t.Run("valid with batch size", func(t *testing.T) {
+		opts, err := NewInsertOptions(WithBatchSize(10))
+		require.NoError(t, err)
+		require.Equal(t, 10, opts.BatchSize)
+	})
+
+	t.Run("valid with no options", func(t *testing.T) {
+		opts, err := NewInsertOptions()
+		require.NoError(t, err)
+		require.Equal(t, DefaultInsertBatchSize, opts.BatchSize)
+	})
+
+	t.Run("rejects negative batch size", func(t *testing.T) {
+		_, err := NewInsertOptions(WithBatchSize(-1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid batch size")
+	})
+
+	t.Run("rejects catalog", func(t *testing.T) {
+		_, err := NewInsertOptions(WithCatalog("catalog"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "catalog is not supported for inserting rows")
+	})
+}
+
+func TestNewMergeOptions(t *testing.T) {
+	t.Run("valid with no options", func(t *testing.T) {
+		_, err := NewMergeOptions()
+		require.NoError(t, err)
+	})
+
+	t.Run("rejects batch size", func(t *testing.T) {
+		_, err := NewMergeOptions(WithBatchSize(10))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "batch size is not supported for merging tables")
+	})
+}
+
+func TestNewCreateTableOptions(t *testing.T) {
+	t.Run("valid with all options", func(t *testing.T) {
+		opts, err := NewCreateTableOptions(WithIfNotExists(), WithPartitionBy("c1"), WithClusterBy("c2", "c3"))
+		require.NoError(t, err)
+		require.True(t, opts.IfNotExists)
+		require.Equal(t, []string{"c1"}, opts.PartitionBy)
+		require.Equal(t, []string{"c2", "c3"}, opts.ClusterBy)
+	})
+
+	t.Run("valid with no options", func(t *testing.T) {
+		opts, err := NewCreateTableOptions()
+		require.NoError(t, err)
+		require.Equal(t, CreateTableOptions{}, opts)
+	})
+
+	t.Run("rejects schema", func(t *testing.T) {
+		_, err := NewCreateTableOptions(WithSchema("schema"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema is not supported for creating tables")
+	})
+
+	t.Run("partition by is rejected for other operations", func(t *testing.T) {
+		_, err := NewTableListOptions(WithPartitionBy("c1"))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "partition by is not supported for table listing")
+	})
+}
+
+func TestNewColumnsForSqlQueryOptions(t *testing.T) {
+	t.Run("valid with execute fallback", func(t *testing.T) {
+		opts, err := NewColumnsForSqlQueryOptions(WithExecuteFallback())
+		require.NoError(t, err)
+		require.True(t, opts.ExecuteFallback)
+	})
+
+	t.Run("valid with no options", func(t *testing.T) {
+		opts, err := NewColumnsForSqlQueryOptions()
+		require.NoError(t, err)
+		require.False(t, opts.ExecuteFallback)
+	})
+
+	t.Run("rejects batch size", func(t *testing.T) {
+		_, err := NewColumnsForSqlQueryOptions(WithBatchSize(10))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "batch size is not supported for listing query columns")
+	})
+
+	t.Run("execute fallback is rejected for other operations", func(t *testing.T) {
+		_, err := NewInsertOptions(WithExecuteFallback())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "execute fallback is not supported for inserting rows")
+	})
+}
+
+func TestNewQueryBatchesOptions(t *testing.T) {
+	t.Run("valid with max latency and buffer size", func(t *testing.T) {
+		opts, err := NewQueryBatchesOptions(WithMaxLatency(time.Second), WithBufferSize(2))
+		require.NoError(t, err)
+		require.Equal(t, time.Second, opts.MaxLatency)
+		require.Equal(t, 2, opts.BufferSize)
+	})
+
+	t.Run("valid with no options", func(t *testing.T) {
+		opts, err := NewQueryBatchesOptions()
+		require.NoError(t, err)
+		require.Zero(t, opts.MaxLatency)
+		require.Zero(t, opts.BufferSize)
+	})
+
+	t.Run("rejects negative buffer size", func(t *testing.T) {
+		_, err := NewQueryBatchesOptions(WithBufferSize(-1))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid buffer size")
+	})
+
+	t.Run("rejects batch size", func(t *testing.T) {
+		_, err := NewQueryBatchesOptions(WithBatchSize(10))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "batch size option is not supported for querying batches")
+	})
+
+	t.Run("max latency is rejected for other operations", func(t *testing.T) {
+		_, err := NewInsertOptions(WithMaxLatency(time.Second))
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "max latency is not supported for inserting rows")
+	})
+}
+
+func TestCheckSupported(t *testing.T) {
+	require.Len(t, optionFields, reflect.TypeFor[Options]().NumField(), "it should describe every option")
+
+	require.NoError(t, NewOptions(WithSchema("s"), WithPrefix("p")).checkSupported("testing", schemaOption, prefixOption))
+	require.EqualError(t, NewOptions(WithSchema("s"), WithPrefix("p")).checkSupported("testing", schemaOption), "prefix is not supported for testing: p")
+	require.EqualError(t, NewOptions(WithIfNotExists()).checkSupported("testing"), "if not exists is not supported for testing", "it should not report the value of flags")
+}
@@ -0,0 +1,30 @@
+package sqlconnect
+
+// QueryHandle identifies a query submitted using [AsyncQuerier.SubmitQuery].
+// It can be serialized to json, so that the query can be tracked and its results fetched later on, even by another process.
+type QueryHandle struct {
+	DatabaseType string `json:"databaseType"`       // the type of the database the query was submitted to, e.g. snowflake
+	ID           string `json:"id"`                 // the id of the query in the warehouse, i.e. snowflake's query id, bigquery's job id or redshift's statement id
+	Location     string `json:"location,omitempty"` // the location of the query, if required for identifying it, e.g. the location of a bigquery job
+}
+
+// QueryState is the state of a submitted query
+type QueryState string
+
+const (
+	QueryStateRunning   QueryState = "running"   // the query is queued or running
+	QueryStateSucceeded QueryState = "succeeded" // the query has completed successfully and its results can be fetched
+	QueryStateFailed    QueryState = "failed"    // the query has failed
+	QueryStateCanceled  QueryState = "canceled"  // the query has been canceled before completing
+)
+
+// QueryStatus is the status of a submitted query, as returned by [AsyncQuerier.QueryStatus]
+type QueryStatus struct {
+	State QueryState `json:"state"`
+	Err   error      `json:"-"` // the error the query has failed with, classified into the warehouse-agnostic error classes it belongs to
+}
+
+// Done returns true if the query is no longer running
+func (s QueryStatus) Done() bool {
+	return s.State != QueryStateRunning
+}
@@ -0,0 +1,25 @@
+package sqlconnect_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQueryHandle(t *testing.T) {
+	handle := sqlconnect.QueryHandle{DatabaseType: "bigquery", ID: "job_123", Location: "EU"}
+	b, err := json.Marshal(handle)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"databaseType":"bigquery","id":"job_123","location":"EU"}`, string(b))
+
+	var unmarshalled sqlconnect.QueryHandle
+	require.NoError(t, json.Unmarshal(b, &unmarshalled))
+	require.Equal(t, handle, unmarshalled)
+
+	b, err = json.Marshal(sqlconnect.QueryHandle{DatabaseType: "snowflake", ID: "01b2c3"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"databaseType":"snowflake","id":"01b2c3"}`, string(b), "it should omit an empty location")
+}
@@ -0,0 +1,35 @@
+package sqlconnect
+
+import (
+	"context"
+	"maps"
+)
+
+type queryTagKey struct{}
+
+// WithQueryTag returns a context whose queries and statements are tagged with the given key-value pairs, e.g. for attributing warehouse costs per customer or job:
+//
+//	ctx = sqlconnect.WithQueryTag(ctx, map[string]string{"customer": "acme", "job": "daily-sync"})
+//
+// Each warehouse maps the tags to its native mechanism, i.e. Snowflake's QUERY_TAG, BigQuery's job labels, Redshift's query_group and Databricks' query_tags,
+// falling back to a leading sql comment where no native mechanism exists, using the [sqlcommenter] format.
+// Redshift's query_group and Databricks' query_tags are set on the session, thus they only tag statements executed using ExecContext,
+// whereas queries are tagged using a leading sql comment instead, since their session cannot be reset before their rows are read.
+// Tags already present in the context are retained, unless overridden by the given tags.
+// Queries and statements performed using a [sql.Conn] or a [sql.Tx] are not tagged.
+//
+// [sqlcommenter]: https://google.github.io/sqlcommenter/spec/
+func WithQueryTag(ctx context.Context, tags map[string]string) context.Context {
+	merged := maps.Clone(QueryTag(ctx))
+	if merged == nil {
+		merged = make(map[string]string, len(tags))
+	}
+	maps.Copy(merged, tags)
+	return context.WithValue(ctx, queryTagKey{}, merged)
+}
+
+// QueryTag returns the query tags of the context, as set by [WithQueryTag]. The returned map must not be modified.
+func QueryTag(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(queryTagKey{}).(map[string]string)
+	return tags
+}
@@ -0,0 +1,20 @@
+package sqlconnect_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestWithQueryTag(t *testing.T) {
+	ctx := context.Background()
+	require.Nil(t, sqlconnect.QueryTag(ctx))
+
+	parent := sqlconnect.WithQueryTag(ctx, map[string]string{"customer": "acme", "job": "sync"})
+	child := sqlconnect.WithQueryTag(parent, map[string]string{"job": "model", "model": "churn"})
+	require.Equal(t, map[string]string{"customer": "acme", "job": "model", "model": "churn"}, sqlconnect.QueryTag(child), "it should merge the tags, overriding existing ones")
+	require.Equal(t, map[string]string{"customer": "acme", "job": "sync"}, sqlconnect.QueryTag(parent), "it should not modify the parent's tags")
+}
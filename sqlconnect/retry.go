@@ -0,0 +1,48 @@
+package sqlconnect
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy configures how a [DB] retries operations that fail with [ErrRetryable] errors, using an exponential backoff between attempts.
+//
+// Only operations known to be idempotent are retried, i.e. metadata reads, read-only queries (e.g. SELECT, SHOW or DESCRIBE), pings and acquiring connections or transactions.
+// Other operations are only retried if the caller opts in using [WithIdempotent].
+// Errors returned while iterating over the rows of a result set, or while using a [sql.Row], [sql.Tx] or [sql.Conn], are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times an operation is attempted, including the first attempt. A value less than 2 disables retries.
+	MaxAttempts int `json:"maxAttempts"`
+	// InitialInterval is the time to wait before the first retry. Defaults to 500ms if not provided.
+	InitialInterval time.Duration `json:"initialInterval"`
+	// MaxInterval caps the time to wait between retries. Defaults to 60s if not provided.
+	MaxInterval time.Duration `json:"maxInterval"`
+	// Multiplier is the factor by which the time to wait is increased after each retry. Defaults to 1.5 if not provided.
+	Multiplier float64 `json:"multiplier"`
+	// MaxElapsedTime is the maximum time spent retrying an operation, after which the last error is returned. Zero means no limit besides [RetryPolicy.MaxAttempts].
+	MaxElapsedTime time.Duration `json:"maxElapsedTime"`
+}
+
+// DefaultRetryPolicy is a sensible retry policy for most workloads. Retries are disabled unless a policy is set using [DB.SetRetryPolicy].
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:     5,
+	InitialInterval: 500 * time.Millisecond,
+	MaxInterval:     10 * time.Second,
+	Multiplier:      2,
+	MaxElapsedTime:  time.Minute,
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent returns a context marking the operations performed with it as idempotent, allowing the [DB] to retry them according to its [RetryPolicy], e.g. for retrying a MERGE statement
+//
+//	_, err := db.ExecContext(sqlconnect.WithIdempotent(ctx), mergeStmt)
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// IsIdempotent returns true if the context has been marked using [WithIdempotent]
+func IsIdempotent(ctx context.Context) bool {
+	idempotent, _ := ctx.Value(idempotentKey{}).(bool)
+	return idempotent
+}
@@ -0,0 +1,58 @@
+package sqlconnect
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// QueryJSONMapSeq returns an iterator executing a query and yielding its results as maps, or an error, see [QuerySeq]
+func QueryJSONMapSeq(ctx context.Context, db JsonQueryDB, query string, params ...any) iter.Seq2[map[string]any, error] {
+	return QuerySeq[map[string]any](ctx, db, db.JSONRowMapper(), query, params...)
+}
+
+// QuerySeq returns an iterator executing a query and yielding its results, or an error after which iteration stops.
+// The query is executed each time the iterator is ranged over, in the caller's goroutine.
+// Breaking out of the loop early cancels the query in the warehouse and closes its rows:
+//
+//	for row, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT * FROM events") {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func QuerySeq[T any](ctx context.Context, db QueryDB, mapper RowMapper[T], query string, params ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		rows, err := db.QueryContext(ctx, query, params...)
+		if err != nil {
+			yield(zero, fmt.Errorf("executing query: %w", err))
+			return
+		}
+		defer func() { _ = rows.Close() }()
+		var returned int64
+		defer func() { recordReturnedRows(ctx, db, query, returned) }()
+		cols, err := rows.ColumnTypes()
+		if err != nil {
+			yield(zero, fmt.Errorf("getting column types: %w", err))
+			return
+		}
+		for rows.Next() {
+			v, err := mapper(cols, rows)
+			if err != nil {
+				yield(zero, fmt.Errorf("mapping row: %w", err))
+				return
+			}
+			returned++ // counting the row before yielding it, since yield doesn't return if the caller breaks
+			if !yield(v, nil) {
+				cancel() // canceling the query before closing its rows, so that drivers don't read its remaining rows
+				return
+			}
+		}
+		if err := rows.Err(); err != nil {
+			err = classifyError(db, err) // errors surfacing while iterating haven't been classified yet
+			yield(zero, fmt.Errorf("iterating rows: %w", err))
+		}
+	}
+}
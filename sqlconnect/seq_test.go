@@ -0,0 +1,128 @@
+package sqlconnect_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestQuerySeq(t *testing.T) {
+	ctx := context.Background()
+	mapper := func(_ []*sql.ColumnType, row sqlconnect.RowScan) (v int64, err error) {
+		err = row.Scan(&v)
+		return v, err
+	}
+
+	t.Run("all rows", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 3})
+		defer func() { _ = db.Close() }()
+
+		var values []int64
+		for v, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			require.NoError(t, err)
+			values = append(values, v)
+		}
+		require.Equal(t, []int64{1, 2, 3}, values)
+		require.Zero(t, db.Stats().InUse, "it should close the rows")
+	})
+
+	t.Run("records returned rows", func(t *testing.T) {
+		db := &recordingDB{DB: sql.OpenDB(&countingConnector{rows: 3})}
+		defer func() { _ = db.Close() }()
+
+		for _, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			require.NoError(t, err)
+		}
+		require.Equal(t, []int64{3}, db.returned, "it should record the number of rows returned once")
+	})
+
+	t.Run("records returned rows with break", func(t *testing.T) {
+		db := &recordingDB{DB: sql.OpenDB(&countingConnector{rows: 100})}
+		defer func() { _ = db.Close() }()
+
+		for v, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			require.NoError(t, err)
+			if v == 2 {
+				break
+			}
+		}
+		require.Equal(t, []int64{2}, db.returned, "it should count the row the caller breaks at")
+	})
+
+	t.Run("with break", func(t *testing.T) {
+		connector := &countingConnector{rows: 100}
+		db := sql.OpenDB(connector)
+		defer func() { _ = db.Close() }()
+
+		for v, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			require.NoError(t, err)
+			if v == 2 {
+				break
+			}
+		}
+		require.Zero(t, db.Stats().InUse, "it should close the rows")
+		require.ErrorIs(t, connector.lastCtx.Err(), context.Canceled, "it should cancel the query")
+	})
+
+	t.Run("with error", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 1, err: io.ErrUnexpectedEOF})
+		defer func() { _ = db.Close() }()
+
+		var values []int64
+		var errs []error
+		for v, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			values = append(values, v)
+		}
+		require.Equal(t, []int64{1}, values)
+		require.Len(t, errs, 1, "it should stop after the error")
+		require.ErrorIs(t, errs[0], io.ErrUnexpectedEOF)
+	})
+
+	t.Run("with context cancelled", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 1})
+		defer func() { _ = db.Close() }()
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+
+		var iterations int
+		for _, err := range sqlconnect.QuerySeq(ctx, db, mapper, "SELECT") {
+			iterations++
+			require.ErrorIs(t, err, context.Canceled)
+		}
+		require.Equal(t, 1, iterations, "it should only iterate once")
+	})
+
+	t.Run("executed on each iteration", func(t *testing.T) {
+		db := sql.OpenDB(&countingConnector{rows: 2})
+		defer func() { _ = db.Close() }()
+
+		seq := sqlconnect.QuerySeq(ctx, db, mapper, "SELECT")
+		for range 2 {
+			var values []int64
+			for v, err := range seq {
+				require.NoError(t, err)
+				values = append(values, v)
+			}
+			require.Equal(t, []int64{1, 2}, values)
+		}
+	})
+}
+
+// recordingDB records the number of rows returned by queries, like the package's DBs do
+type recordingDB struct {
+	*sql.DB
+	returned []int64
+}
+
+func (db *recordingDB) RecordReturnedRows(_ context.Context, _ string, rows int64) {
+	db.returned = append(db.returned, rows)
+}
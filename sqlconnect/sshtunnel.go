@@ -0,0 +1,38 @@
+package sqlconnect
+
+import "time"
+
+// SSHTunnel is the ssh tunnel a [DB] connects to its warehouse through, as configured by the tunnel_info of its credentials or by useSSH
+type SSHTunnel interface {
+	// HostKeyFingerprint returns the SHA256 fingerprint of the host key presented by the tunnel's ssh server, as printed by ssh-keygen -l,
+	// e.g. for pinning it using sshHostKeyFingerprint after trusting it on first use
+	HostKeyFingerprint() string
+	// JumpHostKeyFingerprints returns the SHA256 fingerprints of the host keys presented by the jump hosts of the tunnel, in order
+	JumpHostKeyFingerprints() []string
+	// Status returns the status of the tunnel's connection to its ssh server, which is re-established transparently whenever it is lost
+	Status() SSHTunnelStatus
+	// OnStatusChange sets the function called whenever the status of the tunnel changes, e.g. when it loses its connection, fails to reconnect or reconnects,
+	// for reporting its health. It replaces any function set previously, while a nil function stops the notifications.
+	OnStatusChange(fn func(SSHTunnelStatus))
+}
+
+// SSHTunnelState is the state of an ssh tunnel's connection to its ssh server
+type SSHTunnelState string
+
+const (
+	// SSHTunnelStateConnected means the tunnel is connected to its ssh server
+	SSHTunnelStateConnected SSHTunnelState = "connected"
+	// SSHTunnelStateReconnecting means the tunnel lost its connection to its ssh server and is trying to re-establish it
+	SSHTunnelStateReconnecting SSHTunnelState = "reconnecting"
+	// SSHTunnelStateClosed means the tunnel has been closed
+	SSHTunnelStateClosed SSHTunnelState = "closed"
+)
+
+// SSHTunnelStatus is the status of an ssh tunnel
+type SSHTunnelStatus struct {
+	State      SSHTunnelState // the state of the tunnel's connection to its ssh server
+	Since      time.Time      // when the tunnel entered its state
+	Err        error          // while reconnecting, the error of the last reconnection attempt, or the one that caused the connection to be lost
+	Reconnects int            // the number of times the tunnel reconnected since it was opened
+	ForwardErr error          // the error of the last local connection that the tunnel failed to forward to its remote address, if any
+}
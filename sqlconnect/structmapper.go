@@ -0,0 +1,298 @@
+package sqlconnect
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// StructMapperDB is the subset of [DB] used by [StructRowMapper]
+type StructMapperDB interface {
+	JsonRowMapper
+	NormaliseIdentifier(identifier string) string
+}
+
+// StructRowMapper returns a row mapper that maps rows to structs of type T, after applying the db's json value conversions, e.g. decoding snowflake's VARIANT columns.
+//
+// Exported fields are mapped to the column named by their `sqlconnect:"column"` tag, or to the column named after the field, normalised using [Dialect.NormaliseIdentifier].
+// Fields tagged with `sqlconnect:"-"` are ignored, as well as columns without a field. Mapping a row fails if a field's column is missing or its value cannot be assigned to the field:
+//   - numbers are converted to numeric fields of any size as long as they fit, and so are numeric strings.
+//   - null values are only assigned to pointers, slices, maps, interfaces and [sql.Scanner] fields.
+//   - json values are decoded into struct, slice and map fields.
+//   - RFC 3339 strings are parsed into [time.Time] fields.
+//
+// E.g. using a struct with a tagged field:
+//
+//	type event struct {
+//		ID         int64     `sqlconnect:"id"`
+//		Properties map[string]any
+//		SentAt     *time.Time `sqlconnect:"sent_at"`
+//	}
+//	for e, err := range sqlconnect.QuerySeq(ctx, db, sqlconnect.StructRowMapper[event](db), "SELECT * FROM events") {
+//	}
+func StructRowMapper[T any](db StructMapperDB) RowMapper[T] {
+	fields, fieldsErr := structFields(reflect.TypeFor[T](), db.NormaliseIdentifier)
+	jsonRowMapper := db.JSONRowMapper()
+	return func(cols []*sql.ColumnType, row RowScan) (T, error) {
+		var v T
+		if fieldsErr != nil {
+			return v, fieldsErr
+		}
+		values, err := jsonRowMapper(cols, row)
+		if err != nil {
+			return v, err
+		}
+		rv := reflect.ValueOf(&v).Elem()
+		for _, field := range fields {
+			value, ok := values[field.column]
+			if !ok {
+				return v, fmt.Errorf("missing column %q for field %s", field.column, field.name)
+			}
+			if err := assignValue(rv.FieldByIndex(field.index), value); err != nil {
+				return v, fmt.Errorf("assigning column %q to field %s: %w", field.column, field.name, err)
+			}
+		}
+		return v, nil
+	}
+}
+
+// structField is a field of a struct mapped to a column
+type structField struct {
+	name   string
+	index  []int
+	column string
+}
+
+// structFields returns the exported fields of a struct type, including the ones promoted from embedded structs, along with their columns
+func structFields(t reflect.Type, normalise func(string) string) ([]structField, error) {
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("mapping rows to %s: not a struct", t)
+	}
+	var fields []structField
+	columns := map[string]string{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && f.Type.Kind() == reflect.Struct) {
+			continue
+		}
+		column := f.Tag.Get("sqlconnect")
+		switch column {
+		case "-":
+			continue
+		case "":
+			column = normalise(f.Name)
+		}
+		if other, ok := columns[column]; ok {
+			return nil, fmt.Errorf("mapping rows to %s: fields %s and %s are both mapped to column %q", t, other, f.Name, column)
+		}
+		columns[column] = f.Name
+		fields = append(fields, structField{name: f.Name, index: f.Index, column: column})
+	}
+	return fields, nil
+}
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+)
+
+// assignValue assigns a value, as returned by a json row mapper, to a field
+func assignValue(field reflect.Value, value any) error {
+	if field.CanAddr() && field.Addr().Type().Implements(scannerType) {
+		return field.Addr().Interface().(sql.Scanner).Scan(value)
+	}
+	if value == nil {
+		switch field.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+			field.SetZero()
+			return nil
+		default:
+			return fmt.Errorf("cannot assign null to %s, use a pointer instead", field.Type())
+		}
+	}
+	if field.Kind() == reflect.Pointer {
+		elem := reflect.New(field.Type().Elem())
+		if err := assignValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Type().AssignableTo(field.Type()) {
+		field.Set(rv)
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return assignInt(field, value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return assignUint(field, value)
+	case reflect.Float32, reflect.Float64:
+		return assignFloat(field, value)
+	case reflect.String:
+		switch v := value.(type) {
+		case []byte:
+			field.SetString(string(v))
+			return nil
+		case json.RawMessage:
+			field.SetString(string(v))
+			return nil
+		}
+		if rv.Kind() == reflect.String {
+			field.SetString(rv.String())
+			return nil
+		}
+	case reflect.Bool:
+		switch v := value.(type) {
+		case bool:
+			field.SetBool(v)
+			return nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+			return nil
+		}
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		if field.Type() == timeType {
+			if s, ok := value.(string); ok {
+				t, err := time.Parse(time.RFC3339Nano, s)
+				if err != nil {
+					return fmt.Errorf("cannot assign %q to %s: %w", s, field.Type(), err)
+				}
+				field.Set(reflect.ValueOf(t))
+				return nil
+			}
+			break
+		}
+		if field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.Uint8 {
+			switch v := value.(type) {
+			case string:
+				field.SetBytes([]byte(v))
+				return nil
+			case json.RawMessage:
+				field.SetBytes([]byte(v))
+				return nil
+			}
+		}
+		return assignJSON(field, value)
+	}
+	return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+}
+
+// assignJSON decodes a json value into a field, e.g. a snowflake VARIANT into a struct
+func assignJSON(field reflect.Value, value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case json.RawMessage:
+		b = v
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		var err error
+		if b, err = json.Marshal(v); err != nil {
+			return fmt.Errorf("marshalling %T: %w", value, err)
+		}
+	}
+	if err := json.Unmarshal(b, field.Addr().Interface()); err != nil {
+		return fmt.Errorf("cannot assign %T to %s: %w", value, field.Type(), err)
+	}
+	return nil
+}
+
+func assignInt(field reflect.Value, value any) error {
+	var n int64
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanInt():
+		n = rv.Int()
+	case rv.CanUint():
+		if rv.Uint() > math.MaxInt64 {
+			return fmt.Errorf("cannot assign %d to %s: out of range", rv.Uint(), field.Type())
+		}
+		n = int64(rv.Uint())
+	case rv.CanFloat():
+		f := rv.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return fmt.Errorf("cannot assign %v to %s: not an integer", f, field.Type())
+		}
+		n = int64(f)
+	case rv.Kind() == reflect.String:
+		var err error
+		if n, err = strconv.ParseInt(rv.String(), 10, 64); err != nil {
+			return fmt.Errorf("cannot assign %q to %s: %w", rv.String(), field.Type(), err)
+		}
+	default:
+		return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+	}
+	if field.OverflowInt(n) {
+		return fmt.Errorf("cannot assign %d to %s: out of range", n, field.Type())
+	}
+	field.SetInt(n)
+	return nil
+}
+
+func assignUint(field reflect.Value, value any) error {
+	var n uint64
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanUint():
+		n = rv.Uint()
+	case rv.CanInt():
+		if rv.Int() < 0 {
+			return fmt.Errorf("cannot assign %d to %s: out of range", rv.Int(), field.Type())
+		}
+		n = uint64(rv.Int())
+	case rv.CanFloat():
+		f := rv.Float()
+		if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 {
+			return fmt.Errorf("cannot assign %v to %s: not an unsigned integer", f, field.Type())
+		}
+		n = uint64(f)
+	case rv.Kind() == reflect.String:
+		var err error
+		if n, err = strconv.ParseUint(rv.String(), 10, 64); err != nil {
+			return fmt.Errorf("cannot assign %q to %s: %w", rv.String(), field.Type(), err)
+		}
+	default:
+		return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+	}
+	if field.OverflowUint(n) {
+		return fmt.Errorf("cannot assign %d to %s: out of range", n, field.Type())
+	}
+	field.SetUint(n)
+	return nil
+}
+
+func assignFloat(field reflect.Value, value any) error {
+	var f float64
+	rv := reflect.ValueOf(value)
+	switch {
+	case rv.CanFloat():
+		f = rv.Float()
+	case rv.CanInt():
+		f = float64(rv.Int())
+	case rv.CanUint():
+		f = float64(rv.Uint())
+	case rv.Kind() == reflect.String:
+		var err error
+		if f, err = strconv.ParseFloat(rv.String(), 64); err != nil {
+			return fmt.Errorf("cannot assign %q to %s: %w", rv.String(), field.Type(), err)
+		}
+	default:
+		return fmt.Errorf("cannot assign %T to %s", value, field.Type())
+	}
+	if field.OverflowFloat(f) {
+		return fmt.Errorf("cannot assign %v to %s: out of range", f, field.Type())
+	}
+	field.SetFloat(f)
+	return nil
+}
@@ -0,0 +1,141 @@
+package sqlconnect_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rudderlabs/sqlconnect-go/sqlconnect"
+)
+
+func TestStructRowMapper(t *testing.T) {
+	type Embedded struct {
+		Source string
+	}
+	type properties struct {
+		Plan string `json:"plan"`
+	}
+	type event struct {
+		Embedded
+		ID         int64 `sqlconnect:"id"`
+		Name       string
+		Count      int32
+		Score      float32
+		Active     bool
+		SentAt     *time.Time
+		ReceivedAt time.Time
+		Properties properties
+		Tags       []string
+		Raw        json.RawMessage
+		Comment    sql.NullString
+		Any        any
+		Ignored    string `sqlconnect:"-"`
+		unexported string
+	}
+	sentAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := map[string]any{
+		"SOURCE":     "web",
+		"id":         int64(1),
+		"NAME":       []byte("signup"),
+		"COUNT":      "2",
+		"SCORE":      1.5,
+		"ACTIVE":     true,
+		"SENTAT":     sentAt,
+		"RECEIVEDAT": "2024-01-02T03:04:05Z",
+		"PROPERTIES": map[string]any{"plan": "pro"},
+		"TAGS":       json.RawMessage(`["a","b"]`),
+		"RAW":        []any{1.0},
+		"COMMENT":    nil,
+		"ANY":        "any",
+		"EXTRA":      "ignored",
+	}
+
+	mapper := sqlconnect.StructRowMapper[event](fakeStructMapperDB{})
+	e, err := mapper(nil, fakeRowScan{row})
+	require.NoError(t, err)
+	require.Equal(t, event{
+		Embedded:   Embedded{Source: "web"},
+		ID:         1,
+		Name:       "signup",
+		Count:      2,
+		Score:      1.5,
+		Active:     true,
+		SentAt:     &sentAt,
+		ReceivedAt: sentAt,
+		Properties: properties{Plan: "pro"},
+		Tags:       []string{"a", "b"},
+		Raw:        json.RawMessage(`[1]`),
+		Any:        "any",
+	}, e)
+
+	t.Run("with missing column", func(t *testing.T) {
+		type missing struct {
+			Missing string
+		}
+		_, err := sqlconnect.StructRowMapper[missing](fakeStructMapperDB{})(nil, fakeRowScan{row})
+		require.ErrorContains(t, err, `missing column "MISSING" for field Missing`)
+	})
+
+	t.Run("with type mismatch", func(t *testing.T) {
+		for _, tc := range []struct {
+			name  string
+			value any
+			err   string
+		}{
+			{name: "string to int", value: "one", err: `assigning column "VALUE" to field Value: cannot assign "one" to int8`},
+			{name: "overflow", value: int64(128), err: "cannot assign 128 to int8: out of range"},
+			{name: "fractional", value: 1.5, err: "cannot assign 1.5 to int8: not an integer"},
+			{name: "null", value: nil, err: "cannot assign null to int8, use a pointer instead"},
+			{name: "bool", value: true, err: "cannot assign bool to int8"},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				type mismatch struct {
+					Value int8
+				}
+				_, err := sqlconnect.StructRowMapper[mismatch](fakeStructMapperDB{})(nil, fakeRowScan{map[string]any{"VALUE": tc.value}})
+				require.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+
+	t.Run("with unsupported type", func(t *testing.T) {
+		_, err := sqlconnect.StructRowMapper[string](fakeStructMapperDB{})(nil, fakeRowScan{row})
+		require.ErrorContains(t, err, "mapping rows to string: not a struct")
+	})
+
+	t.Run("with duplicate columns", func(t *testing.T) {
+		type duplicate struct {
+			Name  string
+			Other string `sqlconnect:"NAME"`
+		}
+		_, err := sqlconnect.StructRowMapper[duplicate](fakeStructMapperDB{})(nil, fakeRowScan{row})
+		require.ErrorContains(t, err, `fields Name and Other are both mapped to column "NAME"`)
+	})
+}
+
+// fakeStructMapperDB uppercases identifiers and maps rows scanned by [fakeRowScan] as they are
+type fakeStructMapperDB struct{}
+
+func (fakeStructMapperDB) NormaliseIdentifier(identifier string) string {
+	return strings.ToUpper(identifier)
+}
+
+func (fakeStructMapperDB) JSONRowMapper() sqlconnect.RowMapper[map[string]any] {
+	return func(_ []*sql.ColumnType, row sqlconnect.RowScan) (m map[string]any, err error) {
+		err = row.Scan(&m)
+		return m, err
+	}
+}
+
+type fakeRowScan struct {
+	values map[string]any
+}
+
+func (r fakeRowScan) Scan(dest ...any) error {
+	*dest[0].(*map[string]any) = r.values
+	return nil
+}
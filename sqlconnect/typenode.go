@@ -0,0 +1,41 @@
+package sqlconnect
+
+// TypeKind is the kind of a [TypeNode]
+type TypeKind string
+
+const (
+	TypeKindScalar  TypeKind = "scalar"  // a type without any nested types, e.g. INT64 or STRING
+	TypeKindArray   TypeKind = "array"   // an array of elements of the same type, e.g. ARRAY<STRING>
+	TypeKindStruct  TypeKind = "struct"  // a record of named fields, e.g. STRUCT<a INT64, b STRING>, row(a integer) or bigquery's RECORD
+	TypeKindMap     TypeKind = "map"     // a map of keys to values, e.g. MAP<STRING, INT>
+	TypeKindVariant TypeKind = "variant" // a semi-structured value without a declared schema, e.g. snowflake's VARIANT or redshift's SUPER
+)
+
+// TypeNode is a node in the parsed type tree of a nested column, describing its element types, field names and nullability
+type TypeNode struct {
+	Kind     TypeKind   `json:"kind"`
+	RawType  string     `json:"rawType"`           // the database type of the node, e.g. ARRAY<STRUCT<a INT64>>
+	Name     string     `json:"name,omitempty"`    // the name of the field, only set for fields of a struct
+	Nullable bool       `json:"nullable"`          // whether the node accepts null values
+	Element  *TypeNode  `json:"element,omitempty"` // the type of the elements of an array
+	Key      *TypeNode  `json:"key,omitempty"`     // the type of the keys of a map
+	Value    *TypeNode  `json:"value,omitempty"`   // the type of the values of a map
+	Fields   []TypeNode `json:"fields,omitempty"`  // the fields of a struct, in their declared order
+}
+
+// FieldPaths returns the dot-separated paths of all struct fields reachable from the node, e.g. [a a.b a.c] for STRUCT<a STRUCT<b INT64, c STRING>>.
+// Fields nested inside arrays or maps are not included, since selecting them requires indexing into the array or map first.
+func (n *TypeNode) FieldPaths() []string {
+	if n == nil || n.Kind != TypeKindStruct {
+		return nil
+	}
+	var paths []string
+	for i := range n.Fields {
+		field := &n.Fields[i]
+		paths = append(paths, field.Name)
+		for _, path := range field.FieldPaths() {
+			paths = append(paths, field.Name+"."+path)
+		}
+	}
+	return paths
+}